@@ -1,12 +1,14 @@
 package api
 
 import (
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
 	"github.com/noah-blockchain/noah-go-node/core/types"
 )
 
 type AddressResponse struct {
-	Balance          []BalanceItem `json:"balances"`
-	TransactionCount uint64        `json:"transaction_count"`
+	Balance          []BalanceItem        `json:"balances"`
+	TransactionCount uint64               `json:"transaction_count"`
+	Proof            *proofs.AddressProof `json:"proof,omitempty"`
 }
 
 type BalanceItem struct {
@@ -20,7 +22,11 @@ type Coin struct {
 	Symbol string `json:"symbol"`
 }
 
-func Address(address types.Address, height int) (*AddressResponse, error) {
+func Address(address types.Address, height int, prove bool) (*AddressResponse, error) {
+	if prove {
+		height = provenHeight(height)
+	}
+
 	cState, err := GetStateForHeight(height)
 	if err != nil {
 		return nil, err
@@ -57,5 +63,17 @@ func Address(address types.Address, height int) (*AddressResponse, error) {
 		})
 	}
 
+	if prove {
+		stateTree, err := blockchain.GetImmutableTreeAtHeight(uint64(height))
+		if err != nil {
+			return nil, err
+		}
+
+		response.Proof, err = proofs.NewAddressProof(stateTree, address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &response, nil
 }
@@ -1,15 +1,22 @@
 package api
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"github.com/noah-blockchain/noah-go-node/api/auth"
 	"github.com/noah-blockchain/noah-go-node/config"
 	"github.com/noah-blockchain/noah-go-node/core/noah"
 	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
 	"github.com/noah-blockchain/noah-go-node/rpc/lib/server"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
 	"github.com/rs/cors"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/libs/log"
 	rpc "github.com/tendermint/tendermint/rpc/client/local"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -26,17 +33,19 @@ var (
 var Routes = map[string]*rpcserver.RPCFunc{
 	"status":                 rpcserver.NewRPCFunc(Status, ""),
 	"candidates":             rpcserver.NewRPCFunc(Candidates, "height,include_stakes"),
-	"candidate":              rpcserver.NewRPCFunc(Candidate, "pub_key,height"),
+	"candidate":              rpcserver.NewRPCFunc(Candidate, "pub_key,height,prove"),
 	"validators":             rpcserver.NewRPCFunc(Validators, "height"),
-	"address":                rpcserver.NewRPCFunc(Address, "address,height"),
+	"address":                rpcserver.NewRPCFunc(Address, "address,height,prove"),
 	"addresses":              rpcserver.NewRPCFunc(Addresses, "addresses,height"),
 	"send_transaction":       rpcserver.NewRPCFunc(SendTransaction, "tx"),
 	"transaction":            rpcserver.NewRPCFunc(Transaction, "hash"),
 	"transactions":           rpcserver.NewRPCFunc(Transactions, "query,page,perPage"),
 	"block":                  rpcserver.NewRPCFunc(Block, "height"),
+	"light_block":            rpcserver.NewRPCFunc(LightBlock, "height"),
 	"events":                 rpcserver.NewRPCFunc(Events, "height"),
 	"net_info":               rpcserver.NewRPCFunc(NetInfo, ""),
-	"coin_info":              rpcserver.NewRPCFunc(CoinInfo, "symbol,id,height"),
+	"coin_info":              rpcserver.NewRPCFunc(CoinInfo, "symbol,id,height,prove"),
+	"coin_history":           rpcserver.NewRPCFunc(CoinHistory, "id,from,to,interval"),
 	"estimate_coin_sell":     rpcserver.NewRPCFunc(EstimateCoinSell, "coin_to_sell,coin_to_buy,value_to_sell,height"),
 	"estimate_coin_sell_all": rpcserver.NewRPCFunc(EstimateCoinSellAll, "coin_to_sell,coin_to_buy,value_to_sell,height"),
 	"estimate_coin_buy":      rpcserver.NewRPCFunc(EstimateCoinBuy, "coin_to_sell,coin_to_buy,value_to_buy,height"),
@@ -44,9 +53,17 @@ var Routes = map[string]*rpcserver.RPCFunc{
 	"unconfirmed_txs":        rpcserver.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"max_gas":                rpcserver.NewRPCFunc(MaxGas, "height"),
 	"min_gas_price":          rpcserver.NewRPCFunc(MinGasPrice, ""),
+	"min_gas_prices":         rpcserver.NewRPCFunc(MinGasPrices, ""),
 	"genesis":                rpcserver.NewRPCFunc(Genesis, ""),
 	"missed_blocks":          rpcserver.NewRPCFunc(MissedBlocks, "pub_key,height"),
+	"evidence":               rpcserver.NewRPCFunc(Evidence, "pub_key"),
 	"waitlist":               rpcserver.NewRPCFunc(Waitlist, "pub_key,address,height"),
+	"check":                  rpcserver.NewRPCFunc(Check, "check,height"),
+	"checks":                 rpcserver.NewRPCFunc(Checks, "issuer,page,perPage"),
+	"indexed_transactions":   rpcserver.NewRPCFunc(IndexedTransactions, "from,type,code,from_height,to_height,order,page,perPage"),
+	"indexed_events":         rpcserver.NewRPCFunc(IndexedEvents, "address,pub_key,type,from_height,to_height,order,page,perPage"),
+	"balance_changes":        rpcserver.NewRPCFunc(BalanceChanges, "address,coin_id,from_height,to_height,order,page,perPage"),
+	"address_transactions":   rpcserver.NewRPCFunc(AddressTransactions, "address,role,order,page,perPage"),
 }
 
 func responseTime(b *noah.Blockchain) func(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
@@ -60,16 +77,27 @@ func responseTime(b *noah.Blockchain) func(f func(http.ResponseWriter, *http.Req
 }
 
 // RunAPI start
-func RunAPI(codec *amino.Codec, b *noah.Blockchain, tmRPC *rpc.Local, cfg *config.Config, logger log.Logger) {
+func RunAPI(codec *amino.Codec, b *noah.Blockchain, tmRPC *rpc.Local, cfg *config.Config, apiAuth *auth.Auth, tlsConfig *tls.Config, logger log.Logger) {
 	cdc = codec
 	noahCfg = cfg
 	client = tmRPC
 	blockchain = b
 	waitForTendermint()
 
+	var err error
+	subscriptions, err = startSubscriptions(logger.With("module", "rpc"))
+	if err != nil {
+		panic(err)
+	}
+
 	m := http.NewServeMux()
 
-	rpcserver.RegisterRPCFuncs(m, Routes, cdc, logger.With("module", "rpc"), responseTime(b))
+	rpcserver.RegisterRPCFuncs(m, Routes, cdc, logger.With("module", "rpc"), cfg.APIMaxBatchSize, responseTime(b))
+
+	wm := rpcserver.NewWebsocketManager(WebsocketRoutes, cdc, rpcserver.EventSubscriber(subscriptions))
+	wm.SetLogger(logger.With("module", "rpc", "protocol", "websocket"))
+	m.HandleFunc("/websocket", wm.WebsocketHandler)
+
 	listener, err := rpcserver.Listen(cfg.APIListenAddress, rpcserver.Config{
 		MaxOpenConnections: cfg.APISimultaneousRequests,
 	})
@@ -78,13 +106,18 @@ func RunAPI(codec *amino.Codec, b *noah.Blockchain, tmRPC *rpc.Local, cfg *confi
 		panic(err)
 	}
 
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"POST", "GET"},
+		AllowedHeaders:   []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "Authorization"},
 		AllowCredentials: true,
 	})
 
-	handler := c.Handler(m)
+	handler := c.Handler(authHandler(apiAuth, m))
 	logger.Error("Failed to start API", "err", rpcserver.StartHTTPServer(listener, Handler(handler), logger))
 }
 
@@ -113,6 +146,81 @@ func Handler(h http.Handler) http.Handler {
 	})
 }
 
+// authHandler rejects requests without valid API key or token, methods of JSON-RPC requests are read from their bodies
+// after the client is authenticated. Requests are not checked if apiAuth is nil.
+func authHandler(apiAuth *auth.Auth, h http.Handler) http.Handler {
+	if apiAuth == nil {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := apiAuth.Authenticate(r.Header.Get("Authorization")); err != nil {
+			writeAuthError(w, err)
+			return
+		}
+
+		methods := []string{strings.TrimPrefix(r.URL.Path, "/")}
+		switch {
+		case methods[0] == "websocket":
+			// only subscriptions are served by websocket
+			methods[0] = "subscribe"
+		case methods[0] == "" && r.Method == http.MethodPost:
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, rpcserver.MaxBodyBytes))
+			if err != nil {
+				rpcserver.WriteRPCResponseHTTPError(w, http.StatusBadRequest, rpctypes.RPCInvalidRequestError(rpctypes.JSONRPCStringID(""), err))
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			methods = jsonRPCMethods(body)
+		}
+
+		for _, method := range methods {
+			if _, err := apiAuth.Authorize(r.Header.Get("Authorization"), method); err != nil {
+				writeAuthError(w, err)
+				return
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// jsonRPCMethods returns methods of the JSON-RPC request or of every request of the batch
+func jsonRPCMethods(body []byte) []string {
+	type request struct {
+		Method string `json:"method"`
+	}
+
+	var batch []request
+	if err := json.Unmarshal(body, &batch); err == nil && len(batch) != 0 {
+		methods := make([]string, 0, len(batch))
+		for _, r := range batch {
+			methods = append(methods, r.Method)
+		}
+		return methods
+	}
+
+	var single request
+	_ = json.Unmarshal(body, &single)
+	return []string{single.Method}
+}
+
+func writeAuthError(w http.ResponseWriter, err error) {
+	code := authStatusCode(err)
+	rpcserver.WriteRPCResponseHTTPError(w, code, rpctypes.NewRPCErrorResponse(rpctypes.JSONRPCStringID(""), code, err.Error(), ""))
+}
+
+func authStatusCode(err error) int {
+	switch err {
+	case auth.ErrMethodNotAllowed:
+		return http.StatusForbidden
+	case auth.ErrRateLimited:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusUnauthorized
+	}
+}
+
 func waitForTendermint() {
 	for {
 		_, err := client.Health()
@@ -130,12 +238,20 @@ type Response struct {
 	Log    string      `json:"log,omitempty"`
 }
 
+// suggestedValidUntil returns expiry height suggested for transactions sent now
+func suggestedValidUntil() uint64 {
+	return blockchain.Height() + transaction.DefaultValidityPeriod
+}
+
 func GetStateForHeight(height int) (*state.CheckState, error) {
-	if height > 0 {
-		cState, err := blockchain.GetStateForHeight(uint64(height))
+	return blockchain.GetStateForHeight(uint64(height))
+}
 
-		return cState, err
+// provenHeight returns height of committed state to prove values of, current state is not committed yet
+func provenHeight(height int) int {
+	if height == 0 {
+		return int(blockchain.LastCommittedHeight())
 	}
 
-	return blockchain.CurrentState(), nil
+	return height
 }
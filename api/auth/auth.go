@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Errors of authorization, API servers map them to their status codes
+var (
+	ErrUnauthenticated  = errors.New("API key or token is missing or invalid")
+	ErrMethodNotAllowed = errors.New("method is not allowed for the API key")
+	ErrRateLimited      = errors.New("rate limit of the API key is exceeded")
+)
+
+// quotaWindow is the period of requests quota of the client
+const quotaWindow = time.Minute
+
+type client struct {
+	name    string
+	limit   int
+	methods map[string]bool
+
+	windowStart time.Time
+	requests    int
+}
+
+// Auth checks API keys and JWT bearer tokens of requests and counts requests against quotas of their clients.
+// Quotas are shared by API v1 and v2.
+type Auth struct {
+	byKey     map[string]*client
+	byName    map[string]*client
+	jwtSecret []byte
+
+	requests *prometheus.CounterVec
+
+	lock sync.Mutex
+}
+
+// NewAuth creates Auth with API keys of the config, returns nil if no keys are set and access to API is not restricted
+func NewAuth(cfg *config.Config) (*Auth, error) {
+	if len(cfg.APIKeys) == 0 {
+		return nil, nil
+	}
+
+	a := &Auth{
+		byKey:     map[string]*client{},
+		byName:    map[string]*client{},
+		jwtSecret: []byte(cfg.APIJWTSecret),
+	}
+
+	for _, key := range cfg.APIKeys {
+		if key.Name == "" {
+			return nil, errors.New("name of API key is empty")
+		}
+
+		if _, ok := a.byName[key.Name]; ok {
+			return nil, fmt.Errorf("duplicate API key name %s", key.Name)
+		}
+
+		c := &client{
+			name:  key.Name,
+			limit: key.RequestsPerMinute,
+		}
+		if len(key.Methods) != 0 {
+			c.methods = map[string]bool{}
+			for _, method := range key.Methods {
+				c.methods[normalizeMethod(method)] = true
+			}
+		}
+
+		a.byName[key.Name] = c
+		if key.Key != "" {
+			a.byKey[key.Key] = c
+		}
+	}
+
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "noah_api_requests_total",
+		Help: "Requests to API by API key and result",
+	}, []string{"key", "result"})
+	if err := prometheus.Register(requests); err != nil {
+		registered, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			return nil, err
+		}
+		requests = registered.ExistingCollector.(*prometheus.CounterVec)
+	}
+	a.requests = requests
+
+	return a, nil
+}
+
+// Authorize checks the bearer token of the request to the method and counts the request against the quota of its client.
+// Authorization is the value of Authorization header, method is the name of API method in any case, e.g. "coin_info" or "CoinInfo".
+// Returns the name of the client.
+func (a *Auth) Authorize(authorization string, method string) (string, error) {
+	c := a.authenticate(authorization)
+	if c == nil {
+		a.requests.WithLabelValues("", "unauthenticated").Inc()
+		return "", ErrUnauthenticated
+	}
+
+	if c.methods != nil && !c.methods[normalizeMethod(method)] {
+		a.requests.WithLabelValues(c.name, "method_not_allowed").Inc()
+		return c.name, ErrMethodNotAllowed
+	}
+
+	if !a.take(c, time.Now()) {
+		a.requests.WithLabelValues(c.name, "rate_limited").Inc()
+		return c.name, ErrRateLimited
+	}
+
+	a.requests.WithLabelValues(c.name, "ok").Inc()
+	return c.name, nil
+}
+
+// Authenticate checks the bearer token of the request without checking its methods and quota,
+// so requests of unknown clients are rejected before their bodies are read
+func (a *Auth) Authenticate(authorization string) error {
+	if a.authenticate(authorization) == nil {
+		a.requests.WithLabelValues("", "unauthenticated").Inc()
+		return ErrUnauthenticated
+	}
+
+	return nil
+}
+
+func (a *Auth) authenticate(authorization string) *client {
+	const prefix = "Bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return nil
+	}
+	token := strings.TrimSpace(authorization[len(prefix):])
+
+	for key, c := range a.byKey {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+			return c
+		}
+	}
+
+	if len(a.jwtSecret) == 0 {
+		return nil
+	}
+
+	subject, err := verifyJWT(token, a.jwtSecret, time.Now())
+	if err != nil {
+		return nil
+	}
+
+	return a.byName[subject]
+}
+
+// take counts the request in the current window of the client, returns false if the quota is exhausted
+func (a *Auth) take(c *client, now time.Time) bool {
+	if c.limit == 0 {
+		return true
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if now.Sub(c.windowStart) >= quotaWindow {
+		c.windowStart = now
+		c.requests = 0
+	}
+
+	if c.requests >= c.limit {
+		return false
+	}
+
+	c.requests++
+	return true
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+type jwtClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+}
+
+// verifyJWT verifies HS256 signature and time claims of the token and returns its subject
+func verifyJWT(token string, secret []byte, now time.Time) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return "", err
+	}
+	if header.Alg != "HS256" {
+		return "", fmt.Errorf("unsupported algorithm %s", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return "", errors.New("invalid signature")
+	}
+
+	var claims jwtClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return "", err
+	}
+
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return "", errors.New("token is expired")
+	}
+
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return "", errors.New("token is not valid yet")
+	}
+
+	return claims.Subject, nil
+}
+
+func decodeJWTPart(part string, value interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+// normalizeMethod makes names of methods of API v1 and gRPC comparable, e.g. "coin_info" and "CoinInfo"
+func normalizeMethod(method string) string {
+	if i := strings.LastIndex(method, "/"); i != -1 {
+		method = method[i+1:]
+	}
+
+	return strings.ToLower(strings.Replace(method, "_", "", -1))
+}
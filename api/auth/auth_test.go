@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+)
+
+func newTestAuth(t *testing.T) *Auth {
+	cfg := config.GetConfig()
+	cfg.APIJWTSecret = "jwt-secret"
+	cfg.APIKeys = []config.APIKeyConfig{
+		{Name: "partner", Key: "partner-key", RequestsPerMinute: 2, Methods: []string{"coin_info", "status"}},
+		{Name: "internal", Key: "internal-key"},
+		{Name: "jwt-only"},
+	}
+
+	a, err := NewAuth(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return a
+}
+
+func makeJWT(secret string, claims string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewAuth_Disabled(t *testing.T) {
+	a, err := NewAuth(config.GetConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a != nil {
+		t.Fatal("Auth should be disabled without keys")
+	}
+}
+
+func TestAuth_Authorize(t *testing.T) {
+	a := newTestAuth(t)
+
+	tests := []struct {
+		authorization string
+		method        string
+		name          string
+		err           error
+	}{
+		{"", "status", "", ErrUnauthenticated},
+		{"Bearer wrong-key", "status", "", ErrUnauthenticated},
+		{"partner-key", "status", "", ErrUnauthenticated},
+		{"Bearer partner-key", "/api_pb.ApiService/CoinInfo", "partner", nil},
+		{"Bearer partner-key", "address", "partner", ErrMethodNotAllowed},
+		{"bearer internal-key", "address", "internal", nil},
+	}
+
+	for i, test := range tests {
+		name, err := a.Authorize(test.authorization, test.method)
+		if err != test.err || name != test.name {
+			t.Fatalf("Case %d: expected %q, %v, got %q, %v", i, test.name, test.err, name, err)
+		}
+	}
+}
+
+func TestAuth_RateLimit(t *testing.T) {
+	a := newTestAuth(t)
+
+	for i := 0; i < 2; i++ {
+		if _, err := a.Authorize("Bearer partner-key", "status"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := a.Authorize("Bearer partner-key", "status"); err != ErrRateLimited {
+		t.Fatalf("Expected %v, got %v", ErrRateLimited, err)
+	}
+
+	// quota of other keys is not affected
+	if _, err := a.Authorize("Bearer internal-key", "status"); err != nil {
+		t.Fatal(err)
+	}
+
+	partner := a.byName["partner"]
+	if a.take(partner, partner.windowStart.Add(quotaWindow-time.Second)) {
+		t.Fatal("Quota should not be restored before the end of the window")
+	}
+
+	if !a.take(partner, partner.windowStart.Add(quotaWindow)) {
+		t.Fatal("Quota should be restored in the next window")
+	}
+}
+
+func TestAuth_Authenticate(t *testing.T) {
+	a := newTestAuth(t)
+
+	if err := a.Authenticate("Bearer wrong-key"); err != ErrUnauthenticated {
+		t.Fatalf("Expected %v, got %v", ErrUnauthenticated, err)
+	}
+
+	// authentication does not count requests against the quota
+	for i := 0; i < 3; i++ {
+		if err := a.Authenticate("Bearer partner-key"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := a.Authorize("Bearer partner-key", "status"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuth_JWT(t *testing.T) {
+	a := newTestAuth(t)
+	future, past := time.Now().Add(time.Hour).Unix(), time.Now().Add(-time.Hour).Unix()
+
+	tests := []struct {
+		token string
+		name  string
+		err   error
+	}{
+		{makeJWT("jwt-secret", `{"sub":"jwt-only"}`), "jwt-only", nil},
+		{makeJWT("jwt-secret", fmt.Sprintf(`{"sub":"partner","exp":%d}`, future)), "partner", nil},
+		{makeJWT("jwt-secret", fmt.Sprintf(`{"sub":"partner","exp":%d}`, past)), "", ErrUnauthenticated},
+		{makeJWT("jwt-secret", fmt.Sprintf(`{"sub":"partner","nbf":%d}`, future)), "", ErrUnauthenticated},
+		{makeJWT("other-secret", `{"sub":"jwt-only"}`), "", ErrUnauthenticated},
+		{makeJWT("jwt-secret", `{"sub":"unknown"}`), "", ErrUnauthenticated},
+	}
+
+	for i, test := range tests {
+		name, err := a.Authorize("Bearer "+test.token, "status")
+		if err != test.err || name != test.name {
+			t.Fatalf("Case %d: expected %q, %v, got %q, %v", i, test.name, test.err, name, err)
+		}
+	}
+}
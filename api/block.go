@@ -39,6 +39,7 @@ type BlockTransactionResponse struct {
 	Data        json.RawMessage   `json:"data"`
 	Payload     []byte            `json:"payload"`
 	ServiceData []byte            `json:"service_data"`
+	ValidUntil  uint64            `json:"valid_until,omitempty"`
 	Gas         int64             `json:"gas"`
 	GasCoin     string            `json:"gas_coin"`
 	Tags        map[string]string `json:"tags"`
@@ -109,6 +110,7 @@ func Block(height int64) (*BlockResponse, error) {
 			Data:        data,
 			Payload:     tx.Payload,
 			ServiceData: tx.ServiceData,
+			ValidUntil:  tx.ValidUntil(),
 			Gas:         tx.Gas(),
 			GasCoin:     tx.GasCoin.String(),
 			Tags:        tags,
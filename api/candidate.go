@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
 	"github.com/noah-blockchain/noah-go-node/core/state"
 	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
 	"github.com/noah-blockchain/noah-go-node/core/types"
@@ -14,14 +15,22 @@ type Stake struct {
 	BipValue string `json:"bip_value"`
 }
 
+type CommissionUpdate struct {
+	Commission uint32 `json:"commission"`
+	Height     uint64 `json:"height"`
+}
+
 type CandidateResponse struct {
-	RewardAddress string  `json:"reward_address"`
-	OwnerAddress  string  `json:"owner_address"`
-	TotalStake    string  `json:"total_stake"`
-	PubKey        string  `json:"pub_key"`
-	Commission    uint32  `json:"commission"`
-	Stakes        []Stake `json:"stakes,omitempty"`
-	Status        byte    `json:"status"`
+	RewardAddress    string            `json:"reward_address"`
+	OwnerAddress     string            `json:"owner_address"`
+	TotalStake       string            `json:"total_stake"`
+	PubKey           string            `json:"pub_key"`
+	Commission       uint32            `json:"commission"`
+	CommissionUpdate *CommissionUpdate `json:"commission_update,omitempty"`
+	Stakes           []Stake           `json:"stakes,omitempty"`
+	Status           byte              `json:"status"`
+
+	Proof *proofs.CandidateProof `json:"proof,omitempty"`
 }
 
 func makeResponseCandidate(state *state.CheckState, c candidates.Candidate, includeStakes bool) CandidateResponse {
@@ -34,6 +43,13 @@ func makeResponseCandidate(state *state.CheckState, c candidates.Candidate, incl
 		Status:        c.Status,
 	}
 
+	if update := c.GetCommissionUpdate(); update != nil {
+		candidate.CommissionUpdate = &CommissionUpdate{
+			Commission: update.Commission,
+			Height:     update.Height,
+		}
+	}
+
 	if includeStakes {
 		stakes := state.Candidates().GetStakes(c.PubKey)
 		candidate.Stakes = make([]Stake, len(stakes))
@@ -53,27 +69,42 @@ func makeResponseCandidate(state *state.CheckState, c candidates.Candidate, incl
 	return candidate
 }
 
-func Candidate(pubkey types.Pubkey, height int) (*CandidateResponse, error) {
+// Candidate returns the candidate with its stakes. With prove=true a missing candidate is not an error,
+// the response contains only its public key and the proof of its absence.
+func Candidate(pubkey types.Pubkey, height int, prove bool) (*CandidateResponse, error) {
+	if prove {
+		height = provenHeight(height)
+	}
+
 	cState, err := GetStateForHeight(height)
 	if err != nil {
 		return nil, err
 	}
 
-	if height != 0 {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		cState.Candidates().LoadStakesOfCandidate(pubkey)
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
 	candidate := cState.Candidates().GetCandidate(pubkey)
-	if candidate == nil {
+	if candidate == nil && !prove {
 		return nil, rpctypes.RPCError{Code: 404, Message: "Candidate not found"}
 	}
 
-	response := makeResponseCandidate(cState, *candidate, true)
+	response := CandidateResponse{PubKey: pubkey.String()}
+	if candidate != nil {
+		response = makeResponseCandidate(cState, *candidate, true)
+	}
+
+	if prove {
+		stateTree, err := blockchain.GetImmutableTreeAtHeight(uint64(height))
+		if err != nil {
+			return nil, err
+		}
+
+		response.Proof, err = proofs.NewCandidateProof(stateTree, pubkey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &response, nil
 }
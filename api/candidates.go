@@ -6,15 +6,6 @@ func Candidates(height int, includeStakes bool) (*[]CandidateResponse, error) {
 		return nil, err
 	}
 
-	if height != 0 {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		if includeStakes {
-			cState.Candidates().LoadStakes()
-		}
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
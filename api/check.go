@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+)
+
+type CheckResponse struct {
+	Hash           string `json:"hash"`
+	Issuer         string `json:"issuer"`
+	Nonce          string `json:"nonce"`
+	ChainID        uint8  `json:"chain_id"`
+	DueBlock       uint64 `json:"due_block"`
+	Coin           Coin   `json:"coin"`
+	Value          string `json:"value"`
+	GasCoin        Coin   `json:"gas_coin"`
+	Partial        bool   `json:"partial"`
+	Used           bool   `json:"used"`
+	Expired        bool   `json:"expired"`
+	RemainingValue string `json:"remaining_value"`
+	Commission     string `json:"commission"`
+	Covered        bool   `json:"covered"`
+}
+
+type RedeemedCheckResponse struct {
+	TxHash    string `json:"tx_hash"`
+	Height    int64  `json:"height"`
+	CheckHash string `json:"check_hash"`
+	Receiver  string `json:"receiver"`
+	Coin      Coin   `json:"coin"`
+	Value     string `json:"value"`
+}
+
+// Check decodes the raw check, verifies its signature and lock and reports whether it can be redeemed at the height
+func Check(rawCheck []byte, height int) (*CheckResponse, error) {
+	decodedCheck, err := check.DecodeFromBytes(rawCheck)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 400, Message: "Cannot decode check", Data: err.Error()}
+	}
+
+	cState, err := GetStateForHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	// the check is redeemed in the block next to the state, the same way as RedeemCheck sees it
+	currentBlock := uint64(cState.Tree().Version()) + 1
+
+	status, err := transaction.GetCheckStatus(cState, decodedCheck, currentBlock)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 400, Message: "Invalid check", Data: err.Error()}
+	}
+
+	return &CheckResponse{
+		Hash:     hex.EncodeToString(decodedCheck.Hash().Bytes()),
+		Issuer:   status.Issuer.String(),
+		Nonce:    hex.EncodeToString(decodedCheck.Nonce),
+		ChainID:  uint8(decodedCheck.ChainID),
+		DueBlock: decodedCheck.DueBlock,
+		Coin: Coin{
+			ID:     decodedCheck.Coin.Uint32(),
+			Symbol: cState.Coins().GetCoin(decodedCheck.Coin).GetFullSymbol(),
+		},
+		Value: decodedCheck.Value.String(),
+		GasCoin: Coin{
+			ID:     decodedCheck.GasCoin.Uint32(),
+			Symbol: cState.Coins().GetCoin(decodedCheck.GasCoin).GetFullSymbol(),
+		},
+		Partial:        decodedCheck.IsPartial(),
+		Used:           status.Used,
+		Expired:        status.Expired,
+		RemainingValue: status.RemainingValue.String(),
+		Commission:     status.Commission.String(),
+		Covered:        status.Covered,
+	}, nil
+}
+
+// Checks returns checks of the issuer redeemed by successful transactions.
+// Transactions are found by tags of tx index, so tx indexing should be enabled on the node.
+func Checks(issuer types.Address, page, perPage int) ([]*RedeemedCheckResponse, error) {
+	if page == 0 {
+		page = 1
+	}
+	if perPage == 0 {
+		perPage = 100
+	}
+
+	query := fmt.Sprintf("tx.type='%s' AND tx.from='%s'",
+		hex.EncodeToString([]byte{byte(transaction.TypeRedeemCheck)}), hex.EncodeToString(issuer.Bytes()))
+
+	rpcResult, err := client.TxSearch(query, false, page, perPage, "desc")
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 500, Message: "Cannot search redeemed checks", Data: err.Error()}
+	}
+
+	cState, err := GetStateForHeight(0)
+	if err != nil {
+		return nil, err
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	result := make([]*RedeemedCheckResponse, 0, len(rpcResult.Txs))
+	for _, tx := range rpcResult.Txs {
+		tags := make(map[string]string)
+		for _, tag := range tx.TxResult.Events[0].Attributes {
+			tags[string(tag.Key)] = string(tag.Value)
+		}
+
+		coinID, _ := strconv.ParseUint(tags["tx.coin_id"], 10, 32)
+		coin := types.CoinID(coinID)
+
+		// transactions of older versions have no tags of check hash and value
+		checkHash, value := tags["tx.check_hash"], tags["tx.value"]
+		if checkHash == "" || value == "" {
+			decodedTx, err := transaction.TxDecoder.DecodeFromBytes(tx.Tx)
+			if err != nil {
+				return nil, rpctypes.RPCError{Code: 500, Message: "Cannot decode transaction", Data: err.Error()}
+			}
+
+			decodedCheck, err := check.DecodeFromBytes(decodedTx.GetDecodedData().(*transaction.RedeemCheckData).RawCheck)
+			if err != nil {
+				return nil, rpctypes.RPCError{Code: 500, Message: "Cannot decode check", Data: err.Error()}
+			}
+
+			checkHash, value = hex.EncodeToString(decodedCheck.Hash().Bytes()), decodedCheck.Value.String()
+		}
+
+		result = append(result, &RedeemedCheckResponse{
+			TxHash:    "Mt" + strings.ToLower(hex.EncodeToString(tx.Tx.Hash())),
+			Height:    tx.Height,
+			CheckHash: checkHash,
+			Receiver:  types.HexToAddress(tags["tx.to"]).String(),
+			Coin: Coin{
+				ID:     coin.Uint32(),
+				Symbol: cState.Coins().GetCoin(coin).GetFullSymbol(),
+			},
+			Value: value,
+		})
+	}
+
+	return result, nil
+}
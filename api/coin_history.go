@@ -0,0 +1,60 @@
+package api
+
+import (
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/coinhistory"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+)
+
+// defaultCoinHistoryInterval is the interval of candles in seconds used if it is not set
+const defaultCoinHistoryInterval = 3600
+
+type CoinHistoryResponse struct {
+	Candles []coinhistory.Candle `json:"candles"`
+}
+
+// CoinHistory returns OHLC candles of price of the coin in base coin between unix times from and to with interval in seconds.
+// By default candles are hourly and end at the current time, from defaults to the earliest time allowed by the number of candles.
+func CoinHistory(id uint32, from, to, interval uint64) (*CoinHistoryResponse, error) {
+	if blockchain.CoinHistory() == nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: "Coin history is disabled on this node"}
+	}
+
+	cState, err := GetStateForHeight(0)
+	if err != nil {
+		return nil, err
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if !cState.Coins().Exists(types.CoinID(id)) {
+		return nil, rpctypes.RPCError{Code: 404, Message: "Coin not found"}
+	}
+
+	if interval == 0 {
+		interval = defaultCoinHistoryInterval
+	}
+	if to == 0 {
+		to = uint64(time.Now().Unix())
+	}
+	if from == 0 && to > interval*(coinhistory.MaxCandles-1) {
+		from = to - interval*(coinhistory.MaxCandles-1)
+	}
+
+	candles, err := blockchain.CoinHistory().Candles(types.CoinID(id), time.Unix(int64(from), 0), time.Unix(int64(to), 0), time.Duration(interval)*time.Second)
+	if err != nil {
+		if err == coinhistory.ErrInvalidRange || err == coinhistory.ErrTooManyCandles || err == coinhistory.ErrInvalidInterval {
+			return nil, rpctypes.RPCError{Code: 400, Message: err.Error()}
+		}
+		return nil, rpctypes.RPCError{Code: 500, Message: "Cannot load history of coin", Data: err.Error()}
+	}
+
+	if candles == nil {
+		candles = []coinhistory.Candle{}
+	}
+
+	return &CoinHistoryResponse{Candles: candles}, nil
+}
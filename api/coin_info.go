@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
 	"github.com/noah-blockchain/noah-go-node/core/state/coins"
 	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
@@ -15,11 +16,19 @@ type CoinInfoResponse struct {
 	ReserveBalance string  `json:"reserve_balance"`
 	MaxSupply      string  `json:"max_supply"`
 	OwnerAddress   *string `json:"owner_address"`
+
+	Proof *proofs.CoinProof `json:"proof,omitempty"`
 }
 
-func CoinInfo(coinSymbol *string, id *int, height int) (*CoinInfoResponse, error) {
+// CoinInfo returns the coin by symbol or ID. With prove=true a missing coin is not an error,
+// the response contains only requested symbol or ID and the proof of absence of the coin.
+func CoinInfo(coinSymbol *string, id *int, height int, prove bool) (*CoinInfoResponse, error) {
 	var coin *coins.Model
 
+	if prove {
+		height = provenHeight(height)
+	}
+
 	cState, err := GetStateForHeight(height)
 	if err != nil {
 		return nil, err
@@ -32,35 +41,54 @@ func CoinInfo(coinSymbol *string, id *int, height int) (*CoinInfoResponse, error
 		return nil, rpctypes.RPCError{Code: 404, Message: "Coin not found"}
 	}
 
-	if coinSymbol != nil {
+	response := &CoinInfoResponse{}
+	if id != nil {
+		coin = cState.Coins().GetCoin(types.CoinID(*id))
+		response.ID = uint32(*id)
+	} else {
 		coin = cState.Coins().GetCoinBySymbol(types.StrToCoinBaseSymbol(*coinSymbol), types.GetVersionFromSymbol(*coinSymbol))
-		if coin == nil {
-			return nil, rpctypes.RPCError{Code: 404, Message: "Coin not found"}
-		}
+		response.Symbol = *coinSymbol
 	}
 
-	if id != nil {
-		coin = cState.Coins().GetCoin(types.CoinID(*id))
-		if coin == nil {
-			return nil, rpctypes.RPCError{Code: 404, Message: "Coin not found"}
+	if coin == nil && !prove {
+		return nil, rpctypes.RPCError{Code: 404, Message: "Coin not found"}
+	}
+
+	if coin != nil {
+		var ownerAddress *string
+		info := cState.Coins().GetSymbolInfo(coin.Symbol())
+		if info != nil && info.OwnerAddress() != nil {
+			owner := info.OwnerAddress().String()
+			ownerAddress = &owner
+		}
+
+		response = &CoinInfoResponse{
+			ID:             coin.ID().Uint32(),
+			Name:           coin.Name(),
+			Symbol:         coin.GetFullSymbol(),
+			Volume:         coin.Volume().String(),
+			Crr:            coin.Crr(),
+			ReserveBalance: coin.Reserve().String(),
+			MaxSupply:      coin.MaxSupply().String(),
+			OwnerAddress:   ownerAddress,
 		}
 	}
 
-	var ownerAddress *string
-	info := cState.Coins().GetSymbolInfo(coin.Symbol())
-	if info != nil && info.OwnerAddress() != nil {
-		owner := info.OwnerAddress().String()
-		ownerAddress = &owner
+	if prove {
+		stateTree, err := blockchain.GetImmutableTreeAtHeight(uint64(height))
+		if err != nil {
+			return nil, err
+		}
+
+		if id != nil {
+			response.Proof, err = proofs.NewCoinProof(stateTree, types.CoinID(*id))
+		} else {
+			response.Proof, err = proofs.NewCoinSymbolProof(stateTree, types.StrToCoinBaseSymbol(*coinSymbol), types.GetVersionFromSymbol(*coinSymbol))
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &CoinInfoResponse{
-		ID:             coin.ID().Uint32(),
-		Name:           coin.Name(),
-		Symbol:         coin.GetFullSymbol(),
-		Volume:         coin.Volume().String(),
-		Crr:            coin.Crr(),
-		ReserveBalance: coin.Reserve().String(),
-		MaxSupply:      coin.MaxSupply().String(),
-		OwnerAddress:   ownerAddress,
-	}, nil
+	return response, nil
 }
@@ -14,6 +14,7 @@ import (
 type EstimateCoinBuyResponse struct {
 	WillPay    string `json:"will_pay"`
 	Commission string `json:"commission"`
+	ValidUntil uint64 `json:"valid_until"`
 }
 
 // EstimateCoinBuy returns an estimate of buy coin transaction
@@ -76,5 +77,6 @@ func EstimateCoinBuy(coinToSell, coinToBuy string, valueToBuy *big.Int, height i
 	return &EstimateCoinBuyResponse{
 		WillPay:    result.String(),
 		Commission: commission.String(),
+		ValidUntil: suggestedValidUntil(),
 	}, nil
 }
@@ -14,6 +14,7 @@ import (
 type EstimateCoinSellResponse struct {
 	WillGet    string `json:"will_get"`
 	Commission string `json:"commission"`
+	ValidUntil uint64 `json:"valid_until"`
 }
 
 // EstimateCoinSell returns an estimate of sell coin transaction
@@ -73,5 +74,6 @@ func EstimateCoinSell(coinToSell, coinToBuy string, valueToSell *big.Int, height
 	return &EstimateCoinSellResponse{
 		WillGet:    result.String(),
 		Commission: commission.String(),
+		ValidUntil: suggestedValidUntil(),
 	}, nil
 }
@@ -11,7 +11,8 @@ import (
 
 // EstimateCoinSellAllResponse returns an of sell all coin transaction
 type EstimateCoinSellAllResponse struct {
-	WillGet string `json:"will_get"`
+	WillGet    string `json:"will_get"`
+	ValidUntil uint64 `json:"valid_until"`
 }
 
 // EstimateCoinSellAll returns an estimate of sell all coin transaction
@@ -71,6 +72,7 @@ func EstimateCoinSellAll(coinToSell, coinToBuy string, valueToSell *big.Int, hei
 	}
 
 	return &EstimateCoinSellAllResponse{
-		WillGet: result.String(),
+		WillGet:    result.String(),
+		ValidUntil: suggestedValidUntil(),
 	}, nil
 }
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+)
+
+type EvidenceResponse struct {
+	Evidence      []EvidenceItem `json:"evidence"`
+	EvidenceCount int            `json:"evidence_count"`
+}
+
+type EvidenceItem struct {
+	Type   string    `json:"type"`
+	Height uint64    `json:"height"`
+	Time   time.Time `json:"time"`
+}
+
+// Evidence returns misbehaviour of validator observed by the node
+func Evidence(pubkey types.Pubkey) (*EvidenceResponse, error) {
+	cState := blockchain.ReadState()
+	cState.RLock()
+	candidate := cState.Candidates().GetCandidate(pubkey)
+	cState.RUnlock()
+
+	if candidate == nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: "Candidate not found"}
+	}
+
+	list := blockchain.GetEvidence(candidate.GetTmAddress())
+	response := &EvidenceResponse{
+		Evidence:      make([]EvidenceItem, 0, len(list)),
+		EvidenceCount: len(list),
+	}
+	for _, item := range list {
+		response.Evidence = append(response.Evidence, EvidenceItem{
+			Type:   item.Type,
+			Height: item.Height,
+			Time:   item.Time,
+		})
+	}
+
+	return response, nil
+}
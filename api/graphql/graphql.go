@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go"
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+)
+
+// Blockchain provides states and events to resolvers, it is implemented by *noah.Blockchain
+type Blockchain interface {
+	GetStateForHeight(height uint64) (*state.CheckState, error)
+	GetEventsDB() eventsdb.IEventsDB
+}
+
+// Handler serves GraphQL queries over chain state.
+// Depth of queries is limited at validation, complexity is counted while resolving: every resolved object costs 1.
+type Handler struct {
+	schema        *graphql.Schema
+	maxComplexity int64
+}
+
+// NewHandler parses the schema of the state, maxDepth and maxComplexity limit queries if they are greater than zero
+func NewHandler(blockchain Blockchain, maxDepth int, maxComplexity int64) (*Handler, error) {
+	opts := []graphql.SchemaOpt{graphql.UseStringDescriptions()}
+	if maxDepth > 0 {
+		opts = append(opts, graphql.MaxDepth(maxDepth))
+	}
+
+	schema, err := graphql.ParseSchema(Schema, &queryResolver{blockchain: blockchain}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{schema: schema, maxComplexity: maxComplexity}, nil
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Exec executes the query with limits of the handler
+func (h *Handler) Exec(ctx context.Context, query string, operationName string, variables map[string]interface{}) *graphql.Response {
+	if h.maxComplexity > 0 {
+		ctx = context.WithValue(ctx, complexityKey{}, &complexity{limit: h.maxComplexity})
+	}
+
+	return h.schema.Exec(ctx, query, operationName, variables)
+}
+
+// ServeHTTP executes queries sent by POST as JSON or by GET in query parameters
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodPost:
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodGet:
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response, err := json.Marshal(h.Exec(r.Context(), req.Query, req.OperationName, req.Variables))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(response)
+}
+
+type complexityKey struct{}
+
+type complexity struct {
+	limit int64
+	spent int64
+}
+
+// charge adds cost of resolved objects to complexity of the query, returns an error if the limit is exceeded
+func charge(ctx context.Context, cost int) error {
+	c, ok := ctx.Value(complexityKey{}).(*complexity)
+	if !ok {
+		return nil
+	}
+
+	if atomic.AddInt64(&c.spent, int64(cost)) > c.limit {
+		return fmt.Errorf("query complexity limit of %d is exceeded", c.limit)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+type testBlockchain struct {
+	state  *state.CheckState
+	events testEvents
+}
+
+func (b *testBlockchain) GetStateForHeight(height uint64) (*state.CheckState, error) {
+	return b.state, nil
+}
+
+func (b *testBlockchain) GetEventsDB() eventsdb.IEventsDB {
+	return b.events
+}
+
+type testEvents map[uint32]eventsdb.Events
+
+func (e testEvents) AddEvent(height uint32, event eventsdb.Event) {}
+func (e testEvents) LoadEvents(height uint32) eventsdb.Events     { return e[height] }
+func (e testEvents) CommitEvents() error                          { return nil }
+
+var (
+	testAddress = types.Address{1}
+	testPubKey  = types.Pubkey{2}
+)
+
+func newTestBlockchain(t *testing.T) *testBlockchain {
+	s, err := state.NewState(0, db.NewMemDB(), nil, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coinID := s.App.GetNextCoinID()
+	s.Coins.Create(coinID, types.StrToCoinSymbol("TEST"), "Test coin", helpers.NoahToQNoah(big.NewInt(100)), 10,
+		helpers.NoahToQNoah(big.NewInt(50)), helpers.NoahToQNoah(big.NewInt(1000)), nil)
+	s.App.SetCoinsCount(coinID.Uint32())
+
+	s.Accounts.AddBalance(testAddress, types.GetBaseCoinID(), big.NewInt(1))
+	s.Accounts.AddBalance(testAddress, coinID, big.NewInt(2))
+
+	s.Candidates.Create(testAddress, testAddress, testAddress, testPubKey, 10)
+	s.Candidates.SetStakes(testPubKey, []types.Stake{
+		{Owner: testAddress, Coin: uint64(coinID), Value: "3", NoahValue: "4"},
+	}, nil)
+	s.Validators.Create(testPubKey, big.NewInt(4))
+	s.FrozenFunds.AddFund(10, testAddress, testPubKey, s.Candidates.ID(testPubKey), coinID, big.NewInt(6))
+
+	if _, err := s.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	return &testBlockchain{
+		state: state.NewCheckState(s),
+		events: testEvents{1: {&eventsdb.RewardEvent{
+			Role:            eventsdb.RoleValidator.String(),
+			Address:         testAddress,
+			Amount:          "5",
+			ValidatorPubKey: testPubKey,
+		}}},
+	}
+}
+
+func exec(t *testing.T, handler *Handler, query string) (map[string]interface{}, []string) {
+	response := handler.Exec(context.Background(), query, "", nil)
+
+	var errs []string
+	for _, err := range response.Errors {
+		errs = append(errs, err.Message)
+	}
+
+	var data map[string]interface{}
+	if response.Data != nil {
+		if err := json.Unmarshal(response.Data, &data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return data, errs
+}
+
+func TestNestedQuery(t *testing.T) {
+	handler, err := NewHandler(newTestBlockchain(t), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, errs := exec(t, handler, `{
+		state {
+			height
+			account(address: "`+testAddress.String()+`") {
+				balances { coin { symbol } value }
+				stakes { value noahValue coin { name crr } candidate { pubKey validator { pubKey } } }
+				frozenFunds { height value candidate { pubKey } }
+			}
+			candidates { totalStake stakes { owner { address } } }
+			events(type: "noah/RewardEvent") { address data }
+		}
+	}`)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	got, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`"height":1`,
+		`"balances":[{"coin":{"symbol":"NOAH"},"value":"1"},{"coin":{"symbol":"TEST"},"value":"2"}]`,
+		`"stakes":[{"candidate":{"pubKey":"` + testPubKey.String() + `","validator":{"pubKey":"` + testPubKey.String() + `"}},"coin":{"crr":10,"name":"Test coin"},"noahValue":"4","value":"3"}]`,
+		`"stakes":[{"owner":{"address":"` + testAddress.String() + `"}}]`,
+		`"frozenFunds":[{"candidate":{"pubKey":"` + testPubKey.String() + `"},"height":10,"value":"6"}]`,
+		`"events":[{"address":"` + testAddress.String() + `"`,
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("response %s does not contain %s", got, want)
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	handler, err := NewHandler(newTestBlockchain(t), 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{
+		`{ state { account(address: "NOAHx01") { nonce } } }`,
+		`{ state { candidate(pubKey: "Mx0000") { status } } }`,
+		`{ state { coin { id } } }`,
+	} {
+		if _, errs := exec(t, handler, query); len(errs) == 0 {
+			t.Errorf("expected error for query %s", query)
+		}
+	}
+
+	data, errs := exec(t, handler, `{ state { coin(symbol: "UNKNOWN") { id } } }`)
+	if len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if data["state"].(map[string]interface{})["coin"] != nil {
+		t.Errorf("expected null for unknown coin, got %v", data)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	query := `{ state { candidates { stakes { owner { stakes { candidate { pubKey } } } } } } }`
+
+	handler, err := NewHandler(newTestBlockchain(t), 3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := exec(t, handler, query); len(errs) == 0 {
+		t.Error("expected error of query depth")
+	}
+
+	handler, err = NewHandler(newTestBlockchain(t), 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := exec(t, handler, query); len(errs) == 0 || !strings.Contains(errs[0], "complexity limit of 5") {
+		t.Errorf("expected error of query complexity, got %v", errs)
+	}
+
+	handler, err = NewHandler(newTestBlockchain(t), 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := exec(t, handler, query); len(errs) != 0 {
+		t.Error(errs)
+	}
+
+	// heights of frozen funds are charged even if none of the funds belongs to the account
+	handler, err = NewHandler(newTestBlockchain(t), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frozenFunds := `{ state { account(address: "` + types.Address{3}.String() + `") { frozenFunds { height } } } }`
+	if _, errs := exec(t, handler, frozenFunds); len(errs) == 0 || !strings.Contains(errs[0], "complexity limit of 2") {
+		t.Errorf("expected error of query complexity, got %v", errs)
+	}
+}
@@ -0,0 +1,616 @@
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
+	"github.com/noah-blockchain/noah-go-node/core/state/coins"
+	"github.com/noah-blockchain/noah-go-node/core/state/frozenfunds"
+	"github.com/noah-blockchain/noah-go-node/core/state/validators"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+type queryResolver struct {
+	blockchain Blockchain
+}
+
+func (q *queryResolver) State(ctx context.Context, args struct{ Height *int32 }) (*stateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	var height uint64
+	if args.Height != nil {
+		if *args.Height < 0 {
+			return nil, errors.New("height should not be negative")
+		}
+		height = uint64(*args.Height)
+	}
+
+	cState, err := q.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateResolver{state: cState, height: uint64(cState.Tree().Version()), events: q.blockchain.GetEventsDB()}, nil
+}
+
+// stateResolver resolves all nested objects of the query from the same state
+type stateResolver struct {
+	state  *state.CheckState
+	height uint64
+	events eventsdb.IEventsDB
+}
+
+func (s *stateResolver) Height() int32 {
+	return int32(s.height)
+}
+
+func (s *stateResolver) Account(ctx context.Context, args struct{ Address string }) (*accountResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	address, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResolver{s: s, address: address}, nil
+}
+
+func (s *stateResolver) Coin(ctx context.Context, args struct {
+	ID     *int32
+	Symbol *string
+}) (*coinResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	var coin *coins.Model
+	switch {
+	case args.ID != nil:
+		coin = s.state.Coins().GetCoin(types.CoinID(*args.ID))
+	case args.Symbol != nil:
+		coin = s.state.Coins().GetCoinBySymbol(types.StrToCoinBaseSymbol(*args.Symbol), types.GetVersionFromSymbol(*args.Symbol))
+	default:
+		return nil, errors.New("id or symbol of the coin should be set")
+	}
+
+	if coin == nil {
+		return nil, nil
+	}
+
+	return &coinResolver{coin: coin}, nil
+}
+
+func (s *stateResolver) Candidate(ctx context.Context, args struct{ PubKey string }) (*candidateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	pubKey, err := parsePubKey(args.PubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.candidate(pubKey), nil
+}
+
+func (s *stateResolver) Candidates(ctx context.Context, args struct{ Status *int32 }) ([]*candidateResolver, error) {
+	s.state.RLock()
+	list := s.state.Candidates().GetCandidates()
+	s.state.RUnlock()
+
+	result := make([]*candidateResolver, 0, len(list))
+	for _, candidate := range list {
+		if args.Status != nil && int32(candidate.Status) != *args.Status {
+			continue
+		}
+		result = append(result, &candidateResolver{s: s, candidate: candidate})
+	}
+
+	if err := charge(ctx, len(result)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *stateResolver) Validators(ctx context.Context) ([]*validatorResolver, error) {
+	s.state.RLock()
+	list := s.state.Validators().GetValidators()
+	s.state.RUnlock()
+
+	if err := charge(ctx, len(list)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*validatorResolver, len(list))
+	for i, validator := range list {
+		result[i] = &validatorResolver{s: s, validator: validator}
+	}
+
+	return result, nil
+}
+
+func (s *stateResolver) Events(ctx context.Context, args struct {
+	Address *string
+	Type    *string
+}) ([]*eventResolver, error) {
+	var result []*eventResolver
+	for _, event := range s.events.LoadEvents(uint32(s.height)) {
+		if args.Address != nil && event.AddressString() != *args.Address {
+			continue
+		}
+		if args.Type != nil && event.Type() != *args.Type {
+			continue
+		}
+		result = append(result, &eventResolver{event: event})
+	}
+
+	if err := charge(ctx, len(result)); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *stateResolver) coin(id types.CoinID) *coinResolver {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	return &coinResolver{coin: s.state.Coins().GetCoin(id)}
+}
+
+func (s *stateResolver) candidate(pubKey types.Pubkey) *candidateResolver {
+	s.state.RLock()
+	defer s.state.RUnlock()
+
+	candidate := s.state.Candidates().GetCandidate(pubKey)
+	if candidate == nil {
+		return nil
+	}
+
+	return &candidateResolver{s: s, candidate: candidate}
+}
+
+func (s *stateResolver) candidateByID(id uint32) *candidateResolver {
+	s.state.RLock()
+	pubKey := s.state.Candidates().PubKey(id)
+	s.state.RUnlock()
+
+	return s.candidate(pubKey)
+}
+
+type accountResolver struct {
+	s       *stateResolver
+	address types.Address
+}
+
+func (a *accountResolver) Address() string {
+	return a.address.String()
+}
+
+func (a *accountResolver) Nonce() int32 {
+	a.s.state.RLock()
+	defer a.s.state.RUnlock()
+
+	return int32(a.s.state.Accounts().GetNonce(a.address))
+}
+
+func (a *accountResolver) Balances(ctx context.Context) ([]*balanceResolver, error) {
+	a.s.state.RLock()
+	balances := a.s.state.Accounts().GetBalances(a.address)
+	a.s.state.RUnlock()
+
+	if err := charge(ctx, len(balances)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*balanceResolver, len(balances))
+	for i, balance := range balances {
+		result[i] = &balanceResolver{s: a.s, coin: balance.Coin.ID, value: balance.Value.String()}
+	}
+
+	return result, nil
+}
+
+func (a *accountResolver) Stakes(ctx context.Context) ([]*stakeResolver, error) {
+	a.s.state.RLock()
+	count := a.s.state.Candidates().Count()
+	a.s.state.RUnlock()
+
+	// every candidate is checked for stakes of the account, the scan is charged before it is done
+	if err := charge(ctx, count); err != nil {
+		return nil, err
+	}
+
+	a.s.state.RLock()
+	list := a.s.state.Candidates().GetCandidates()
+	a.s.state.RUnlock()
+
+	var result []*stakeResolver
+	for _, candidate := range list {
+		a.s.state.RLock()
+		stakes := a.s.state.Candidates().GetStakes(candidate.PubKey)
+		a.s.state.RUnlock()
+
+		for _, stake := range stakes {
+			if stake.Owner != a.address {
+				continue
+			}
+			result = append(result, &stakeResolver{
+				s:         a.s,
+				owner:     stake.Owner,
+				candidate: candidate.PubKey,
+				coin:      stake.Coin,
+				value:     stake.Value.String(),
+				noahValue: stake.NoahValue.String(),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func (a *accountResolver) FrozenFunds(ctx context.Context) ([]*frozenFundResolver, error) {
+	a.s.state.RLock()
+	defer a.s.state.RUnlock()
+
+	// every stored height of the unbond period is charged before its frozen funds are scanned
+	var result []*frozenFundResolver
+	var err error
+	a.s.state.FrozenFunds().IterateFrozenFunds(a.s.height, a.s.height+candidates.UnbondPeriod, func(funds *frozenfunds.Model) bool {
+		if err = charge(ctx, 1); err != nil {
+			return true
+		}
+
+		for _, fund := range funds.List {
+			if fund.Address != a.address {
+				continue
+			}
+			result = append(result, &frozenFundResolver{
+				s:           a.s,
+				height:      funds.Height(),
+				candidateID: fund.CandidateID,
+				coin:        fund.Coin,
+				value:       fund.Value.String(),
+			})
+		}
+
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (a *accountResolver) Waitlist(ctx context.Context) ([]*waitlistItemResolver, error) {
+	a.s.state.RLock()
+	model := a.s.state.WaitList().GetByAddress(a.address)
+	a.s.state.RUnlock()
+
+	if model == nil {
+		return nil, nil
+	}
+
+	if err := charge(ctx, len(model.List)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*waitlistItemResolver, len(model.List))
+	for i, item := range model.List {
+		result[i] = &waitlistItemResolver{s: a.s, candidateID: item.CandidateId, coin: item.Coin, value: item.Value.String()}
+	}
+
+	return result, nil
+}
+
+type balanceResolver struct {
+	s     *stateResolver
+	coin  types.CoinID
+	value string
+}
+
+func (b *balanceResolver) Coin() *coinResolver {
+	return b.s.coin(b.coin)
+}
+
+func (b *balanceResolver) Value() string {
+	return b.value
+}
+
+type coinResolver struct {
+	coin *coins.Model
+}
+
+func (c *coinResolver) ID() int32 {
+	return int32(c.coin.ID())
+}
+
+func (c *coinResolver) Symbol() string {
+	return c.coin.GetFullSymbol()
+}
+
+func (c *coinResolver) Name() string {
+	return c.coin.Name()
+}
+
+func (c *coinResolver) Crr() int32 {
+	return int32(c.coin.Crr())
+}
+
+func (c *coinResolver) Volume() string {
+	return c.coin.Volume().String()
+}
+
+func (c *coinResolver) Reserve() string {
+	return c.coin.Reserve().String()
+}
+
+func (c *coinResolver) MaxSupply() string {
+	return c.coin.MaxSupply().String()
+}
+
+func (c *coinResolver) Version() int32 {
+	return int32(c.coin.Version())
+}
+
+type candidateResolver struct {
+	s         *stateResolver
+	candidate *candidates.Candidate
+}
+
+func (c *candidateResolver) PubKey() string {
+	return c.candidate.PubKey.String()
+}
+
+func (c *candidateResolver) RewardAddress() string {
+	return c.candidate.RewardAddress.String()
+}
+
+func (c *candidateResolver) OwnerAddress() string {
+	return c.candidate.OwnerAddress.String()
+}
+
+func (c *candidateResolver) ControlAddress() string {
+	return c.candidate.ControlAddress.String()
+}
+
+func (c *candidateResolver) TotalStake() string {
+	c.s.state.RLock()
+	defer c.s.state.RUnlock()
+
+	return c.s.state.Candidates().GetTotalStake(c.candidate.PubKey).String()
+}
+
+func (c *candidateResolver) Commission() int32 {
+	return int32(c.candidate.Commission)
+}
+
+func (c *candidateResolver) Status() int32 {
+	return int32(c.candidate.Status)
+}
+
+func (c *candidateResolver) Stakes(ctx context.Context) ([]*stakeResolver, error) {
+	c.s.state.RLock()
+	stakes := c.s.state.Candidates().GetStakes(c.candidate.PubKey)
+	c.s.state.RUnlock()
+
+	if err := charge(ctx, len(stakes)); err != nil {
+		return nil, err
+	}
+
+	result := make([]*stakeResolver, len(stakes))
+	for i, stake := range stakes {
+		result[i] = &stakeResolver{
+			s:         c.s,
+			owner:     stake.Owner,
+			candidate: c.candidate.PubKey,
+			coin:      stake.Coin,
+			value:     stake.Value.String(),
+			noahValue: stake.NoahValue.String(),
+		}
+	}
+
+	return result, nil
+}
+
+func (c *candidateResolver) Validator(ctx context.Context) (*validatorResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	c.s.state.RLock()
+	validator := c.s.state.Validators().GetByPublicKey(c.candidate.PubKey)
+	c.s.state.RUnlock()
+
+	if validator == nil {
+		return nil, nil
+	}
+
+	return &validatorResolver{s: c.s, validator: validator}, nil
+}
+
+type stakeResolver struct {
+	s         *stateResolver
+	owner     types.Address
+	candidate types.Pubkey
+	coin      types.CoinID
+	value     string
+	noahValue string
+}
+
+func (s *stakeResolver) Owner(ctx context.Context) (*accountResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return &accountResolver{s: s.s, address: s.owner}, nil
+}
+
+func (s *stakeResolver) Candidate(ctx context.Context) (*candidateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	candidate := s.s.candidate(s.candidate)
+	if candidate == nil {
+		return nil, errors.New("candidate of the stake not found")
+	}
+
+	return candidate, nil
+}
+
+func (s *stakeResolver) Coin() *coinResolver {
+	return s.s.coin(s.coin)
+}
+
+func (s *stakeResolver) Value() string {
+	return s.value
+}
+
+func (s *stakeResolver) NoahValue() string {
+	return s.noahValue
+}
+
+type validatorResolver struct {
+	s         *stateResolver
+	validator *validators.Validator
+}
+
+func (v *validatorResolver) PubKey() string {
+	return v.validator.PubKey.String()
+}
+
+func (v *validatorResolver) Candidate(ctx context.Context) (*candidateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return v.s.candidate(v.validator.PubKey), nil
+}
+
+func (v *validatorResolver) AbsentTimes() int32 {
+	return int32(v.validator.CountAbsentTimes())
+}
+
+func (v *validatorResolver) AccumReward() string {
+	return v.validator.GetAccumReward().String()
+}
+
+type frozenFundResolver struct {
+	s           *stateResolver
+	height      uint64
+	candidateID uint32
+	coin        types.CoinID
+	value       string
+}
+
+func (f *frozenFundResolver) Height() int32 {
+	return int32(f.height)
+}
+
+func (f *frozenFundResolver) Candidate(ctx context.Context) (*candidateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return f.s.candidateByID(f.candidateID), nil
+}
+
+func (f *frozenFundResolver) Coin() *coinResolver {
+	return f.s.coin(f.coin)
+}
+
+func (f *frozenFundResolver) Value() string {
+	return f.value
+}
+
+type waitlistItemResolver struct {
+	s           *stateResolver
+	candidateID uint32
+	coin        types.CoinID
+	value       string
+}
+
+func (w *waitlistItemResolver) Candidate(ctx context.Context) (*candidateResolver, error) {
+	if err := charge(ctx, 1); err != nil {
+		return nil, err
+	}
+
+	return w.s.candidateByID(w.candidateID), nil
+}
+
+func (w *waitlistItemResolver) Coin() *coinResolver {
+	return w.s.coin(w.coin)
+}
+
+func (w *waitlistItemResolver) Value() string {
+	return w.value
+}
+
+type eventResolver struct {
+	event eventsdb.Event
+}
+
+func (e *eventResolver) Type() string {
+	return e.event.Type()
+}
+
+func (e *eventResolver) Address() string {
+	return e.event.AddressString()
+}
+
+func (e *eventResolver) ValidatorPubKey() string {
+	return e.event.ValidatorPubKeyString()
+}
+
+func (e *eventResolver) Data() (string, error) {
+	data, err := json.Marshal(e.event)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func parseAddress(s string) (types.Address, error) {
+	const prefix = "NOAHx"
+	if !strings.HasPrefix(s, prefix) || len(s) != len(prefix)+2*types.AddressLength {
+		return types.Address{}, errors.New("invalid address")
+	}
+
+	data, err := hex.DecodeString(s[len(prefix):])
+	if err != nil {
+		return types.Address{}, errors.New("invalid address")
+	}
+
+	return types.BytesToAddress(data), nil
+}
+
+func parsePubKey(s string) (types.Pubkey, error) {
+	const prefix = "Mp"
+	if !strings.HasPrefix(s, prefix) || len(s) != len(prefix)+2*32 {
+		return types.Pubkey{}, errors.New("invalid public key")
+	}
+
+	data, err := hex.DecodeString(s[len(prefix):])
+	if err != nil {
+		return types.Pubkey{}, errors.New("invalid public key")
+	}
+
+	return types.BytesToPubkey(data), nil
+}
@@ -0,0 +1,103 @@
+package graphql
+
+// Schema is the GraphQL schema of the state, values of big numbers are decimal strings
+const Schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	"State committed at the height, the latest committed state if height is not set"
+	state(height: Int): State!
+}
+
+type State {
+	height: Int!
+	account(address: String!): Account!
+	"Coin by ID or by symbol with version, e.g. COIN-1"
+	coin(id: Int, symbol: String): Coin
+	candidate(pubKey: String!): Candidate
+	"Candidates with the status, all candidates if status is not set"
+	candidates(status: Int): [Candidate!]!
+	validators: [Validator!]!
+	"Events of the block at the height of the state"
+	events(address: String, type: String): [Event!]!
+}
+
+type Account {
+	address: String!
+	nonce: Int!
+	balances: [Balance!]!
+	"Stakes delegated by the account to all candidates"
+	stakes: [Stake!]!
+	"Funds of the account frozen until the end of unbond period"
+	frozenFunds: [FrozenFund!]!
+	"Stakes of the account moved to the waitlist"
+	waitlist: [WaitlistItem!]!
+}
+
+type Balance {
+	coin: Coin!
+	value: String!
+}
+
+type Coin {
+	id: Int!
+	symbol: String!
+	name: String!
+	crr: Int!
+	volume: String!
+	reserve: String!
+	maxSupply: String!
+	version: Int!
+}
+
+type Candidate {
+	pubKey: String!
+	rewardAddress: String!
+	ownerAddress: String!
+	controlAddress: String!
+	totalStake: String!
+	commission: Int!
+	status: Int!
+	stakes: [Stake!]!
+	"Validator of the candidate, null if the candidate is not a validator"
+	validator: Validator
+}
+
+type Stake {
+	owner: Account!
+	candidate: Candidate!
+	coin: Coin!
+	value: String!
+	noahValue: String!
+}
+
+type Validator {
+	pubKey: String!
+	candidate: Candidate
+	absentTimes: Int!
+	accumReward: String!
+}
+
+type FrozenFund {
+	height: Int!
+	candidate: Candidate
+	coin: Coin!
+	value: String!
+}
+
+type WaitlistItem {
+	candidate: Candidate
+	coin: Coin!
+	value: String!
+}
+
+type Event {
+	type: String!
+	address: String!
+	validatorPubKey: String!
+	"JSON of the event"
+	data: String!
+}
+`
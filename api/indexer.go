@@ -0,0 +1,137 @@
+package api
+
+import (
+	"github.com/noah-blockchain/noah-go-node/core/indexer"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+)
+
+type IndexedTransactionsResponse struct {
+	Txs        []indexer.Tx `json:"txs"`
+	TotalCount int          `json:"total_count"`
+}
+
+type IndexedEventsResponse struct {
+	Events     []indexer.Event `json:"events"`
+	TotalCount int             `json:"total_count"`
+}
+
+type AddressTransactionsResponse struct {
+	Txs        []indexer.AddressTx `json:"txs"`
+	TotalCount int                 `json:"total_count"`
+}
+
+type BalanceChangesResponse struct {
+	Balances   []indexer.BalanceChange `json:"balances"`
+	TotalCount int                     `json:"total_count"`
+}
+
+// IndexedTransactions returns transactions from SQL indexer ordered by heights, failed transactions are selected by non-zero code
+func IndexedTransactions(from *types.Address, txType uint8, code *uint32, fromHeight, toHeight uint64, order string, page, perPage int) (*IndexedTransactionsResponse, error) {
+	sqlIndexer, err := getIndexer()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := indexer.TxFilter{
+		Type:       txType,
+		Code:       code,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+	if from != nil {
+		filter.From = from.String()
+	}
+
+	txs, count, err := sqlIndexer.Transactions(filter, indexer.Page{Order: order, Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, indexerError(err)
+	}
+
+	return &IndexedTransactionsResponse{Txs: txs, TotalCount: count}, nil
+}
+
+// IndexedEvents returns app events from SQL indexer ordered by heights
+func IndexedEvents(address *types.Address, pubKey *types.Pubkey, eventType string, fromHeight, toHeight uint64, order string, page, perPage int) (*IndexedEventsResponse, error) {
+	sqlIndexer, err := getIndexer()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := indexer.EventFilter{
+		Type:       eventType,
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+	if address != nil {
+		filter.Address = address.String()
+	}
+	if pubKey != nil {
+		filter.ValidatorPubKey = pubKey.String()
+	}
+
+	events, count, err := sqlIndexer.Events(filter, indexer.Page{Order: order, Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, indexerError(err)
+	}
+
+	return &IndexedEventsResponse{Events: events, TotalCount: count}, nil
+}
+
+// AddressTransactions returns transactions in which the address is involved with its roles in them, only transactions
+// with the role are returned if it is set
+func AddressTransactions(address types.Address, role string, order string, page, perPage int) (*AddressTransactionsResponse, error) {
+	sqlIndexer, err := getIndexer()
+	if err != nil {
+		return nil, err
+	}
+
+	txs, count, err := sqlIndexer.AddressTransactions(address.String(), role, indexer.Page{Order: order, Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, indexerError(err)
+	}
+
+	return &AddressTransactionsResponse{Txs: txs, TotalCount: count}, nil
+}
+
+// BalanceChanges returns balances of the address at the end of blocks in which they were changed
+func BalanceChanges(address types.Address, coin *types.CoinID, fromHeight, toHeight uint64, order string, page, perPage int) (*BalanceChangesResponse, error) {
+	sqlIndexer, err := getIndexer()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := indexer.BalanceFilter{
+		Address:    address.String(),
+		FromHeight: fromHeight,
+		ToHeight:   toHeight,
+	}
+	if coin != nil {
+		id := coin.Uint32()
+		filter.Coin = &id
+	}
+
+	balances, count, err := sqlIndexer.BalanceChanges(filter, indexer.Page{Order: order, Page: page, PerPage: perPage})
+	if err != nil {
+		return nil, indexerError(err)
+	}
+
+	return &BalanceChangesResponse{Balances: balances, TotalCount: count}, nil
+}
+
+func getIndexer() (*indexer.Indexer, error) {
+	sqlIndexer := blockchain.Indexer()
+	if sqlIndexer == nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: "SQL indexer is disabled on this node"}
+	}
+
+	return sqlIndexer, nil
+}
+
+func indexerError(err error) error {
+	if err == indexer.ErrInvalidOrder || err == indexer.ErrInvalidRole {
+		return rpctypes.RPCError{Code: 400, Message: err.Error()}
+	}
+
+	return rpctypes.RPCError{Code: 500, Message: "Cannot query SQL indexer", Data: err.Error()}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+
+	rpctypes "github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+const validatorsPerPage = 100
+
+type LightBlockResponse struct {
+	SignedHeader tmTypes.SignedHeader       `json:"signed_header"`
+	Validators   abciTypes.ValidatorUpdates `json:"validators"`
+}
+
+// LightBlock returns signed header of the block and validators which signed it.
+// Validators are requested from Tendermint by pages and converted to validator updates of their public keys and voting powers,
+// the set is complete when its hash matches the validators hash of the header.
+func LightBlock(height int64) (*LightBlockResponse, error) {
+	var h *int64
+	if height != 0 {
+		h = &height
+	}
+
+	commit, err := client.Commit(h)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: "Block not found", Data: err.Error()}
+	}
+
+	height = commit.Height
+	response := LightBlockResponse{SignedHeader: commit.SignedHeader}
+
+	var vals []*tmTypes.Validator
+	for page := 1; ; page++ {
+		tmVals, err := client.Validators(&height, page, validatorsPerPage)
+		if err != nil && page > 1 && validatorsMatchHeader(vals, commit.Header) {
+			// previous page was the last full one
+			break
+		}
+		if err != nil {
+			return nil, rpctypes.RPCError{Code: 404, Message: "Validators not found", Data: err.Error()}
+		}
+
+		vals = append(vals, tmVals.Validators...)
+
+		if len(tmVals.Validators) < validatorsPerPage {
+			break
+		}
+	}
+
+	if !validatorsMatchHeader(vals, commit.Header) {
+		return nil, rpctypes.RPCError{Code: 500, Message: "Validators do not match the header"}
+	}
+
+	for _, val := range vals {
+		response.Validators = append(response.Validators, tmTypes.TM2PB.ValidatorUpdate(val))
+	}
+
+	return &response, nil
+}
+
+func validatorsMatchHeader(vals []*tmTypes.Validator, header *tmTypes.Header) bool {
+	return len(vals) > 0 && bytes.Equal(tmTypes.NewValidatorSet(vals).Hash(), header.ValidatorsHash)
+}
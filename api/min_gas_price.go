@@ -1,5 +1,31 @@
 package api
 
+type MinGasPricesResponse struct {
+	MinGasPrice              uint64            `json:"min_gas_price"`
+	MinGasPriceByType        map[string]uint64 `json:"min_gas_price_by_type"`
+	PayloadGasPriceSurcharge uint64            `json:"payload_gas_price_surcharge"`
+	PayloadSurchargeBytes    int               `json:"payload_surcharge_bytes"`
+}
+
 func MinGasPrice() (uint64, error) {
 	return uint64(blockchain.MinGasPrice()), nil
 }
+
+// MinGasPrices returns minimal gas prices of transactions accepted to mempool by transaction types and payload surcharge
+func MinGasPrices() (*MinGasPricesResponse, error) {
+	byType := blockchain.MinGasPriceByType()
+	response := &MinGasPricesResponse{
+		MinGasPrice:       uint64(blockchain.MinGasPrice()),
+		MinGasPriceByType: make(map[string]uint64, len(byType)),
+	}
+
+	for name, price := range byType {
+		response.MinGasPriceByType[name] = uint64(price)
+	}
+
+	surcharge, bytes := blockchain.PayloadGasPriceSurcharge()
+	response.PayloadGasPriceSurcharge = uint64(surcharge)
+	response.PayloadSurchargeBytes = bytes
+
+	return response, nil
+}
@@ -16,12 +16,6 @@ func MissedBlocks(pubkey types.Pubkey, height int) (*MissedBlocksResponse, error
 		return nil, err
 	}
 
-	if height != 0 {
-		cState.Lock()
-		cState.Validators().LoadValidators()
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 	"github.com/noah-blockchain/noah-go-node/version"
 	core_types "github.com/tendermint/tendermint/rpc/core/types"
 	"time"
@@ -15,6 +16,7 @@ type StatusResponse struct {
 	LatestBlockTime   time.Time                `json:"latest_block_time"`
 	KeepLastStates    int64                    `json:"keep_last_states"`
 	TmStatus          *core_types.ResultStatus `json:"tm_status"`
+	NextUpgrade       *upgrades.Upgrade        `json:"next_upgrade"`
 }
 
 func Status() (*StatusResponse, error) {
@@ -31,5 +33,6 @@ func Status() (*StatusResponse, error) {
 		LatestBlockHeight: result.SyncInfo.LatestBlockHeight,
 		LatestBlockTime:   result.SyncInfo.LatestBlockTime,
 		TmStatus:          result,
+		NextUpgrade:       upgrades.Next(uint64(result.SyncInfo.LatestBlockHeight)),
 	}, nil
 }
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/server"
+	"github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	core_types "github.com/tendermint/tendermint/rpc/core/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// EventTypeApp is the value of tm.event key of app events, e.g. tm.event='AppEvent' AND event.type='noah/RewardEvent'.
+// Events of an app event also contain event.address, event.validator_pub_key and event.height keys.
+const EventTypeApp = "AppEvent"
+
+const (
+	subscribeTimeout = 15 * time.Second
+
+	// buffer of subscriptions to allow some slowness in clients
+	subscriptionBufferSize = 100
+
+	// eventsSubscriber is the name of API in subscriptions to Tendermint events
+	eventsSubscriber = "noah-api"
+)
+
+// subscriptions republishes new blocks and transactions of Tendermint and app events of committed blocks to websocket clients
+var subscriptions *tmpubsub.Server
+
+var WebsocketRoutes = map[string]*rpcserver.RPCFunc{
+	"subscribe":       rpcserver.NewWSRPCFunc(Subscribe, "query"),
+	"unsubscribe":     rpcserver.NewWSRPCFunc(Unsubscribe, "query"),
+	"unsubscribe_all": rpcserver.NewWSRPCFunc(UnsubscribeAll, ""),
+}
+
+type SubscribeResponse struct{}
+
+// EventResponse is written to the websocket for every event matching the query of subscription.
+// Data is a block or a transaction of Tendermint or an app event.
+type EventResponse struct {
+	Query  string              `json:"query"`
+	Data   json.RawMessage     `json:"data"`
+	Events map[string][]string `json:"events"`
+}
+
+// Subscribe subscribes the websocket connection to events matching the query, query language is the same as in Subscribe of API v2
+func Subscribe(wsCtx rpctypes.WSRPCContext, query string) (*SubscribeResponse, error) {
+	subscriber := wsCtx.GetRemoteAddr()
+
+	if subscriptions.NumClients() >= noahCfg.RPC.MaxSubscriptionClients {
+		return nil, rpctypes.RPCError{Code: 429, Message: fmt.Sprintf("max_subscription_clients %d reached", noahCfg.RPC.MaxSubscriptionClients)}
+	}
+	if subscriptions.NumClientSubscriptions(subscriber) >= noahCfg.RPC.MaxSubscriptionsPerClient {
+		return nil, rpctypes.RPCError{Code: 429, Message: fmt.Sprintf("max_subscriptions_per_client %d reached", noahCfg.RPC.MaxSubscriptionsPerClient)}
+	}
+
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 400, Message: "Failed to parse query", Data: err.Error()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), subscribeTimeout)
+	defer cancel()
+
+	sub, err := subscriptions.Subscribe(ctx, subscriber, q, subscriptionBufferSize)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 400, Message: err.Error()}
+	}
+
+	// capture the ID of the request, events are written with it
+	subscriptionID := wsCtx.Request.ID
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				data, err := wsCtx.Codec().MarshalJSON(msg.Data())
+				if err != nil {
+					wsCtx.TryWriteRPCResponse(rpctypes.RPCInternalError(subscriptionID, err))
+					continue
+				}
+
+				wsCtx.TryWriteRPCResponse(rpctypes.NewRPCSuccessResponse(wsCtx.Codec(), subscriptionID, &EventResponse{
+					Query:  query,
+					Data:   data,
+					Events: msg.Events(),
+				}))
+			case <-sub.Cancelled():
+				if sub.Err() != tmpubsub.ErrUnsubscribed {
+					reason := "API stopped"
+					if sub.Err() != nil {
+						reason = sub.Err().Error()
+					}
+					wsCtx.TryWriteRPCResponse(rpctypes.RPCServerError(subscriptionID, fmt.Errorf("subscription was cancelled (reason: %s)", reason)))
+				}
+				return
+			}
+		}
+	}()
+
+	return &SubscribeResponse{}, nil
+}
+
+// Unsubscribe cancels the subscription of the websocket connection with the query
+func Unsubscribe(wsCtx rpctypes.WSRPCContext, query string) (*SubscribeResponse, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, rpctypes.RPCError{Code: 400, Message: "Failed to parse query", Data: err.Error()}
+	}
+
+	if err := subscriptions.Unsubscribe(context.Background(), wsCtx.GetRemoteAddr(), q); err != nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: err.Error()}
+	}
+
+	return &SubscribeResponse{}, nil
+}
+
+// UnsubscribeAll cancels all subscriptions of the websocket connection
+func UnsubscribeAll(wsCtx rpctypes.WSRPCContext) (*SubscribeResponse, error) {
+	if err := subscriptions.UnsubscribeAll(context.Background(), wsCtx.GetRemoteAddr()); err != nil {
+		return nil, rpctypes.RPCError{Code: 404, Message: err.Error()}
+	}
+
+	return &SubscribeResponse{}, nil
+}
+
+// startSubscriptions subscribes to new blocks and transactions of Tendermint and republishes them with app events
+// of every new block to subscriptions
+func startSubscriptions(logger log.Logger) (*tmpubsub.Server, error) {
+	server := tmpubsub.NewServer()
+	server.SetLogger(logger)
+	if err := server.Start(); err != nil {
+		return nil, err
+	}
+
+	blocks, err := client.Subscribe(context.Background(), eventsSubscriber, tmTypes.EventQueryNewBlock.String(), subscriptionBufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := client.Subscribe(context.Background(), eventsSubscriber, tmTypes.EventQueryTx.String(), subscriptionBufferSize)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg, ok := <-blocks:
+				if !ok {
+					return
+				}
+				publishEvent(server, msg, logger)
+
+				if block, ok := msg.Data.(tmTypes.EventDataNewBlock); ok {
+					publishAppEvents(server, uint64(block.Block.Height), logger)
+				}
+			case msg, ok := <-txs:
+				if !ok {
+					return
+				}
+				publishEvent(server, msg, logger)
+			}
+		}
+	}()
+
+	return server, nil
+}
+
+func publishEvent(server *tmpubsub.Server, msg core_types.ResultEvent, logger log.Logger) {
+	if err := server.PublishWithEvents(context.Background(), msg.Data, msg.Events); err != nil {
+		logger.Error("Failed to publish event", "query", msg.Query, "err", err)
+	}
+}
+
+// publishAppEvents publishes events of the committed block stored by the app
+func publishAppEvents(server *tmpubsub.Server, height uint64, logger log.Logger) {
+	for _, event := range blockchain.GetEventsDB().LoadEvents(uint32(height)) {
+		err := server.PublishWithEvents(context.Background(), event, map[string][]string{
+			tmTypes.EventTypeKey:      {EventTypeApp},
+			"event.type":              {event.Type()},
+			"event.address":           {event.AddressString()},
+			"event.validator_pub_key": {event.ValidatorPubKeyString()},
+			"event.height":            {strconv.FormatUint(height, 10)},
+		})
+		if err != nil {
+			logger.Error("Failed to publish app event", "height", height, "err", err)
+		}
+	}
+}
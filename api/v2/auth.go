@@ -0,0 +1,75 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/noah-blockchain/noah-go-node/api/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func authUnaryInterceptor(apiAuth *auth.Auth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, apiAuth, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(apiAuth *auth.Auth) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), apiAuth, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// authHandler checks requests to HTTP handlers which are served without gRPC, method is the name of the handler
+func authHandler(apiAuth *auth.Auth, method string, h http.Handler) http.Handler {
+	if apiAuth == nil {
+		return h
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := apiAuth.Authorize(r.Header.Get("Authorization"), method); err != nil {
+			writeHTTPResponse(w, r, nil, status.Error(authCode(err), err.Error()))
+			return
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorize checks the bearer token from metadata of the call, HTTP gateway passes Authorization header as metadata
+func authorize(ctx context.Context, apiAuth *auth.Auth, method string) error {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) != 0 {
+			authorization = values[0]
+		}
+	}
+
+	if _, err := apiAuth.Authorize(authorization, method); err != nil {
+		return status.Error(authCode(err), err.Error())
+	}
+
+	return nil
+}
+
+func authCode(err error) codes.Code {
+	switch err {
+	case auth.ErrMethodNotAllowed:
+		return codes.PermissionDenied
+	case auth.ErrRateLimited:
+		return codes.ResourceExhausted
+	default:
+		return codes.Unauthenticated
+	}
+}
@@ -0,0 +1,105 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/noah-blockchain/noah-go-node/api/v2/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkHandler serves status of the check, GET /v2/check?check=0x...&height=
+func checkHandler(srv *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		height, err := parseUintParam(query.Get("height"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		response, err := srv.Check(ctx, query.Get("check"), height)
+		writeHTTPResponse(w, r, response, err)
+	}
+}
+
+// checksHandler serves checks redeemed from the issuer, GET /v2/checks?issuer=Mx...&page=&per_page=
+func checksHandler(srv *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, err := parseUintParam(query.Get("page"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		perPage, err := parseUintParam(query.Get("per_page"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		response, err := srv.Checks(ctx, query.Get("issuer"), int(page), int(perPage))
+		writeHTTPResponse(w, r, response, err)
+	}
+}
+
+// addressTransactionsHandler serves transactions of the address from SQL indexer,
+// GET /v2/address_transactions?address=Mx...&role=&order=&page=&per_page=
+func addressTransactionsHandler(srv *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		page, err := parseUintParam(query.Get("page"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		perPage, err := parseUintParam(query.Get("per_page"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		response, err := srv.AddressTransactions(ctx, query.Get("address"), query.Get("role"), query.Get("order"), int(page), int(perPage))
+		writeHTTPResponse(w, r, response, err)
+	}
+}
+
+func parseUintParam(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid number %s", value)
+	}
+
+	return parsed, nil
+}
+
+// writeHTTPResponse writes response of handlers which are not in gRPC schema, errors are in the same form as errors of gateway
+func writeHTTPResponse(w http.ResponseWriter, r *http.Request, response interface{}, err error) {
+	marshaler := &runtime.JSONPb{OrigName: true, EmitDefaults: true}
+	if err != nil {
+		httpError(r.Context(), nil, marshaler, w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", marshaler.ContentType())
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,30 @@
+package v2
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/noah-blockchain/noah-go-node/api/v2/service"
+)
+
+// coinHistoryHandler serves candles of price of the coin, GET /v2/coin_history?id=&from=&to=&interval=
+func coinHistoryHandler(srv *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var params [4]uint64
+		for i, name := range []string{"id", "from", "to", "interval"} {
+			value, err := parseUintParam(query.Get(name))
+			if err != nil {
+				writeHTTPResponse(w, r, nil, err)
+				return
+			}
+			params[i] = value
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		response, err := srv.CoinHistory(ctx, params[0], params[1], params[2], params[3])
+		writeHTTPResponse(w, r, response, err)
+	}
+}
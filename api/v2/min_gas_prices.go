@@ -0,0 +1,15 @@
+package v2
+
+import (
+	"net/http"
+
+	"github.com/noah-blockchain/noah-go-node/api/v2/service"
+)
+
+// minGasPricesHandler serves minimal gas prices by transaction types, GET /v2/min_gas_prices
+func minGasPricesHandler(srv *service.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response, err := srv.MinGasPrices(r.Context())
+		writeHTTPResponse(w, r, response, err)
+	}
+}
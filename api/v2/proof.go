@@ -0,0 +1,108 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/noah-blockchain/noah-go-node/api/v2/service"
+	pb "github.com/noah-blockchain/node-grpc-gateway/api_pb"
+)
+
+// provenResponse is the response of gRPC method extended with proof of the state, response is nil if the item is absent
+type provenResponse struct {
+	response interface{}
+	proof    interface{}
+}
+
+// MarshalJSON adds the proof to the fields of the response encoded the same way as by the gateway
+func (p *provenResponse) MarshalJSON() ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if p.response != nil {
+		data, err := (&runtime.JSONPb{OrigName: true, EmitDefaults: true}).Marshal(p.response)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	proof, err := json.Marshal(p.proof)
+	if err != nil {
+		return nil, err
+	}
+	fields["proof"] = proof
+
+	return json.Marshal(fields)
+}
+
+// proofHandler returns the handler of gateway path requested with prove=true and the name of its method, nil if the path has no proofs.
+// GET /v2/address/{address}, /v2/candidate/{public_key} and /v2/coin_info/{symbol} with prove=true respond with proof of the
+// committed state. Absent candidate and coin are not errors, the response contains only the proof of absence.
+func proofHandler(srv *service.Service, path string) (string, http.Handler) {
+	switch {
+	case strings.HasPrefix(path, "/v2/address/"):
+		return "Address", provenHandler(srv, func(ctx context.Context, height uint64, query url.Values) (interface{}, interface{}, error) {
+			response, proof, err := srv.AddressProof(ctx, &pb.AddressRequest{
+				Address:   strings.TrimPrefix(path, "/v2/address/"),
+				Height:    height,
+				Delegated: query.Get("delegated") == "true",
+			})
+			if err != nil || response == nil {
+				return nil, proof, err
+			}
+			return response, proof, nil
+		})
+	case strings.HasPrefix(path, "/v2/candidate/"):
+		return "Candidate", provenHandler(srv, func(ctx context.Context, height uint64, _ url.Values) (interface{}, interface{}, error) {
+			response, proof, err := srv.CandidateProof(ctx, &pb.CandidateRequest{
+				PublicKey: strings.TrimPrefix(path, "/v2/candidate/"),
+				Height:    height,
+			})
+			if err != nil || response == nil {
+				return nil, proof, err
+			}
+			return response, proof, nil
+		})
+	case strings.HasPrefix(path, "/v2/coin_info/"):
+		return "CoinInfo", provenHandler(srv, func(ctx context.Context, height uint64, _ url.Values) (interface{}, interface{}, error) {
+			response, proof, err := srv.CoinInfoProof(ctx, &pb.CoinInfoRequest{
+				Symbol: strings.TrimPrefix(path, "/v2/coin_info/"),
+				Height: height,
+			})
+			if err != nil || response == nil {
+				return nil, proof, err
+			}
+			return response, proof, nil
+		})
+	}
+
+	return "", nil
+}
+
+func provenHandler(srv *service.Service, prove func(ctx context.Context, height uint64, query url.Values) (interface{}, interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		height, err := parseUintParam(query.Get("height"))
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), srv.TimeoutDuration())
+		defer cancel()
+
+		response, proof, err := prove(ctx, height, query)
+		if err != nil {
+			writeHTTPResponse(w, r, nil, err)
+			return
+		}
+
+		writeHTTPResponse(w, r, &provenResponse{response: response, proof: proof}, nil)
+	}
+}
@@ -36,13 +36,6 @@ func (s *Service) Address(ctx context.Context, req *pb.AddressRequest) (*pb.Addr
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if req.Height != 0 && req.Delegated {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		cState.Candidates().LoadStakes()
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
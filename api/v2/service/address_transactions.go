@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/noah-blockchain/noah-go-node/core/indexer"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddressTransactionsResponse is the page of transactions of the address, it is served over HTTP only since the SQL indexer is not in gRPC schema
+type AddressTransactionsResponse struct {
+	Txs        []indexer.AddressTx `json:"txs"`
+	TotalCount int                 `json:"total_count"`
+}
+
+// AddressTransactions returns transactions in which the address is involved with its roles in them, SQL indexer should be enabled on the node.
+func (s *Service) AddressTransactions(ctx context.Context, address, role, order string, page, perPage int) (*AddressTransactionsResponse, error) {
+	sqlIndexer := s.blockchain.Indexer()
+	if sqlIndexer == nil {
+		return nil, status.Error(codes.FailedPrecondition, "SQL indexer is disabled on this node")
+	}
+
+	if !strings.HasPrefix(strings.Title(address), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(address[2:])
+	if err != nil || len(decodeString) != types.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	txs, count, err := sqlIndexer.AddressTransactions(types.BytesToAddress(decodeString).String(), role,
+		indexer.Page{Order: order, Page: page, PerPage: perPage})
+	if err != nil {
+		if err == indexer.ErrInvalidOrder || err == indexer.ErrInvalidRole {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &AddressTransactionsResponse{Txs: txs, TotalCount: count}, nil
+}
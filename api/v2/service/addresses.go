@@ -18,13 +18,6 @@ func (s *Service) Addresses(ctx context.Context, req *pb.AddressesRequest) (*pb.
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if req.Height != 0 && req.Delegated {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		cState.Candidates().LoadStakes()
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
@@ -56,7 +56,7 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 			return nil, timeoutStatus.Err()
 		}
 
-		currentState := s.blockchain.CurrentState()
+		currentState := s.blockchain.ReadState()
 		currentState.RLock()
 		defer currentState.RUnlock()
 
@@ -125,7 +125,7 @@ func (s *Service) Block(ctx context.Context, req *pb.BlockRequest) (*pb.BlockRes
 		case pb.BlockRequest_block_reward:
 			response.BlockReward = rewards.GetRewardForBlock(uint64(height)).String()
 		case pb.BlockRequest_transactions:
-			cState := s.blockchain.CurrentState()
+			cState := s.blockchain.ReadState()
 
 			response.Transactions, err = s.blockTransaction(block, blockResults, cState.Coins())
 			if err != nil {
@@ -30,13 +30,6 @@ func (s *Service) Candidate(ctx context.Context, req *pb.CandidateRequest) (*pb.
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if req.Height != 0 {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		cState.Candidates().LoadStakesOfCandidate(pubkey)
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
@@ -14,15 +14,6 @@ func (s *Service) Candidates(ctx context.Context, req *pb.CandidatesRequest) (*p
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if req.Height != 0 {
-		cState.Lock()
-		cState.Candidates().LoadCandidates()
-		if req.IncludeStakes {
-			cState.Candidates().LoadStakes()
-		}
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	pb "github.com/noah-blockchain/node-grpc-gateway/api_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckResponse is status of the check, it is served over HTTP only since check methods are not in gRPC schema
+type CheckResponse struct {
+	Hash           string   `json:"hash"`
+	Issuer         string   `json:"issuer"`
+	Nonce          string   `json:"nonce"`
+	ChainId        uint64   `json:"chain_id"`
+	DueBlock       uint64   `json:"due_block"`
+	Coin           *pb.Coin `json:"coin"`
+	Value          string   `json:"value"`
+	GasCoin        *pb.Coin `json:"gas_coin"`
+	Partial        bool     `json:"partial"`
+	Used           bool     `json:"used"`
+	Expired        bool     `json:"expired"`
+	RemainingValue string   `json:"remaining_value"`
+	Commission     string   `json:"commission"`
+	Covered        bool     `json:"covered"`
+}
+
+// ChecksResponse is the list of checks redeemed from the issuer
+type ChecksResponse struct {
+	Checks []*ChecksResponse_Check `json:"checks"`
+}
+
+type ChecksResponse_Check struct {
+	TxHash    string   `json:"tx_hash"`
+	Height    uint64   `json:"height"`
+	CheckHash string   `json:"check_hash"`
+	Receiver  string   `json:"receiver"`
+	Coin      *pb.Coin `json:"coin"`
+	Value     string   `json:"value"`
+}
+
+// Check decodes the raw check, verifies its signature and lock and reports whether it can be redeemed at the height.
+func (s *Service) Check(ctx context.Context, rawCheck string, height uint64) (*CheckResponse, error) {
+	if !strings.HasPrefix(strings.Title(rawCheck), "0x") {
+		return nil, status.Error(codes.InvalidArgument, "invalid check")
+	}
+
+	decodeString, err := hex.DecodeString(rawCheck[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	decodedCheck, err := check.DecodeFromBytes(decodeString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Cannot decode check: %s", err.Error())
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	// the check is redeemed in the block next to the state, the same way as RedeemCheck sees it
+	currentBlock := uint64(cState.Tree().Version()) + 1
+
+	checkStatus, err := transaction.GetCheckStatus(cState, decodedCheck, currentBlock)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid check: %s", err.Error())
+	}
+
+	return &CheckResponse{
+		Hash:     hex.EncodeToString(decodedCheck.Hash().Bytes()),
+		Issuer:   checkStatus.Issuer.String(),
+		Nonce:    hex.EncodeToString(decodedCheck.Nonce),
+		ChainId:  uint64(decodedCheck.ChainID),
+		DueBlock: decodedCheck.DueBlock,
+		Coin: &pb.Coin{
+			Id:     uint64(decodedCheck.Coin),
+			Symbol: cState.Coins().GetCoin(decodedCheck.Coin).GetFullSymbol(),
+		},
+		Value: decodedCheck.Value.String(),
+		GasCoin: &pb.Coin{
+			Id:     uint64(decodedCheck.GasCoin),
+			Symbol: cState.Coins().GetCoin(decodedCheck.GasCoin).GetFullSymbol(),
+		},
+		Partial:        decodedCheck.IsPartial(),
+		Used:           checkStatus.Used,
+		Expired:        checkStatus.Expired,
+		RemainingValue: checkStatus.RemainingValue.String(),
+		Commission:     checkStatus.Commission.String(),
+		Covered:        checkStatus.Covered,
+	}, nil
+}
+
+// Checks returns checks of the issuer redeemed by successful transactions, tx indexing should be enabled on the node.
+func (s *Service) Checks(ctx context.Context, issuer string, page, perPage int) (*ChecksResponse, error) {
+	if !strings.HasPrefix(strings.Title(issuer), "Mx") {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	decodeString, err := hex.DecodeString(issuer[2:])
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid address")
+	}
+
+	query := fmt.Sprintf("tx.type='%s' AND tx.from='%s'",
+		hex.EncodeToString([]byte{byte(transaction.TypeRedeemCheck)}), hex.EncodeToString(decodeString))
+	rpcResult, err := s.client.TxSearch(query, false, page, perPage, "desc")
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	cState := s.blockchain.ReadState()
+	cState.RLock()
+	defer cState.RUnlock()
+
+	response := &ChecksResponse{Checks: make([]*ChecksResponse_Check, 0, len(rpcResult.Txs))}
+	for _, tx := range rpcResult.Txs {
+		if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+			return nil, timeoutStatus.Err()
+		}
+
+		tags := make(map[string]string)
+		for _, tag := range tx.TxResult.Events[0].Attributes {
+			tags[string(tag.Key)] = string(tag.Value)
+		}
+
+		coinID, _ := strconv.ParseUint(tags["tx.coin_id"], 10, 32)
+		coin := types.CoinID(coinID)
+
+		// transactions of older versions have no tags of check hash and value
+		checkHash, value := tags["tx.check_hash"], tags["tx.value"]
+		if checkHash == "" || value == "" {
+			decodedTx, err := transaction.TxDecoder.DecodeFromBytes(tx.Tx)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+
+			decodedCheck, err := check.DecodeFromBytes(decodedTx.GetDecodedData().(*transaction.RedeemCheckData).RawCheck)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+
+			checkHash, value = hex.EncodeToString(decodedCheck.Hash().Bytes()), decodedCheck.Value.String()
+		}
+
+		response.Checks = append(response.Checks, &ChecksResponse_Check{
+			TxHash:    "Mt" + strings.ToLower(hex.EncodeToString(tx.Tx.Hash())),
+			Height:    uint64(tx.Height),
+			CheckHash: checkHash,
+			Receiver:  types.HexToAddress(tags["tx.to"]).String(),
+			Coin: &pb.Coin{
+				Id:     uint64(coin),
+				Symbol: cState.Coins().GetCoin(coin).GetFullSymbol(),
+			},
+			Value: value,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/coinhistory"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultCoinHistoryInterval is the interval of candles in seconds used if it is not set
+const defaultCoinHistoryInterval = 3600
+
+// CoinHistoryResponse is the list of candles of the coin, it is served over HTTP only since coin history is not in gRPC schema
+type CoinHistoryResponse struct {
+	Candles []coinhistory.Candle `json:"candles"`
+}
+
+// CoinHistory returns OHLC candles of price of the coin in base coin between unix times from and to with interval in seconds.
+func (s *Service) CoinHistory(ctx context.Context, id, from, to, interval uint64) (*CoinHistoryResponse, error) {
+	if s.blockchain.CoinHistory() == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Coin history is disabled on this node")
+	}
+
+	cState, err := s.blockchain.GetStateForHeight(0)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	cState.RLock()
+	defer cState.RUnlock()
+
+	if !cState.Coins().Exists(types.CoinID(id)) {
+		return nil, status.Error(codes.NotFound, "Coin not found")
+	}
+
+	if interval == 0 {
+		interval = defaultCoinHistoryInterval
+	}
+	if to == 0 {
+		to = uint64(time.Now().Unix())
+	}
+	if from == 0 && to > interval*(coinhistory.MaxCandles-1) {
+		from = to - interval*(coinhistory.MaxCandles-1)
+	}
+
+	if timeoutStatus := s.checkTimeout(ctx); timeoutStatus != nil {
+		return nil, timeoutStatus.Err()
+	}
+
+	candles, err := s.blockchain.CoinHistory().Candles(types.CoinID(id), time.Unix(int64(from), 0), time.Unix(int64(to), 0), time.Duration(interval)*time.Second)
+	if err != nil {
+		if err == coinhistory.ErrInvalidRange || err == coinhistory.ErrTooManyCandles || err == coinhistory.ErrInvalidInterval {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if candles == nil {
+		candles = []coinhistory.Candle{}
+	}
+
+	return &CoinHistoryResponse{Candles: candles}, nil
+}
@@ -19,7 +19,7 @@ func (s *Service) Frozen(ctx context.Context, req *pb.FrozenRequest) (*pb.Frozen
 		return nil, status.Error(codes.InvalidArgument, "invalid address")
 	}
 
-	cState := s.blockchain.CurrentState()
+	cState := s.blockchain.ReadState()
 	cState.RLock()
 	defer cState.RUnlock()
 
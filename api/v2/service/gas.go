@@ -26,3 +26,30 @@ func (s *Service) MaxGasPrice(ctx context.Context, req *pb.MaxGasPriceRequest) (
 		MaxGasPrice: cState.App().GetMaxGas(),
 	}, nil
 }
+
+// MinGasPricesResponse is the minimal gas prices by transaction types, it is served over HTTP only since they are not in gRPC schema
+type MinGasPricesResponse struct {
+	MinGasPrice              uint64            `json:"min_gas_price"`
+	MinGasPriceByType        map[string]uint64 `json:"min_gas_price_by_type"`
+	PayloadGasPriceSurcharge uint64            `json:"payload_gas_price_surcharge"`
+	PayloadSurchargeBytes    int               `json:"payload_surcharge_bytes"`
+}
+
+// MinGasPrices returns minimal gas prices of transactions accepted to mempool by transaction types and payload surcharge.
+func (s *Service) MinGasPrices(context.Context) (*MinGasPricesResponse, error) {
+	byType := s.blockchain.MinGasPriceByType()
+	response := &MinGasPricesResponse{
+		MinGasPrice:       uint64(s.blockchain.MinGasPrice()),
+		MinGasPriceByType: make(map[string]uint64, len(byType)),
+	}
+
+	for name, price := range byType {
+		response.MinGasPriceByType[name] = uint64(price)
+	}
+
+	surcharge, bytes := s.blockchain.PayloadGasPriceSurcharge()
+	response.PayloadGasPriceSurcharge = uint64(surcharge)
+	response.PayloadSurchargeBytes = bytes
+
+	return response, nil
+}
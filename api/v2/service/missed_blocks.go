@@ -20,12 +20,6 @@ func (s *Service) MissedBlocks(ctx context.Context, req *pb.MissedBlocksRequest)
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
 
-	if req.Height != 0 {
-		cState.Lock()
-		cState.Validators().LoadValidators()
-		cState.Unlock()
-	}
-
 	cState.RLock()
 	defer cState.RUnlock()
 
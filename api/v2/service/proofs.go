@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	pb "github.com/noah-blockchain/node-grpc-gateway/api_pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddressProof returns Address response for the committed state with proof of it, it is served over HTTP only since proofs are not in gRPC schema.
+func (s *Service) AddressProof(ctx context.Context, req *pb.AddressRequest) (*pb.AddressResponse, *proofs.AddressProof, error) {
+	stateTree, err := s.provenTree(&req.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.Address(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decodeString, _ := hex.DecodeString(req.Address[2:])
+	proof, err := proofs.NewAddressProof(stateTree, types.BytesToAddress(decodeString))
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return response, proof, nil
+}
+
+// CandidateProof returns Candidate response for the committed state with proof of it, response is nil if the candidate is not found.
+// It is served over HTTP only since proofs are not in gRPC schema.
+func (s *Service) CandidateProof(ctx context.Context, req *pb.CandidateRequest) (*pb.CandidateResponse, *proofs.CandidateProof, error) {
+	stateTree, err := s.provenTree(&req.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.Candidate(ctx, req)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, nil, err
+	}
+
+	decodeString, _ := hex.DecodeString(req.PublicKey[2:])
+	proof, err := proofs.NewCandidateProof(stateTree, types.BytesToPubkey(decodeString))
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return response, proof, nil
+}
+
+// CoinInfoProof returns CoinInfo response for the committed state with proof of it, response is nil if the coin is not found.
+// It is served over HTTP only since proofs are not in gRPC schema.
+func (s *Service) CoinInfoProof(ctx context.Context, req *pb.CoinInfoRequest) (*pb.CoinInfoResponse, *proofs.CoinProof, error) {
+	stateTree, err := s.provenTree(&req.Height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response, err := s.CoinInfo(ctx, req)
+	if err != nil && status.Code(err) != codes.NotFound {
+		return nil, nil, err
+	}
+
+	proof, err := proofs.NewCoinSymbolProof(stateTree, types.StrToCoinBaseSymbol(req.Symbol), types.GetVersionFromSymbol(req.Symbol))
+	if err != nil {
+		return nil, nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return response, proof, nil
+}
+
+// provenTree returns state tree of given committed height, height 0 is replaced with the latest committed height,
+// so the response and the proof are of the same state
+func (s *Service) provenTree(height *uint64) (*tree.ImmutableTree, error) {
+	if *height == 0 {
+		*height = s.blockchain.LastCommittedHeight()
+	}
+
+	stateTree, err := s.blockchain.GetImmutableTreeAtHeight(*height)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return stateTree, nil
+}
@@ -17,7 +17,7 @@ func (s *Service) Status(context.Context, *empty.Empty) (*pb.StatusResponse, err
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	cState := s.blockchain.CurrentState()
+	cState := s.blockchain.ReadState()
 	cState.RLock()
 	defer cState.RUnlock()
 
@@ -34,7 +34,7 @@ func (s *Service) Transaction(ctx context.Context, req *pb.TransactionRequest) (
 		tags[string(tag.Key)] = string(tag.Value)
 	}
 
-	cState := s.blockchain.CurrentState()
+	cState := s.blockchain.ReadState()
 
 	cState.RLock()
 	defer cState.RUnlock()
@@ -22,7 +22,7 @@ func (s *Service) Transactions(ctx context.Context, req *pb.TransactionsRequest)
 	result := make([]*pb.TransactionResponse, 0, lenTx)
 	if lenTx != 0 {
 
-		cState := s.blockchain.CurrentState()
+		cState := s.blockchain.ReadState()
 		cState.RLock()
 		defer cState.RUnlock()
 
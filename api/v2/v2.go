@@ -2,8 +2,10 @@ package v2
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"github.com/noah-blockchain/noah-go-node/api/auth"
 	"github.com/noah-blockchain/noah-go-node/api/v2/service"
 	gw "github.com/noah-blockchain/node-grpc-gateway/api_pb"
 	_ "github.com/noah-blockchain/node-grpc-gateway/statik"
@@ -21,6 +23,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
 	_struct "google.golang.org/protobuf/types/known/structpb"
@@ -33,7 +36,11 @@ import (
 )
 
 // Run initialises gRPC and API v2 interfaces
-func Run(srv *service.Service, addrGRPC, addrApi string, logger log.Logger) error {
+// Listeners use TLS if their configs are not nil, gateway connects to gRPC with gatewayTLS if grpcTLS is not nil.
+// Access is restricted by API keys if apiAuth is not nil.
+// GraphQL queries are served at /v2/graphql if graphQL is not nil.
+// Address, candidate and coin info requested with prove=true are served with proofs of the state, see proofHandler.
+func Run(srv *service.Service, addrGRPC, addrApi string, grpcTLS, gatewayTLS, apiTLS *tls.Config, apiAuth *auth.Auth, graphQL http.Handler, logger log.Logger) error {
 	lis, err := net.Listen("tcp", addrGRPC)
 	if err != nil {
 		return err
@@ -44,21 +51,35 @@ func Run(srv *service.Service, addrGRPC, addrApi string, logger log.Logger) erro
 	loggerOpts := []kit.Option{
 		kit.WithLevels(func(code codes.Code, logger kit_log.Logger) kit_log.Logger { return logger }),
 	}
-	grpcServer := grpc.NewServer(
-		grpc_middleware.WithStreamServerChain(
-			grpc_prometheus.StreamServerInterceptor,
-			grpc_recovery.StreamServerInterceptor(),
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		grpc_recovery.StreamServerInterceptor(),
+	}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		grpc_recovery.UnaryServerInterceptor(),
+	}
+	if apiAuth != nil {
+		streamInterceptors = append(streamInterceptors, authStreamInterceptor(apiAuth))
+		unaryInterceptors = append(unaryInterceptors, authUnaryInterceptor(apiAuth))
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc_middleware.WithStreamServerChain(append(streamInterceptors,
 			grpc_ctxtags.StreamServerInterceptor(requestExtractorFields()),
 			kit.StreamServerInterceptor(kitLogger, loggerOpts...),
-		),
-		grpc_middleware.WithUnaryServerChain(
-			grpc_prometheus.UnaryServerInterceptor,
-			grpc_recovery.UnaryServerInterceptor(),
+		)...),
+		grpc_middleware.WithUnaryServerChain(append(unaryInterceptors,
 			grpc_ctxtags.UnaryServerInterceptor(requestExtractorFields()),
 			kit.UnaryServerInterceptor(kitLogger, loggerOpts...),
 			unaryTimeoutInterceptor(srv.TimeoutDuration()),
-		),
-	)
+		)...),
+	}
+	if grpcTLS != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(grpcTLS)))
+	}
+
+	grpcServer := grpc.NewServer(serverOpts...)
 	runtime.GlobalHTTPErrorHandler = httpError
 	gw.RegisterApiServiceServer(grpcServer, srv)
 	grpc_prometheus.Register(grpcServer)
@@ -76,9 +97,13 @@ func Run(srv *service.Service, addrGRPC, addrApi string, logger log.Logger) erro
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
 	)
 	opts := []grpc.DialOption{
-		grpc.WithInsecure(),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(200000000)),
 	}
+	if grpcTLS != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(gatewayTLS)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
 	err = gw.RegisterApiServiceHandlerFromEndpoint(ctx, gwmux, addrGRPC, opts)
 	if err != nil {
 		return err
@@ -86,15 +111,33 @@ func Run(srv *service.Service, addrGRPC, addrApi string, logger log.Logger) erro
 	mux := http.NewServeMux()
 	openapi := "/v2/openapi-ui/"
 	_ = serveOpenAPI(openapi, mux)
+	mux.Handle("/v2/check", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "Check", checkHandler(srv)))))
+	mux.Handle("/v2/checks", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "Checks", checksHandler(srv)))))
+	mux.Handle("/v2/address_transactions", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "AddressTransactions", addressTransactionsHandler(srv)))))
+	mux.Handle("/v2/coin_history", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "CoinHistory", coinHistoryHandler(srv)))))
+	mux.Handle("/v2/min_gas_prices", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "MinGasPrices", minGasPricesHandler(srv)))))
+	if graphQL != nil {
+		mux.Handle("/v2/graphql", handlers.CompressHandler(allowCORS(authHandler(apiAuth, "GraphQL", graphQL))))
+	}
 	mux.HandleFunc("/v2/", func(writer http.ResponseWriter, request *http.Request) {
 		if request.URL.Path == "/v2/" {
 			http.Redirect(writer, request, openapi, 302)
 			return
 		}
+		if request.URL.Query().Get("prove") == "true" {
+			if method, handler := proofHandler(srv, request.URL.Path); handler != nil {
+				handlers.CompressHandler(allowCORS(authHandler(apiAuth, method, handler))).ServeHTTP(writer, request)
+				return
+			}
+		}
 		http.StripPrefix("/v2", handlers.CompressHandler(allowCORS(wsproxy.WebsocketProxy(gwmux)))).ServeHTTP(writer, request)
 	})
 	group.Go(func() error {
-		return http.ListenAndServe(addrApi, mux)
+		server := &http.Server{Addr: addrApi, Handler: mux, TLSConfig: apiTLS}
+		if apiTLS != nil {
+			return server.ListenAndServeTLS("", "")
+		}
+		return server.ListenAndServe()
 	})
 
 	return group.Wait()
@@ -114,7 +157,7 @@ func allowCORS(h http.Handler) http.Handler {
 }
 
 func preflightHandler(w http.ResponseWriter, _ *http.Request) {
-	headers := []string{"Content-Type", "Accept"}
+	headers := []string{"Content-Type", "Accept", "Authorization"}
 	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ","))
 	methods := []string{"GET", "HEAD", "POST", "PUT", "DELETE"}
 	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
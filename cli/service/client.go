@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	pb "github.com/noah-blockchain/noah-go-node/cli/cli_pb"
 	"github.com/c-bata/go-prompt"
@@ -12,6 +13,7 @@ import (
 	"github.com/marcusolsson/tui-go"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/protobuf/encoding/protojson"
 	"io"
 	"log"
@@ -107,11 +109,17 @@ func (mc *ManagerConsole) Cli(ctx context.Context) {
 	}
 }
 
-func NewCLI(socketPath string) (*ManagerConsole, error) {
+// NewCLI connects to the manager on the unix socket, the connection uses TLS if tlsConfig is not nil
+func NewCLI(socketPath string, tlsConfig *tls.Config) (*ManagerConsole, error) {
+	transport := grpc.WithInsecure()
+	if tlsConfig != nil {
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
 	cc, err := grpc.Dial("passthrough:///unix:///"+socketPath,
 		grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor()),
 		grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor()),
-		grpc.WithInsecure())
+		transport)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +178,37 @@ func NewCLI(socketPath string) (*ManagerConsole, error) {
 			Usage:   "Show dashboard",
 			Action:  dashboardCMD(client),
 		},
+		{
+			Name:    "add_webhook",
+			Aliases: []string{"aw"},
+			Usage:   "register URL receiving signed payloads about activity of addresses and candidates",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "url", Aliases: []string{"u"}, Required: true},
+				&cli.StringFlag{Name: "secret", Aliases: []string{"s"}, Required: true, Usage: "key of HMAC-SHA256 signature of payloads"},
+				&cli.StringSliceFlag{Name: "address", Aliases: []string{"a"}, Required: false, Usage: "watched address NOAHx..."},
+				&cli.StringSliceFlag{Name: "pub_key", Aliases: []string{"p"}, Required: false, Usage: "watched candidate Mp..."},
+				jsonFlag,
+			},
+			Action: addWebhookCMD(client),
+		},
+		{
+			Name:    "remove_webhook",
+			Aliases: []string{"rw"},
+			Usage:   "unregister webhook and drop its pending payloads",
+			Flags: []cli.Flag{
+				&cli.Uint64Flag{Name: "id", Aliases: []string{"i"}, Required: true},
+			},
+			Action: removeWebhookCMD(client),
+		},
+		{
+			Name:    "webhooks",
+			Aliases: []string{"w"},
+			Usage:   "display registered webhooks",
+			Flags: []cli.Flag{
+				jsonFlag,
+			},
+			Action: webhooksCMD(client),
+		},
 		{
 			Name:    "exit",
 			Aliases: []string{"e"},
@@ -445,3 +484,59 @@ func dealPeerCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
 		return nil
 	}
 }
+
+func addWebhookCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		response, err := client.AddWebhook(c.Context, &pb.AddWebhookRequest{
+			Url:       c.String("url"),
+			Secret:    c.String("secret"),
+			Addresses: c.StringSlice("address"),
+			PubKeys:   c.StringSlice("pub_key"),
+		})
+		if err != nil {
+			return err
+		}
+		if c.Bool("json") {
+			bb, err := protojson.Marshal(response)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bb))
+			return nil
+		}
+		fmt.Println(proto.MarshalTextString(response))
+		return nil
+	}
+}
+
+func removeWebhookCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		_, err := client.RemoveWebhook(c.Context, &pb.RemoveWebhookRequest{
+			Id: c.Uint64("id"),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println("OK")
+		return nil
+	}
+}
+
+func webhooksCMD(client pb.ManagerServiceClient) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		response, err := client.Webhooks(c.Context, &empty.Empty{})
+		if err != nil {
+			return err
+		}
+		if c.Bool("json") {
+			bb, err := protojson.Marshal(response)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bb))
+			return nil
+		}
+		fmt.Println(proto.MarshalTextString(response))
+		return nil
+	}
+}
@@ -2,15 +2,18 @@ package service
 
 import (
 	"context"
+	"crypto/tls"
 	pb "github.com/noah-blockchain/noah-go-node/cli/cli_pb"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"net"
 	"os"
 )
 
-func StartCLIServer(socketPath string, manager pb.ManagerServiceServer, ctx context.Context) error {
+// StartCLIServer serves manager on the unix socket, connections use TLS if tlsConfig is not nil
+func StartCLIServer(socketPath string, tlsConfig *tls.Config, manager pb.ManagerServiceServer, ctx context.Context) error {
 	if err := os.RemoveAll(socketPath); err != nil {
 		return err
 	}
@@ -20,14 +23,19 @@ func StartCLIServer(socketPath string, manager pb.ManagerServiceServer, ctx cont
 		return err
 	}
 
-	server := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc_middleware.WithStreamServerChain(
 			grpc_recovery.StreamServerInterceptor(),
 		),
 		grpc_middleware.WithUnaryServerChain(
 			grpc_recovery.UnaryServerInterceptor(),
 		),
-	)
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(opts...)
 
 	pb.RegisterManagerServiceServer(server, manager)
 
@@ -23,13 +23,13 @@ func TestStartCLIServer(t *testing.T) {
 	socketPath, _ := filepath.Abs(filepath.Join(".", "file.sock"))
 	_ = ioutil.WriteFile(socketPath, []byte("address already in use"), 0644)
 	go func() {
-		err := StartCLIServer(socketPath, NewManager(blockchain, tmRPC, tmNode, cfg), ctx)
+		err := StartCLIServer(socketPath, nil, NewManager(blockchain, tmRPC, tmNode, cfg), ctx)
 		if err != nil {
 			t.Log(err)
 		}
 	}()
 	time.Sleep(time.Millisecond)
-	console, err := NewCLI(socketPath)
+	console, err := NewCLI(socketPath, nil)
 	if err != nil {
 		t.Log(err)
 	}
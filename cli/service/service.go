@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	pb "github.com/noah-blockchain/noah-go-node/cli/cli_pb"
 	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/appdb"
 	"github.com/noah-blockchain/noah-go-node/core/noah"
 	"github.com/noah-blockchain/mintnoaher-go-node/core/types"
 	"github.com/noah-blockchain/noah-go-node/version"
@@ -18,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -281,6 +284,74 @@ func (m *Manager) DealPeer(_ context.Context, req *pb.DealPeerRequest) (*empty.E
 	return res, nil
 }
 
+func (m *Manager) AddWebhook(_ context.Context, req *pb.AddWebhookRequest) (*pb.Webhook, error) {
+	addresses := make([]types.Address, 0, len(req.Addresses))
+	for _, address := range req.Addresses {
+		if !strings.HasPrefix(address, "NOAHx") || len(address) != len("NOAHx")+2*types.AddressLength {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid address %s", address)
+		}
+		decoded, err := hex.DecodeString(address[len("NOAHx"):])
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid address %s", address)
+		}
+		addresses = append(addresses, types.BytesToAddress(decoded))
+	}
+
+	pubKeys := make([]types.Pubkey, 0, len(req.PubKeys))
+	for _, pubKey := range req.PubKeys {
+		if !strings.HasPrefix(pubKey, "Mp") || len(pubKey) != len("Mp")+2*32 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public key %s", pubKey)
+		}
+		decoded, err := hex.DecodeString(pubKey[len("Mp"):])
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid public key %s", pubKey)
+		}
+		pubKeys = append(pubKeys, types.BytesToPubkey(decoded))
+	}
+
+	webhook, err := m.blockchain.Webhooks().Add(req.Url, req.Secret, addresses, pubKeys)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return webhookResponse(webhook, 0), nil
+}
+
+func (m *Manager) RemoveWebhook(_ context.Context, req *pb.RemoveWebhookRequest) (*empty.Empty, error) {
+	if !m.blockchain.Webhooks().Remove(req.Id) {
+		return nil, status.Errorf(codes.NotFound, "webhook %d not found", req.Id)
+	}
+
+	return new(empty.Empty), nil
+}
+
+func (m *Manager) Webhooks(context.Context, *empty.Empty) (*pb.WebhooksResponse, error) {
+	pending := m.blockchain.Webhooks().PendingDeliveries()
+
+	response := &pb.WebhooksResponse{}
+	for _, webhook := range m.blockchain.Webhooks().Webhooks() {
+		response.Webhooks = append(response.Webhooks, webhookResponse(webhook, pending[webhook.ID]))
+	}
+
+	return response, nil
+}
+
+func webhookResponse(webhook appdb.Webhook, pending int) *pb.Webhook {
+	response := &pb.Webhook{
+		Id:                webhook.ID,
+		Url:               webhook.URL,
+		PendingDeliveries: uint64(pending),
+	}
+	for _, address := range webhook.Addresses {
+		response.Addresses = append(response.Addresses, address.String())
+	}
+	for _, pubKey := range webhook.PubKeys {
+		response.PubKeys = append(response.PubKeys, pubKey.String())
+	}
+
+	return response
+}
+
 func maxPeerHeight(sw *p2p.Switch) int64 {
 	var max int64
 	for _, peer := range sw.Peers().List() {
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/noah-blockchain/noah-go-node/cmd/utils"
 	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/storage"
+	coreTypes "github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/types"
@@ -25,7 +27,8 @@ var (
 )
 
 const (
-	genesisPath = "genesis.json"
+	genesisPath   = "genesis.json"
+	stateFilePath = "state"
 
 	blockMaxBytes   int64 = 10000000
 	blockMaxGas     int64 = 100000
@@ -61,9 +64,24 @@ func export(cmd *cobra.Command, args []string) error {
 		log.Panicf("Cannot parse indent: %s", err)
 	}
 
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		log.Panicf("Cannot parse format: %s", err)
+	}
+
+	legacy, err := cmd.Flags().GetBool("legacy")
+	if err != nil {
+		log.Panicf("Cannot parse legacy: %s", err)
+	}
+
+	// streaming export reads the state of the current version only, so the layout of the state is never guessed
+	if format != "" && legacy {
+		return fmt.Errorf("streaming export requires state of the current version, use --legacy=false")
+	}
+
 	fmt.Println("Start exporting...")
 
-	ldb, err := db.NewGoLevelDB("state", utils.GetNoahHome()+"/data")
+	ldb, err := storage.NewDB("state", db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", 0)
 	if err != nil {
 		log.Panicf("Cannot load db: %s", err)
 	}
@@ -73,21 +91,31 @@ func export(cmd *cobra.Command, args []string) error {
 		log.Panicf("Cannot new state at given height: %s", err)
 	}
 
-	exportTimeStart, newState := time.Now(), currentState.Export11To12(height)
-	fmt.Printf("State has been exported. Took %s", time.Since(exportTimeStart))
-
-	if startHeight > 0 {
-		newState.StartHeight = startHeight
-	}
-
 	var jsonBytes []byte
-	if indent {
-		jsonBytes, err = amino.NewCodec().MarshalJSONIndent(newState, "", "	")
+	if format != "" {
+		jsonBytes = exportStateFile(currentState, height, startHeight, state.StreamFormat(format))
 	} else {
-		jsonBytes, err = amino.NewCodec().MarshalJSON(newState)
-	}
-	if err != nil {
-		log.Panicf("Cannot marshal state to json: %s", err)
+		exportTimeStart := time.Now()
+		var newState coreTypes.AppState
+		if legacy {
+			newState = currentState.Export11To12(height)
+		} else {
+			newState = currentState.Export(height)
+		}
+		fmt.Printf("State has been exported. Took %s", time.Since(exportTimeStart))
+
+		if startHeight > 0 {
+			newState.StartHeight = startHeight
+		}
+
+		if indent {
+			jsonBytes, err = amino.NewCodec().MarshalJSONIndent(newState, "", "	")
+		} else {
+			jsonBytes, err = amino.NewCodec().MarshalJSON(newState)
+		}
+		if err != nil {
+			log.Panicf("Cannot marshal state to json: %s", err)
+		}
 	}
 
 	appHash := [32]byte{}
@@ -131,6 +159,44 @@ func export(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// exportStateFile writes the state to a separate file in given streaming format and returns app state
+// of genesis which refers to the file. The state is not kept in memory, so it is suitable for large networks.
+func exportStateFile(currentState *state.CheckState, height uint64, startHeight uint64, format state.StreamFormat) []byte {
+	fileName := stateFilePath + "." + string(format)
+	if format == state.StreamBinary {
+		fileName = stateFilePath + ".bin"
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		log.Panicf("Cannot create state file: %s", err)
+	}
+
+	exportTimeStart := time.Now()
+	if err := currentState.ExportStream(height, f, format); err != nil {
+		log.Panicf("Cannot export state: %s", err)
+	}
+
+	if err := f.Close(); err != nil {
+		log.Panicf("Cannot write state file: %s", err)
+	}
+	fmt.Printf("State has been exported to %s. Took %s", fileName, time.Since(exportTimeStart))
+
+	// start height of the file is used if it is not set
+	jsonBytes, err := amino.NewCodec().MarshalJSON(coreTypes.AppState{
+		StartHeight: startHeight,
+		StateFile: &coreTypes.StateFile{
+			Name:   fileName,
+			Sha256: fmt.Sprintf("%x", getFileSha256Hash(fileName)),
+		},
+	})
+	if err != nil {
+		log.Panicf("Cannot marshal state to json: %s", err)
+	}
+
+	return jsonBytes
+}
+
 func getFileSha256Hash(file string) []byte {
 	f, err := os.Open(file)
 	if err != nil {
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/noah-blockchain/noah-go-node/core/genesis"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+var (
+	GenesisCommand = &cobra.Command{
+		Use:   "genesis",
+		Short: "Tools for upgrading genesis files",
+	}
+
+	GenesisDiffCommand = &cobra.Command{
+		Use:   "diff [old genesis] [new genesis]",
+		Short: "Show changes of balances, coins, stakes and validators between two genesis files",
+		Args:  cobra.ExactArgs(2),
+		RunE:  genesisDiff,
+	}
+
+	GenesisMigrateCommand = &cobra.Command{
+		Use:   "migrate [genesis]",
+		Short: "Apply registered migrations to the state of genesis file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  genesisMigrate,
+	}
+)
+
+func genesisDiff(cmd *cobra.Command, args []string) error {
+	_, oldState, err := readGenesisState(args[0])
+	if err != nil {
+		return err
+	}
+
+	_, newState, err := readGenesisState(args[1])
+	if err != nil {
+		return err
+	}
+
+	changes := genesis.Diff(oldState, newState)
+
+	asJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		return json.NewEncoder(cmd.OutOrStdout()).Encode(changes)
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		fmt.Fprintln(cmd.OutOrStdout(), change.String())
+		counts[change.Kind]++
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d changes: %d balances, %d coins, %d stakes, %d validators\n", len(changes),
+		counts[genesis.KindBalance], counts[genesis.KindCoin], counts[genesis.KindStake], counts[genesis.KindValidator])
+
+	return nil
+}
+
+func genesisMigrate(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+
+	if from == "" || to == "" {
+		if len(genesis.Migrations()) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No migrations are registered, state of 1.1 is converted by noah export")
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "Registered migrations:")
+		}
+		for _, migration := range genesis.Migrations() {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s -> %s: %s\n", migration.From, migration.To, migration.Description)
+		}
+		return fmt.Errorf("both --from and --to versions are required")
+	}
+
+	doc, appState, err := readGenesisState(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := genesis.Migrate(appState, from, to); err != nil {
+		return err
+	}
+
+	if err := appState.Verify(); err != nil {
+		return fmt.Errorf("migrated state is not valid: %s", err)
+	}
+
+	doc.AppState, err = amino.NewCodec().MarshalJSON(appState)
+	if err != nil {
+		return err
+	}
+
+	if err := doc.SaveAs(output); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Genesis migrated from %s to %s and saved to %s\n", from, to, output)
+
+	return nil
+}
+
+func readGenesisState(file string) (*tmTypes.GenesisDoc, *types.AppState, error) {
+	doc, err := tmTypes.GenesisDocFromFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appState := new(types.AppState)
+	if err := amino.UnmarshalJSON(doc.AppState, appState); err != nil {
+		return nil, nil, fmt.Errorf("cannot decode app state of %s: %s", file, err)
+	}
+
+	if appState.StateFile != nil {
+		return nil, nil, fmt.Errorf("state of %s is in separate file %s, export it without --format", file, appState.StateFile.Name)
+	}
+
+	return doc, appState, nil
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"github.com/noah-blockchain/noah-go-node/cli/service"
 	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/config"
 	"github.com/spf13/cobra"
 	"strings"
 )
@@ -13,7 +15,7 @@ var ManagerCommand = &cobra.Command{
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		newArgs := setParentFlags(cmd, args)
-		console, err := service.NewCLI(utils.GetNoahHome() + "/manager.sock")
+		console, err := newManagerCLI()
 		if err != nil {
 			return nil
 		}
@@ -27,7 +29,7 @@ var ManagerConsole = &cobra.Command{
 	DisableFlagParsing: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		_ = setParentFlags(cmd, args)
-		console, err := service.NewCLI(utils.GetNoahHome() + "/manager.sock")
+		console, err := newManagerCLI()
 		if err != nil {
 			return nil
 		}
@@ -36,6 +38,22 @@ var ManagerConsole = &cobra.Command{
 	},
 }
 
+func newManagerCLI() (*service.ManagerConsole, error) {
+	serverTLS, err := config.ServerTLSConfig(cfg.ManagerTLSCertFile, cfg.ManagerTLSKeyFile, cfg.ManagerTLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsConfig *tls.Config
+	if serverTLS != nil {
+		if tlsConfig, err = config.SelfClientTLSConfig(serverTLS, cfg.ManagerConsoleTLSCertFile, cfg.ManagerConsoleTLSKeyFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return service.NewCLI(utils.GetNoahHome()+"/manager.sock", tlsConfig)
+}
+
 func setParentFlags(cmd *cobra.Command, args []string) (newArgs []string) {
 	for _, arg := range args {
 		split := strings.Split(arg, "=")
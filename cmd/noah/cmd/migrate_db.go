@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/storage"
+	"github.com/spf13/cobra"
+	db "github.com/tendermint/tm-db"
+)
+
+var MigrateDBCommand = &cobra.Command{
+	Use:   "migrate-db",
+	Short: "Copy node databases from current db_backend to another backend",
+	RunE:  migrateDB,
+}
+
+const migrateBatchSize = 10000
+
+// appDBNames are databases of the application stored in $(home-dir)/data
+var appDBNames = []string{"state", "events", "app", "coin_history"}
+
+// tmDBNames are databases of Tendermint stored in db_dir
+var tmDBNames = []string{"blockstore", "state", "tx_index", "evidence"}
+
+func migrateDB(cmd *cobra.Command, args []string) error {
+	to, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return err
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+
+	from := db.BackendType(cfg.DBBackend)
+	target := db.BackendType(to)
+
+	for _, backend := range []db.BackendType{from, target} {
+		if !storage.IsSupported(backend) {
+			return fmt.Errorf("unsupported db backend %q, expected one of %v", backend, storage.Backends)
+		}
+		if backend == db.MemDBBackend {
+			return fmt.Errorf("%s is not persistent and cannot be migrated", backend)
+		}
+	}
+
+	if from == target {
+		return fmt.Errorf("databases already use %s backend", from)
+	}
+
+	if output == "" {
+		output = filepath.Join(utils.GetNoahHome(), "migrated-"+to)
+	}
+
+	tmDBDir := config.GetTmConfig(cfg).DBDir()
+	dirs := []struct {
+		src, dst string
+		names    []string
+	}{
+		{src: filepath.Join(utils.GetNoahHome(), "data"), dst: filepath.Join(output, "data"), names: appDBNames},
+		{src: tmDBDir, dst: filepath.Join(output, filepath.Base(tmDBDir)), names: tmDBNames},
+	}
+
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir.dst, 0755); err != nil {
+			return err
+		}
+
+		for _, name := range dir.names {
+			if _, err := os.Stat(filepath.Join(dir.src, name+".db")); os.IsNotExist(err) {
+				continue
+			}
+
+			if err := migrateDBFile(name, from, dir.src, target, dir.dst); err != nil {
+				return fmt.Errorf("cannot migrate %s: %s", filepath.Join(dir.src, name), err)
+			}
+		}
+	}
+
+	fmt.Printf("Databases have been copied to %s.\n", output)
+	fmt.Printf("Stop the node, replace data directories with copied ones and set db_backend = \"%s\" in config.\n", to)
+
+	return nil
+}
+
+func migrateDBFile(name string, from db.BackendType, srcDir string, to db.BackendType, dstDir string) error {
+	src, err := storage.NewDB(name, from, srcDir, 0)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := storage.NewDB(name, to, dstDir, 0)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	count, err := storage.Copy(dst, src, migrateBatchSize)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Copied %d records of %s\n", count, filepath.Join(srcDir, name))
+
+	return nil
+}
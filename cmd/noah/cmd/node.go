@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"fmt"
 	apiV1 "github.com/noah-blockchain/noah-go-node/api"
+	"github.com/noah-blockchain/noah-go-node/api/auth"
+	"github.com/noah-blockchain/noah-go-node/api/graphql"
 	apiV2 "github.com/noah-blockchain/noah-go-node/api/v2"
 	serviceApi "github.com/noah-blockchain/noah-go-node/api/v2/service"
 	"github.com/noah-blockchain/noah-go-node/cli/service"
 	"github.com/noah-blockchain/noah-go-node/cmd/utils"
 	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/doublesign"
 	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
 	"github.com/noah-blockchain/noah-go-node/core/noah"
 	"github.com/noah-blockchain/noah-go-node/core/statistics"
@@ -74,21 +78,49 @@ func runNode(cmd *cobra.Command) error {
 
 	tmConfig := config.GetTmConfig(cfg)
 
+	apiAuth, err := auth.NewAuth(cfg)
+	if err != nil {
+		return err
+	}
+
+	tlsConfigs, err := loadListenersTLS()
+	if err != nil {
+		return err
+	}
+
 	app := noah.NewNoahBlockchain(cfg)
+	app.SetLogger(logger.With("module", "noah"))
+
+	// deliver payloads of webhooks registered through manager console
+	app.Webhooks().SetLogger(logger.With("module", "webhooks"))
+	app.Webhooks().Start()
 
 	// update BlocksTimeDelta in case it was corrupted
 	updateBlocksTimeDelta(app, tmConfig)
 
+	filePV := privval.LoadOrGenFilePV(tmConfig.PrivValidatorKeyFile(), tmConfig.PrivValidatorStateFile())
+
+	var privValidator tmTypes.PrivValidator = filePV
+	if cfg.ValidatorMode && cfg.DoubleSignGuard {
+		guard, err := newSignGuard(filePV, tmConfig)
+		if err != nil {
+			return err
+		}
+
+		app.SetSignGuard(guard)
+		privValidator = guard
+	}
+
 	// start TM node
-	node := startTendermintNode(app, tmConfig, logger)
+	node := startTendermintNode(app, privValidator, tmConfig, logger)
 	client := rpc.New(node)
 	app.SetTmNode(node)
 
 	if !cfg.ValidatorMode {
-		runAPI(logger, app, client, node)
+		runAPI(logger, app, client, node, apiAuth, tlsConfigs)
 	}
 
-	runCLI(cmd, app, client, node)
+	runCLI(cmd, app, client, node, tlsConfigs.manager)
 
 	if cfg.Instrumentation.Prometheus {
 		go app.SetStatisticData(statistics.New()).Statistic(cmd.Context())
@@ -104,9 +136,9 @@ func runNode(cmd *cobra.Command) error {
 	return nil
 }
 
-func runCLI(cmd *cobra.Command, app *noah.Blockchain, client *rpc.Local, tmNode *tmNode.Node) {
+func runCLI(cmd *cobra.Command, app *noah.Blockchain, client *rpc.Local, tmNode *tmNode.Node, tlsConfig *tls.Config) {
 	go func() {
-		err := service.StartCLIServer(utils.GetNoahHome()+"/manager.sock", service.NewManager(app, client, tmNode, cfg), cmd.Context())
+		err := service.StartCLIServer(utils.GetNoahHome()+"/manager.sock", tlsConfig, service.NewManager(app, client, tmNode, cfg), cmd.Context())
 		if err != nil {
 			panic(err)
 		}
@@ -139,11 +171,59 @@ func registerEvidenceMessages(cdc *amino.Codec) {
 	cdc.RegisterConcrete(&tmTypes.DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence", nil)
 }
 
-func runAPI(logger tmLog.Logger, app *noah.Blockchain, client *rpc.Local, node *tmNode.Node) {
+// listenersTLS holds TLS configs of listeners of the node, nil configs mean plaintext listeners
+type listenersTLS struct {
+	api     *tls.Config
+	grpc    *tls.Config
+	apiV2   *tls.Config
+	manager *tls.Config
+
+	// gateway is client config of API v2 gateway connecting to gRPC
+	gateway *tls.Config
+}
+
+func loadListenersTLS() (*listenersTLS, error) {
+	var (
+		l   listenersTLS
+		err error
+	)
+
+	if l.api, err = config.ServerTLSConfig(cfg.APITLSCertFile, cfg.APITLSKeyFile, cfg.APITLSClientCAFile); err != nil {
+		return nil, fmt.Errorf("API TLS: %s", err)
+	}
+	if l.grpc, err = config.ServerTLSConfig(cfg.GRPCTLSCertFile, cfg.GRPCTLSKeyFile, cfg.GRPCTLSClientCAFile); err != nil {
+		return nil, fmt.Errorf("gRPC TLS: %s", err)
+	}
+	if l.apiV2, err = config.ServerTLSConfig(cfg.APIv2TLSCertFile, cfg.APIv2TLSKeyFile, cfg.APIv2TLSClientCAFile); err != nil {
+		return nil, fmt.Errorf("API v2 TLS: %s", err)
+	}
+	if l.manager, err = config.ServerTLSConfig(cfg.ManagerTLSCertFile, cfg.ManagerTLSKeyFile, cfg.ManagerTLSClientCAFile); err != nil {
+		return nil, fmt.Errorf("manager TLS: %s", err)
+	}
+	if l.grpc != nil {
+		if l.gateway, err = config.SelfClientTLSConfig(l.grpc, cfg.GRPCGatewayTLSCertFile, cfg.GRPCGatewayTLSKeyFile); err != nil {
+			return nil, fmt.Errorf("gRPC gateway TLS: %s", err)
+		}
+	}
+
+	return &l, nil
+}
+
+func runAPI(logger tmLog.Logger, app *noah.Blockchain, client *rpc.Local, node *tmNode.Node, apiAuth *auth.Auth, tlsConfigs *listenersTLS) {
 	cdc := amino.NewCodec()
 	registerCryptoAmino(cdc)
 	eventsdb.RegisterAminoEvents(cdc)
 	registerEvidenceMessages(cdc)
+
+	var graphQL http.Handler
+	if cfg.GraphQL {
+		handler, err := graphql.NewHandler(app, cfg.GraphQLMaxDepth, int64(cfg.GraphQLMaxComplexity))
+		if err != nil {
+			panic(err)
+		}
+		graphQL = handler
+	}
+
 	go func(srv *serviceApi.Service) {
 		grpcURL, err := url.Parse(cfg.GRPCListenAddress)
 		if err != nil {
@@ -154,10 +234,10 @@ func runAPI(logger tmLog.Logger, app *noah.Blockchain, client *rpc.Local, node *
 			logger.Error("Failed to parse API v2 address", err)
 		}
 		logger.Error("Failed to start Api V2 in both gRPC and RESTful",
-			apiV2.Run(srv, grpcURL.Host, apiV2url.Host, logger.With("module", "rpc")))
+			apiV2.Run(srv, grpcURL.Host, apiV2url.Host, tlsConfigs.grpc, tlsConfigs.gateway, tlsConfigs.apiV2, apiAuth, graphQL, logger.With("module", "rpc")))
 	}(serviceApi.NewService(cdc, app, client, node, cfg, version.Version))
 
-	go apiV1.RunAPI(cdc, app, client, cfg, logger)
+	go apiV1.RunAPI(cdc, app, client, cfg, apiAuth, tlsConfigs.api, logger)
 }
 
 func enablePprof(cmd *cobra.Command, logger tmLog.Logger) error {
@@ -229,7 +309,28 @@ func updateBlocksTimeDelta(app *noah.Blockchain, config *tmCfg.Config) {
 	blockStoreDB.Close()
 }
 
-func startTendermintNode(app types.Application, cfg *tmCfg.Config, logger tmLog.Logger) *tmNode.Node {
+// newSignGuard creates double sign guard for the validator and checks blocks stored on disk
+// for signatures made with the validator key by other nodes
+func newSignGuard(pv *privval.FilePV, config *tmCfg.Config) (*doublesign.Guard, error) {
+	guard, err := doublesign.NewGuard(pv, cfg.SignWatermarkFile(), pv.LastSignState.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	blockStoreDB, err := tmNode.DefaultDBProvider(&tmNode.DBContext{ID: "blockstore", Config: config})
+	if err != nil {
+		return nil, err
+	}
+	defer blockStoreDB.Close()
+
+	if err := guard.CheckBlockStore(store.NewBlockStore(blockStoreDB)); err != nil {
+		return nil, err
+	}
+
+	return guard, nil
+}
+
+func startTendermintNode(app types.Application, privValidator tmTypes.PrivValidator, cfg *tmCfg.Config, logger tmLog.Logger) *tmNode.Node {
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
 	if err != nil {
 		panic(err)
@@ -237,7 +338,7 @@ func startTendermintNode(app types.Application, cfg *tmCfg.Config, logger tmLog.
 
 	node, err := tmNode.NewNode(
 		cfg,
-		privval.LoadOrGenFilePV(cfg.PrivValidatorKeyFile(), cfg.PrivValidatorStateFile()),
+		privValidator,
 		nodeKey,
 		proxy.NewLocalClientCreator(app),
 		getGenesis,
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/core/indexer"
+	"github.com/noah-blockchain/noah-go-node/log"
+	"github.com/spf13/cobra"
+)
+
+var ReindexCommand = &cobra.Command{
+	Use:   "reindex",
+	Short: "Write stored blocks to SQL indexer again",
+	Long: `Reindex replays stored blocks from --from to --to height over the state of the previous height and writes them to SQL indexer,
+data of the blocks is replaced with the same data the node writes. The state of the previous height should be kept by the node.
+Databases of the node except the indexer are only read, the node should be stopped.`,
+	RunE: reindex,
+}
+
+func reindex(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetUint64("from")
+	if err != nil {
+		return err
+	}
+
+	to, err := cmd.Flags().GetUint64("to")
+	if err != nil {
+		return err
+	}
+
+	if from < 2 {
+		return errors.New("the first height to re-index should be set by --from, blocks are replayed over the state of the previous height")
+	}
+
+	sqlIndexer, err := indexer.NewIndexer(utils.GetNoahHome() + "/data/indexer.db")
+	if err != nil {
+		return err
+	}
+	defer sqlIndexer.Close()
+
+	replayer, to, closeDBs, err := newReplayer(from-1, to)
+	if err != nil {
+		return err
+	}
+	defer closeDBs()
+
+	replayer.SetIndexer(sqlIndexer, log.NewLogger(cfg).With("module", "indexer"))
+
+	out := cmd.OutOrStdout()
+	for replayer.Height() < to {
+		block, err := replayer.Replay()
+		if err != nil {
+			return err
+		}
+
+		// blocks are indexed from results of replay, so they should be the same as the network has
+		if !block.AppHashMatches() {
+			return fmt.Errorf("app hash mismatch at height %d", block.Height)
+		}
+
+		if failedHeight := sqlIndexer.FailedHeight(); failedHeight != 0 {
+			return fmt.Errorf("cannot index block %d", failedHeight)
+		}
+
+		fmt.Fprintf(out, "Block %d is re-indexed, %d txs\n", block.Height, len(block.Txs))
+	}
+
+	return nil
+}
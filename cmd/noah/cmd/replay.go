@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/appdb"
+	"github.com/noah-blockchain/noah-go-node/core/noah"
+	"github.com/noah-blockchain/noah-go-node/core/storage"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	"github.com/spf13/cobra"
+	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/store"
+	db "github.com/tendermint/tm-db"
+)
+
+var ReplayCommand = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay stored blocks over the state of given height and print changes of the state made by every transaction",
+	Long: `Replay loads the state committed at --from height and delivers stored blocks up to --to height, new states are kept in memory.
+App hash of every block is compared with the hash in the header of the next block, replay stops at the first mismatch.
+Databases of the node are only read, the node should be stopped.`,
+	RunE: replay,
+}
+
+func replay(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetUint64("from")
+	if err != nil {
+		return err
+	}
+
+	to, err := cmd.Flags().GetUint64("to")
+	if err != nil {
+		return err
+	}
+
+	if from == 0 {
+		return errors.New("height of the state should be set by --from")
+	}
+
+	replayer, to, closeDBs, err := newReplayer(from, to)
+	if err != nil {
+		return err
+	}
+	defer closeDBs()
+
+	out := cmd.OutOrStdout()
+	for replayer.Height() < to {
+		block, err := replayer.Replay()
+		if err != nil {
+			return err
+		}
+
+		printReplayedBlock(out, block)
+
+		if !block.AppHashMatches() {
+			return fmt.Errorf("app hash mismatch at height %d", block.Height)
+		}
+	}
+
+	return nil
+}
+
+// newReplayer opens databases of the node and creates replayer of stored blocks following the state of given height.
+// The last height to replay is limited by stored blocks, the last stored block is used if it is 0.
+func newReplayer(from, to uint64) (replayer *noah.Replayer, lastHeight uint64, closeDBs func(), err error) {
+	var closers []func() error
+	closeDBs = func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			_ = closers[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			closeDBs()
+		}
+	}()
+
+	ldb, err := storage.NewDB("state", db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", 0)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	closers = append(closers, ldb.Close)
+
+	applicationDB := appdb.NewAppDB(cfg)
+	closers = append(closers, func() error { applicationDB.Close(); return nil })
+
+	tmConfig := config.GetTmConfig(cfg)
+	blockStoreDB, err := tmNode.DefaultDBProvider(&tmNode.DBContext{ID: "blockstore", Config: tmConfig})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	closers = append(closers, blockStoreDB.Close)
+
+	tmStateDB, err := tmNode.DefaultDBProvider(&tmNode.DBContext{ID: "state", Config: tmConfig})
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	closers = append(closers, tmStateDB.Close)
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	if to == 0 || to > uint64(blockStore.Height()) {
+		to = uint64(blockStore.Height())
+	}
+
+	replayer, err = noah.NewReplayer(cfg, ldb, from, applicationDB.GetStartHeight(), blockStore, tmStateDB)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("cannot load state at height %d: %s", from, err)
+	}
+
+	return replayer, to, closeDBs, nil
+}
+
+func printReplayedBlock(out io.Writer, block *noah.ReplayedBlock) {
+	switch {
+	case block.ExpectedAppHash == nil:
+		fmt.Fprintf(out, "Block %d: app hash %X, next block is not stored\n", block.Height, block.AppHash)
+	case block.AppHashMatches():
+		fmt.Fprintf(out, "Block %d: app hash %X matches\n", block.Height, block.AppHash)
+	default:
+		fmt.Fprintf(out, "Block %d: app hash %X does not match %X\n", block.Height, block.AppHash, block.ExpectedAppHash)
+	}
+
+	printStateChanges(out, "begin block", block.BeginBlock)
+	for _, tx := range block.Txs {
+		printStateChanges(out, fmt.Sprintf("tx Mt%x, code %d %s", tx.Hash, tx.Code, tx.Log), tx.Changes)
+	}
+	printStateChanges(out, "end block", block.EndBlock)
+}
+
+func printStateChanges(out io.Writer, title string, changes []tree.Change) {
+	fmt.Fprintf(out, "  %s\n", title)
+	for _, change := range changes {
+		switch {
+		case change.Old == nil:
+			fmt.Fprintf(out, "    + %X: %X\n", change.Key, change.New)
+		case change.New == nil:
+			fmt.Fprintf(out, "    - %X: %X\n", change.Key, change.Old)
+		default:
+			fmt.Fprintf(out, "    ~ %X: %X -> %X\n", change.Key, change.Old, change.New)
+		}
+	}
+}
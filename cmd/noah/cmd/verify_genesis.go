@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/core/state"
 	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/go-amino"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 var VerifyGenesis = &cobra.Command{
@@ -24,6 +30,10 @@ func verifyGenesis(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if genesisState.StateFile != nil {
+		return verifyStateFile(genesisState.StateFile)
+	}
+
 	if err := genesisState.Verify(); err != nil {
 		return err
 	}
@@ -32,3 +42,29 @@ func verifyGenesis(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func verifyStateFile(stateFile *types.StateFile) error {
+	name := stateFile.Name
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(utils.GetNoahHome(), "config", name)
+	}
+
+	if hash := fmt.Sprintf("%x", getFileSha256Hash(name)); hash != strings.ToLower(stateFile.Sha256) {
+		return fmt.Errorf("wrong hash of state file %s. Expected %s, got %s", name, stateFile.Sha256, hash)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// the file is read item by item, the same way as it is imported
+	if _, err := state.VerifyStream(bufio.NewReader(f)); err != nil {
+		return fmt.Errorf("state file %s is not valid: %s", name, err)
+	}
+
+	fmt.Printf("Genesis is ok, state file %s is ok\n", name)
+
+	return nil
+}
@@ -27,6 +27,10 @@ func main() {
 		cmd.VerifyGenesis,
 		cmd.Version,
 		cmd.ExportCommand,
+		cmd.MigrateDBCommand,
+		cmd.GenesisCommand,
+		cmd.ReplayCommand,
+		cmd.ReindexCommand,
 	)
 
 	rootCmd.PersistentFlags().StringVar(&utils.NoaHome, "home-dir", "", "base dir (default is $HOME/.noah)")
@@ -39,9 +43,26 @@ func main() {
 	cmd.ExportCommand.Flags().Uint64("height", 0, "export height")
 	cmd.ExportCommand.Flags().Uint64("start-height", 0, "height for starting a new chain")
 	cmd.ExportCommand.Flags().Bool("indent", false, "using indent")
+	cmd.ExportCommand.Flags().String("format", "", "write state to a separate file in streaming format: json or binary")
+	cmd.ExportCommand.Flags().Bool("legacy", true, "database keeps state of version 1.1, use \"false\" for state of the current version")
 	cmd.ExportCommand.Flags().String("chain-id", "", "export chain id")
 	cmd.ExportCommand.Flags().Duration("genesis-time", 0, "export height")
 
+	cmd.MigrateDBCommand.Flags().String("to", "", "target db backend: goleveldb | boltdb")
+	cmd.MigrateDBCommand.Flags().String("output", "", "directory for copied databases (default is $(home-dir)/migrated-$(to))")
+
+	cmd.GenesisCommand.AddCommand(cmd.GenesisDiffCommand, cmd.GenesisMigrateCommand)
+	cmd.GenesisDiffCommand.Flags().Bool("json", false, "print changes as JSON")
+	cmd.GenesisMigrateCommand.Flags().String("from", "", "version of the state in genesis file")
+	cmd.GenesisMigrateCommand.Flags().String("to", "", "target version of the state")
+	cmd.GenesisMigrateCommand.Flags().String("output", "migrated_genesis.json", "path of migrated genesis file")
+
+	cmd.ReplayCommand.Flags().Uint64("from", 0, "height of the state to replay blocks from")
+	cmd.ReplayCommand.Flags().Uint64("to", 0, "the last height to replay (default is the last stored block)")
+
+	cmd.ReindexCommand.Flags().Uint64("from", 0, "the first height to re-index")
+	cmd.ReindexCommand.Flags().Uint64("to", 0, "the last height to re-index (default is the last stored block)")
+
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		panic(err)
 	}
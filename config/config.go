@@ -21,19 +21,21 @@ const (
 	defaultConfigFileName  = "config.toml"
 	defaultGenesisJSONName = "genesis.json"
 
-	defaultPrivValName      = "priv_validator.json"
-	defaultPrivValStateName = "priv_validator_state.json"
-	defaultNodeKeyName      = "node_key.json"
+	defaultPrivValName       = "priv_validator.json"
+	defaultPrivValStateName  = "priv_validator_state.json"
+	defaultNodeKeyName       = "node_key.json"
+	defaultSignWatermarkName = "sign_watermark.json"
 )
 
 var (
-	ChainId                 string
-	NetworkId               string
-	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
-	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
-	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValName)
-	defaultPrivValStatePath = filepath.Join(defaultConfigDir, defaultPrivValStateName)
-	defaultNodeKeyPath      = filepath.Join(defaultConfigDir, defaultNodeKeyName)
+	ChainId                  string
+	NetworkId                string
+	defaultConfigFilePath    = filepath.Join(defaultConfigDir, defaultConfigFileName)
+	defaultGenesisJSONPath   = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
+	defaultPrivValKeyPath    = filepath.Join(defaultConfigDir, defaultPrivValName)
+	defaultPrivValStatePath  = filepath.Join(defaultConfigDir, defaultPrivValStateName)
+	defaultNodeKeyPath       = filepath.Join(defaultConfigDir, defaultNodeKeyName)
+	defaultSignWatermarkPath = filepath.Join(defaultDataDir, defaultSignWatermarkName)
 )
 
 func DefaultConfig() *Config {
@@ -78,7 +80,7 @@ func DefaultConfig() *Config {
 	cfg.DBPath = "tmdata"
 
 	cfg.Mempool.CacheSize = 100000
-	cfg.Mempool.Recheck = false
+	cfg.Mempool.Recheck = true // recheck only evicts expired transactions, see Blockchain.CheckTx
 	cfg.Mempool.Size = 10000
 
 	cfg.Consensus.WalPath = "tmdata/cs.wal/wal"
@@ -113,8 +115,6 @@ func GetConfig() *Config {
 		cfg.RPC.GRPCListenAddress = ""
 	}
 
-	cfg.Mempool.Recheck = false
-
 	cfg.P2P.AddrBook = "config/addrbook.json"
 
 	cfg.SetRoot(utils.GetNoahHome())
@@ -247,7 +247,7 @@ type BaseConfig struct {
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
 
-	// Database backend: leveldb | memdb
+	// Database backend: goleveldb | memdb | boltdb (requires binary built with "boltdb" tag)
 	DBBackend string `mapstructure:"db_backend"`
 
 	// Database directory
@@ -265,12 +265,66 @@ type BaseConfig struct {
 	// API v2 Timeout
 	APIv2TimeoutDuration time.Duration `mapstructure:"api_v2_timeout_duration"`
 
+	// Serve GraphQL queries over chain state at /v2/graphql of API v2
+	GraphQL bool `mapstructure:"graphql"`
+
+	// Max depth of selections and max number of resolved objects in GraphQL query, 0 disables the limit
+	GraphQLMaxDepth      int `mapstructure:"graphql_max_depth"`
+	GraphQLMaxComplexity int `mapstructure:"graphql_max_complexity"`
+
+	// TLS certificates and keys of listeners, listeners accept plaintext connections if certificate is not set.
+	// If client CA is set, clients are required to present certificates signed by it (mutual TLS).
+	APITLSCertFile     string `mapstructure:"api_tls_cert_file"`
+	APITLSKeyFile      string `mapstructure:"api_tls_key_file"`
+	APITLSClientCAFile string `mapstructure:"api_tls_client_ca_file"`
+
+	GRPCTLSCertFile     string `mapstructure:"grpc_tls_cert_file"`
+	GRPCTLSKeyFile      string `mapstructure:"grpc_tls_key_file"`
+	GRPCTLSClientCAFile string `mapstructure:"grpc_tls_client_ca_file"`
+
+	APIv2TLSCertFile     string `mapstructure:"api_v2_tls_cert_file"`
+	APIv2TLSKeyFile      string `mapstructure:"api_v2_tls_key_file"`
+	APIv2TLSClientCAFile string `mapstructure:"api_v2_tls_client_ca_file"`
+
+	ManagerTLSCertFile     string `mapstructure:"manager_tls_cert_file"`
+	ManagerTLSKeyFile      string `mapstructure:"manager_tls_key_file"`
+	ManagerTLSClientCAFile string `mapstructure:"manager_tls_client_ca_file"`
+
+	// Client certificates and keys of API v2 gateway connecting to gRPC and of manager console connecting to manager socket,
+	// they are required if client CA of the listener is set and should be signed by it.
+	GRPCGatewayTLSCertFile string `mapstructure:"grpc_gateway_tls_cert_file"`
+	GRPCGatewayTLSKeyFile  string `mapstructure:"grpc_gateway_tls_key_file"`
+
+	ManagerConsoleTLSCertFile string `mapstructure:"manager_console_tls_cert_file"`
+	ManagerConsoleTLSKeyFile  string `mapstructure:"manager_console_tls_key_file"`
+
 	ValidatorMode bool `mapstructure:"validator_mode"`
 
+	// If true, validator refuses to sign when its key may be used by another node
+	DoubleSignGuard bool `mapstructure:"double_sign_guard"`
+
+	// Path to the JSON file containing the latest height signed by the validator on this node
+	SignWatermark string `mapstructure:"sign_watermark_file"`
+
 	KeepLastStates int64 `mapstructure:"keep_last_states"`
 
 	APISimultaneousRequests int `mapstructure:"api_simultaneous_requests"`
 
+	// Max number of requests in JSON-RPC batch of API v1, 0 disables the limit
+	APIMaxBatchSize int `mapstructure:"api_max_batch_size"`
+
+	// Index transactions, events and balance changes of committed blocks into SQLite database for API queries
+	SQLIndexer bool `mapstructure:"sql_indexer"`
+
+	// Record volume, reserve and price of coins changed by committed blocks for coin_history of API,
+	// records older than retention are pruned, 0 keeps all records
+	CoinHistory          bool          `mapstructure:"coin_history"`
+	CoinHistoryRetention time.Duration `mapstructure:"coin_history_retention"`
+
+	// Attempts and timeout of delivering a payload to a webhook registered through manager console
+	WebhookMaxAttempts int           `mapstructure:"webhook_max_attempts"`
+	WebhookTimeout     time.Duration `mapstructure:"webhook_timeout"`
+
 	LogPath string `mapstructure:"log_path"`
 
 	StateCacheSize int `mapstructure:"state_cache_size"`
@@ -278,6 +332,40 @@ type BaseConfig struct {
 	StateMemAvailable int `mapstructure:"state_mem_available"`
 
 	HaltHeight int `mapstructure:"halt_height"`
+
+	// Minimal gas price of transactions accepted to mempool by transaction type,
+	// used when it is higher than current mempool-wide minimal gas price
+	MinGasPriceByType map[string]uint32 `mapstructure:"min_gas_price_by_type"`
+
+	// Minimal gas price surcharge for every started PayloadSurchargeBytes of payload and service data, 0 - disabled
+	PayloadGasPriceSurcharge uint32 `mapstructure:"payload_gas_price_surcharge"`
+
+	PayloadSurchargeBytes int `mapstructure:"payload_surcharge_bytes"`
+
+	// Maximum number of transactions accepted to mempool from one sender during SenderRateLimitBlocks blocks, 0 - unlimited
+	SenderRateLimit int `mapstructure:"sender_rate_limit"`
+
+	SenderRateLimitBlocks int `mapstructure:"sender_rate_limit_blocks"`
+
+	// Secret to verify JWT bearer tokens signed with HS256, subject of the token is the name of the API key
+	APIJWTSecret string `mapstructure:"api_jwt_secret"`
+
+	// Keys of clients of API v1 and v2, requests without key or token are rejected if any key is set
+	APIKeys []APIKeyConfig `mapstructure:"api_keys"`
+}
+
+// APIKeyConfig is the client of API with its quota and allowed methods
+type APIKeyConfig struct {
+	Name string `mapstructure:"name"`
+
+	// Secret key sent as bearer token, may be empty if the client uses JWT only
+	Key string `mapstructure:"key"`
+
+	// Maximum number of requests per minute, 0 - unlimited
+	RequestsPerMinute int `mapstructure:"requests_per_minute"`
+
+	// Names of methods allowed to the client, e.g. "address" or "coin_info", all methods are allowed if empty
+	Methods []string `mapstructure:"methods"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
@@ -298,13 +386,30 @@ func DefaultBaseConfig() BaseConfig {
 		GRPCListenAddress:       "tcp://0.0.0.0:8842",
 		APIv2ListenAddress:      "tcp://0.0.0.0:8843",
 		APIv2TimeoutDuration:    10 * time.Second,
+		GraphQL:                 false,
+		GraphQLMaxDepth:         10,
+		GraphQLMaxComplexity:    1000,
 		ValidatorMode:           false,
+		DoubleSignGuard:         true,
+		SignWatermark:           defaultSignWatermarkPath,
 		KeepLastStates:          120,
 		StateCacheSize:          1000000,
 		StateMemAvailable:       1024,
 		APISimultaneousRequests: 100,
+		APIMaxBatchSize:         100,
+		SQLIndexer:              false,
+		CoinHistory:             false,
+		CoinHistoryRetention:    90 * 24 * time.Hour,
+		WebhookMaxAttempts:      10,
+		WebhookTimeout:          10 * time.Second,
 		LogPath:                 "stdout",
 		LogFormat:               LogFormatPlain,
+
+		MinGasPriceByType:        map[string]uint32{},
+		PayloadGasPriceSurcharge: 0,
+		PayloadSurchargeBytes:    256,
+		SenderRateLimit:          0,
+		SenderRateLimitBlocks:    60,
 	}
 }
 
@@ -333,6 +438,11 @@ func (cfg BaseConfig) PrivValidatorKeyFile() string {
 	return rootify(cfg.PrivValidatorKey, cfg.RootDir)
 }
 
+// SignWatermarkFile returns the full path to the sign_watermark.json file
+func (cfg BaseConfig) SignWatermarkFile() string {
+	return rootify(cfg.SignWatermark, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
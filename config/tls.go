@@ -0,0 +1,81 @@
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// ServerTLSConfig returns TLS config of a listener with given certificate and key, nil if certificate is not set.
+// If clientCAFile is set, clients are required to present certificates signed by CA from the file.
+func ServerTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("client CA is set for listener without TLS certificate")
+		}
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return tlsConfig, nil
+}
+
+// SelfClientTLSConfig returns TLS config of a client connecting to the listener of this node with given server config.
+// The client accepts only the certificate of the listener and presents certificate from certFile and keyFile,
+// which is required if the listener requires client certificates.
+func SelfClientTLSConfig(serverTLS *tls.Config, certFile, keyFile string) (*tls.Config, error) {
+	var certificates []tls.Certificate
+	if certFile != "" || keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	} else if serverTLS.ClientAuth == tls.RequireAndVerifyClientCert {
+		return nil, errors.New("client certificate is required by the listener")
+	}
+
+	serverCertificate := serverTLS.Certificates[0].Certificate[0]
+
+	return &tls.Config{
+		Certificates: certificates,
+		MinVersion:   tls.VersionTLS12,
+		// the address of the listener may not match names of the certificate, so the certificate is compared instead
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], serverCertificate) {
+				return errors.New("certificate of the server does not match certificate of the listener")
+			}
+			return nil
+		},
+	}, nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestCertificate(t *testing.T, dir, name string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func handshake(serverTLS, clientTLS *tls.Config) error {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverTLS)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		errs <- conn.(*tls.Conn).Handshake()
+	}()
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", listener.Addr().String(), clientTLS)
+	if err == nil {
+		// client certificate is verified by the server after the client completes TLS 1.3 handshake
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		if err == io.EOF {
+			err = nil
+		}
+		conn.Close()
+	}
+
+	if serverErr := <-errs; serverErr != nil {
+		return serverErr
+	}
+
+	return err
+}
+
+func selfClient(t *testing.T, serverTLS *tls.Config, certFile, keyFile string) *tls.Config {
+	clientTLS, err := SelfClientTLSConfig(serverTLS, certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clientTLS
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCertificate(t, dir, "server")
+
+	tlsConfig, err := ServerTLSConfig("", "", "")
+	if err != nil || tlsConfig != nil {
+		t.Fatalf("Expected plaintext listener, got %v, %v", tlsConfig, err)
+	}
+
+	if _, err := ServerTLSConfig("", "", certFile); err == nil {
+		t.Fatal("Expected error for client CA without certificate")
+	}
+
+	tlsConfig, err = ServerTLSConfig(certFile, keyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.ClientAuth != tls.NoClientCert {
+		t.Fatal("Client certificates should not be required without client CA")
+	}
+
+	if err := handshake(tlsConfig, selfClient(t, tlsConfig, "", "")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServerTLSConfig_Mutual(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCertificate(t, dir, "server")
+	caFile, caKeyFile := writeTestCertificate(t, dir, "ca")
+	otherCertFile, otherKeyFile := writeTestCertificate(t, dir, "other")
+
+	serverTLS, err := ServerTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SelfClientTLSConfig(serverTLS, "", ""); err == nil {
+		t.Fatal("Expected error for client without certificate")
+	}
+
+	// the node connects to its own listener with the client certificate signed by the client CA
+	if err := handshake(serverTLS, selfClient(t, serverTLS, caFile, caKeyFile)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := handshake(serverTLS, selfClient(t, serverTLS, certFile, keyFile)); err == nil {
+		t.Fatal("Certificate of the listener should not be trusted as client certificate")
+	}
+
+	anonymous := &tls.Config{InsecureSkipVerify: true}
+	if err := handshake(serverTLS, anonymous); err == nil {
+		t.Fatal("Client without certificate should be rejected")
+	}
+
+	otherTLS, err := ServerTLSConfig(otherCertFile, otherKeyFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := handshake(serverTLS, &tls.Config{Certificates: otherTLS.Certificates, InsecureSkipVerify: true}); err == nil {
+		t.Fatal("Client with certificate of unknown CA should be rejected")
+	}
+
+	// the client accepts only the certificate of the listener
+	if err := handshake(otherTLS, selfClient(t, serverTLS, caFile, caKeyFile)); err == nil {
+		t.Fatal("Server with other certificate should be rejected")
+	}
+}
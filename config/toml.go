@@ -78,9 +78,45 @@ api_v2_listen_addr = "{{ .BaseConfig.APIv2ListenAddress }}"
 # API v2 Timeout
 api_v2_timeout_duration = "{{ .BaseConfig.APIv2TimeoutDuration }}"
 
+# Serve GraphQL queries over chain state at /v2/graphql of API v2
+graphql = {{ .BaseConfig.GraphQL }}
+
+# Max depth of selections and max number of resolved objects in GraphQL query, 0 disables the limit
+graphql_max_depth = {{ .BaseConfig.GraphQLMaxDepth }}
+graphql_max_complexity = {{ .BaseConfig.GraphQLMaxComplexity }}
+
+# TLS certificates and keys of API, gRPC and manager socket listeners. Plaintext connections are accepted if certificate is not set.
+# If client CA is set, clients are required to present certificates signed by it (mutual TLS).
+api_tls_cert_file = "{{ js .BaseConfig.APITLSCertFile }}"
+api_tls_key_file = "{{ js .BaseConfig.APITLSKeyFile }}"
+api_tls_client_ca_file = "{{ js .BaseConfig.APITLSClientCAFile }}"
+
+grpc_tls_cert_file = "{{ js .BaseConfig.GRPCTLSCertFile }}"
+grpc_tls_key_file = "{{ js .BaseConfig.GRPCTLSKeyFile }}"
+grpc_tls_client_ca_file = "{{ js .BaseConfig.GRPCTLSClientCAFile }}"
+
+api_v2_tls_cert_file = "{{ js .BaseConfig.APIv2TLSCertFile }}"
+api_v2_tls_key_file = "{{ js .BaseConfig.APIv2TLSKeyFile }}"
+api_v2_tls_client_ca_file = "{{ js .BaseConfig.APIv2TLSClientCAFile }}"
+
+manager_tls_cert_file = "{{ js .BaseConfig.ManagerTLSCertFile }}"
+manager_tls_key_file = "{{ js .BaseConfig.ManagerTLSKeyFile }}"
+manager_tls_client_ca_file = "{{ js .BaseConfig.ManagerTLSClientCAFile }}"
+
+# Client certificates and keys of API v2 gateway connecting to gRPC and of manager console connecting to manager socket.
+# They are required if client CA of the listener is set, should be signed by it and allow client authentication.
+grpc_gateway_tls_cert_file = "{{ js .BaseConfig.GRPCGatewayTLSCertFile }}"
+grpc_gateway_tls_key_file = "{{ js .BaseConfig.GRPCGatewayTLSKeyFile }}"
+
+manager_console_tls_cert_file = "{{ js .BaseConfig.ManagerConsoleTLSCertFile }}"
+manager_console_tls_key_file = "{{ js .BaseConfig.ManagerConsoleTLSKeyFile }}"
+
 # Sets node to be in validator mode. Disables API, events, history of blocks, indexes, etc. 
 validator_mode = {{ .BaseConfig.ValidatorMode }}
 
+# In validator mode refuses to sign blocks if the validator key is found to be used by another node.
+double_sign_guard = {{ .BaseConfig.DoubleSignGuard }}
+
 # Sets number of last stated to be saved on disk.
 keep_last_states = {{ .BaseConfig.KeepLastStates }}
 
@@ -93,12 +129,32 @@ state_mem_available = {{ .BaseConfig.StateMemAvailable }}
 # Limit for simultaneous requests to API
 api_simultaneous_requests = {{ .BaseConfig.APISimultaneousRequests }}
 
+# Max number of requests in JSON-RPC batch of API v1, larger batches are rejected. 0 disables the limit
+api_max_batch_size = {{ .BaseConfig.APIMaxBatchSize }}
+
+# Index transactions, events and balance changes of committed blocks into SQLite database at data/indexer.db.
+# Indexed data is served by indexed_transactions, indexed_events, balance_changes and address_transactions of API.
+# Requires the node built with cgo, i.e. by "make build CGO_ENABLED=1", default builds can't open the database.
+# Blocks committed before the indexer is enabled or failed to be written are indexed by "noah reindex --from --to".
+sql_indexer = {{ .BaseConfig.SQLIndexer }}
+
+# Record volume, reserve and price of coins changed by blocks into data/coin_history.db for coin_history of API.
+# Records older than retention are pruned, "0s" keeps all records. Blocks committed while it is disabled are not recorded.
+coin_history = {{ .BaseConfig.CoinHistory }}
+coin_history_retention = "{{ .BaseConfig.CoinHistoryRetention }}"
+
+# Webhooks are registered through manager console, payloads are kept in outbox of app database until delivered.
+# Webhooks are delivered concurrently, payloads of a webhook are sent in order and a failed one pauses its webhook.
+# A payload is dropped after the max number of failed attempts, retries are delayed exponentially up to an hour.
+webhook_max_attempts = {{ .BaseConfig.WebhookMaxAttempts }}
+webhook_timeout = "{{ .BaseConfig.WebhookTimeout }}"
+
 # If this node is many blocks behind the tip of the chain, FastSync
 # allows them to catchup quickly by downloading blocks in parallel
 # and verifying their commits
 fast_sync = {{ .BaseConfig.FastSync }}
 
-# Database backend: leveldb | memdb
+# Database backend: goleveldb | memdb | boltdb (requires binary built with "boltdb" tag)
 db_backend = "{{ .BaseConfig.DBBackend }}"
 
 # Database directory
@@ -119,12 +175,47 @@ log_path = "{{ .BaseConfig.LogPath }}"
 priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Path to the JSON file containing the latest height signed by the validator on this node
+sign_watermark_file = "{{ js .BaseConfig.SignWatermark }}"
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey}}"
 
 # TCP or UNIX socket address for the profiling server to listen on
 prof_laddr = "{{ .BaseConfig.ProfListenAddress }}"
 
+# Surcharge to minimal gas price for every started payload_surcharge_bytes of tx payload and service data, 0 - disabled
+payload_gas_price_surcharge = {{ .BaseConfig.PayloadGasPriceSurcharge }}
+payload_surcharge_bytes = {{ .BaseConfig.PayloadSurchargeBytes }}
+
+# Maximum number of transactions accepted to mempool from one sender during sender_rate_limit_blocks blocks, 0 - unlimited
+sender_rate_limit = {{ .BaseConfig.SenderRateLimit }}
+sender_rate_limit_blocks = {{ .BaseConfig.SenderRateLimitBlocks }}
+
+# Secret to verify JWT bearer tokens signed with HS256, subject of the token is the name of the API key
+api_jwt_secret = "{{ .BaseConfig.APIJWTSecret }}"
+
+# Minimal gas price of transactions accepted to mempool by transaction type.
+# Applied when it is higher than current minimal gas price of mempool. Example:
+# multisend = 5
+# price_vote = 5
+[min_gas_price_by_type]
+{{ range $type, $price := .BaseConfig.MinGasPriceByType }}{{ $type }} = {{ $price }}
+{{ end }}
+# Keys of clients of API v1 and v2. If any key is set, requests should have header "Authorization: Bearer <key or JWT>".
+# requests_per_minute = 0 - unlimited, all methods are allowed if methods are empty. Example:
+# [[api_keys]]
+# name = "partner"
+# key = "secret"
+# requests_per_minute = 600
+# methods = ["status", "address", "send_transaction"]
+{{ range .BaseConfig.APIKeys }}
+[[api_keys]]
+name = "{{ .Name }}"
+key = "{{ .Key }}"
+requests_per_minute = {{ .RequestsPerMinute }}
+methods = [{{ range $i, $method := .Methods }}{{ if $i }}, {{ end }}"{{ $method }}"{{ end }}]
+{{ end }}
 ##### advanced configuration options #####
 
 ##### rpc server configuration options #####
@@ -220,6 +311,9 @@ size = {{ .Mempool.Size }}
 # size of the cache (used to filter transactions we saw earlier)
 cache_size = {{ .Mempool.CacheSize }}
 
+# recheck transactions left in the mempool after every block, recheck evicts expired transactions
+recheck = {{ .Mempool.Recheck }}
+
 ##### instrumentation configuration options #####
 [instrumentation]
 
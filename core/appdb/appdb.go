@@ -5,9 +5,13 @@ import (
 	"errors"
 	"github.com/noah-blockchain/noah-go-node/cmd/utils"
 	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/storage"
+	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/tendermint/go-amino"
-	"github.com/tendermint/tendermint/abci/types"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tm-db"
+	"math"
+	"time"
 )
 
 var (
@@ -20,6 +24,9 @@ const (
 	startHeightPath    = "startHeight"
 	blockTimeDeltaPath = "blockDelta"
 	validatorsPath     = "validators"
+	evidencePath       = "evidence"
+	webhooksPath       = "webhooks"
+	webhookOutboxPath  = "webhookOutbox/"
 
 	dbName = "app"
 )
@@ -105,17 +112,17 @@ func (appDB *AppDB) GetStartHeight() uint64 {
 }
 
 // GetValidators returns list of latest validators stored on dist
-func (appDB *AppDB) GetValidators() types.ValidatorUpdates {
+func (appDB *AppDB) GetValidators() abciTypes.ValidatorUpdates {
 	result, err := appDB.db.Get([]byte(validatorsPath))
 	if err != nil {
 		panic(err)
 	}
 
 	if len(result) == 0 {
-		return types.ValidatorUpdates{}
+		return abciTypes.ValidatorUpdates{}
 	}
 
-	var vals types.ValidatorUpdates
+	var vals abciTypes.ValidatorUpdates
 
 	err = cdc.UnmarshalBinaryBare(result, &vals)
 	if err != nil {
@@ -126,7 +133,7 @@ func (appDB *AppDB) GetValidators() types.ValidatorUpdates {
 }
 
 // SaveValidators stores given validators list on disk, panics on error
-func (appDB *AppDB) SaveValidators(vals types.ValidatorUpdates) {
+func (appDB *AppDB) SaveValidators(vals abciTypes.ValidatorUpdates) {
 	data, err := cdc.MarshalBinaryBare(vals)
 	if err != nil {
 		panic(err)
@@ -181,9 +188,244 @@ func (appDB *AppDB) SetLastBlocksTimeDelta(height uint64, delta int) {
 	}
 }
 
+// Evidence is a record about misbehaviour of validator observed by the node
+type Evidence struct {
+	Type   string
+	Height uint64
+	Time   time.Time
+}
+
+// GetEvidence returns list of evidence stored for validator with given address ordered by heights
+func (appDB *AppDB) GetEvidence(address types.TmAddress) []Evidence {
+	start := evidenceKey(address, 0, "")
+	end := evidenceKey(address, math.MaxUint64, "\xff")
+
+	iterator, err := appDB.db.Iterator(start, end)
+	if err != nil {
+		panic(err)
+	}
+	defer iterator.Close()
+
+	var list []Evidence
+	for ; iterator.Valid(); iterator.Next() {
+		var evidence Evidence
+		if err := cdc.UnmarshalBinaryBare(iterator.Value(), &evidence); err != nil {
+			panic(err)
+		}
+		list = append(list, evidence)
+	}
+
+	return list
+}
+
+// AddEvidence stores evidence for validator with given address, panics on error.
+// Every evidence is a separate record keyed by its height and type, so evidence of the same type and height is stored only once.
+func (appDB *AppDB) AddEvidence(address types.TmAddress, evidence Evidence) {
+	data, err := cdc.MarshalBinaryBare(evidence)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := appDB.db.Set(evidenceKey(address, evidence.Height, evidence.Type), data); err != nil {
+		panic(err)
+	}
+}
+
+func evidenceKey(address types.TmAddress, height uint64, evidenceType string) []byte {
+	key := make([]byte, 0, len(evidencePath)+len(address)+8+len(evidenceType))
+	key = append(key, evidencePath...)
+	key = append(key, address[:]...)
+	key = append(key, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(key[len(key)-8:], height)
+
+	return append(key, evidenceType...)
+}
+
+// Webhook is an URL registered to receive notifications about activity of watched addresses and candidates
+type Webhook struct {
+	ID        uint64
+	URL       string
+	Secret    string
+	Addresses []types.Address
+	PubKeys   []types.Pubkey
+}
+
+type webhooksList struct {
+	LastID uint64
+	List   []Webhook
+}
+
+// GetWebhooks returns list of registered webhooks
+func (appDB *AppDB) GetWebhooks() []Webhook {
+	return appDB.getWebhooksList().List
+}
+
+// AddWebhook stores the webhook with a new ID and returns it, panics on error
+func (appDB *AppDB) AddWebhook(webhook Webhook) Webhook {
+	list := appDB.getWebhooksList()
+	list.LastID++
+	webhook.ID = list.LastID
+	list.List = append(list.List, webhook)
+	appDB.saveWebhooksList(list)
+
+	return webhook
+}
+
+// RemoveWebhook deletes the webhook with given ID and its pending deliveries, returns false if there is no such webhook
+func (appDB *AppDB) RemoveWebhook(id uint64) bool {
+	list := appDB.getWebhooksList()
+	for i, webhook := range list.List {
+		if webhook.ID != id {
+			continue
+		}
+
+		list.List = append(list.List[:i], list.List[i+1:]...)
+		appDB.saveWebhooksList(list)
+
+		var keys [][]byte
+		appDB.iterateWebhookDeliveries(id, func(key []byte, value []byte) bool {
+			keys = append(keys, append([]byte(nil), key...))
+			return false
+		})
+		for _, key := range keys {
+			if err := appDB.db.Delete(key); err != nil {
+				panic(err)
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (appDB *AppDB) getWebhooksList() webhooksList {
+	var list webhooksList
+
+	result, err := appDB.db.Get([]byte(webhooksPath))
+	if err != nil {
+		panic(err)
+	}
+
+	if len(result) == 0 {
+		return list
+	}
+
+	if err := cdc.UnmarshalBinaryBare(result, &list); err != nil {
+		panic(err)
+	}
+
+	return list
+}
+
+func (appDB *AppDB) saveWebhooksList(list webhooksList) {
+	data, err := cdc.MarshalBinaryBare(list)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := appDB.db.Set([]byte(webhooksPath), data); err != nil {
+		panic(err)
+	}
+}
+
+// WebhookDelivery is a payload in the outbox waiting to be sent to the webhook
+type WebhookDelivery struct {
+	ID          string
+	WebhookID   uint64
+	Payload     []byte
+	Attempts    uint32
+	NextAttempt time.Time
+}
+
+// SetWebhookDelivery stores the delivery in the outbox of its webhook, delivery with the same ID is replaced, panics on error
+func (appDB *AppDB) SetWebhookDelivery(delivery WebhookDelivery) {
+	data, err := cdc.MarshalBinaryBare(delivery)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := appDB.db.Set(webhookDeliveryKey(delivery.WebhookID, delivery.ID), data); err != nil {
+		panic(err)
+	}
+}
+
+// DeleteWebhookDelivery removes the delivery from the outbox of the webhook, panics on error
+func (appDB *AppDB) DeleteWebhookDelivery(webhookID uint64, id string) {
+	if err := appDB.db.Delete(webhookDeliveryKey(webhookID, id)); err != nil {
+		panic(err)
+	}
+}
+
+// GetWebhookDeliveries returns up to limit first deliveries of the webhook ordered by their IDs
+func (appDB *AppDB) GetWebhookDeliveries(webhookID uint64, limit int) []WebhookDelivery {
+	var list []WebhookDelivery
+	appDB.iterateWebhookDeliveries(webhookID, func(key []byte, value []byte) bool {
+		var delivery WebhookDelivery
+		if err := cdc.UnmarshalBinaryBare(value, &delivery); err != nil {
+			panic(err)
+		}
+		list = append(list, delivery)
+
+		return len(list) >= limit
+	})
+
+	return list
+}
+
+// CountWebhookDeliveries returns number of deliveries in the outbox of the webhook
+func (appDB *AppDB) CountWebhookDeliveries(webhookID uint64) int {
+	count := 0
+	appDB.iterateWebhookDeliveries(webhookID, func(key []byte, value []byte) bool {
+		count++
+		return false
+	})
+
+	return count
+}
+
+// iterateWebhookDeliveries iterates over the outbox of the webhook until fn returns true
+func (appDB *AppDB) iterateWebhookDeliveries(webhookID uint64, fn func(key []byte, value []byte) bool) {
+	start := webhookDeliveryKey(webhookID, "")
+	end := webhookDeliveryKey(webhookID+1, "")
+
+	iterator, err := appDB.db.Iterator(start, end)
+	if err != nil {
+		panic(err)
+	}
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		if fn(iterator.Key(), iterator.Value()) {
+			return
+		}
+	}
+}
+
+// webhookDeliveryKey groups deliveries by webhooks, so the outbox of a webhook is read without others
+func webhookDeliveryKey(webhookID uint64, id string) []byte {
+	key := make([]byte, len(webhookOutboxPath)+8, len(webhookOutboxPath)+8+len(id))
+	copy(key, webhookOutboxPath)
+	binary.BigEndian.PutUint64(key[len(webhookOutboxPath):], webhookID)
+
+	return append(key, id...)
+}
+
 // NewAppDB creates AppDB instance with given config
 func NewAppDB(cfg *config.Config) *AppDB {
+	appDB, err := storage.NewDB(dbName, db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", 0)
+	if err != nil {
+		panic(err)
+	}
+
+	return &AppDB{
+		db: appDB,
+	}
+}
+
+// NewMemAppDB creates AppDB instance which keeps data in memory
+func NewMemAppDB() *AppDB {
 	return &AppDB{
-		db: db.NewDB(dbName, db.BackendType(cfg.DBBackend), utils.GetMinterHome()+"/data"),
+		db: db.NewMemDB(),
 	}
 }
@@ -0,0 +1,34 @@
+package appdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+func TestAppDB_Evidence(t *testing.T) {
+	appDB := NewMemAppDB()
+	address, other := types.TmAddress{1}, types.TmAddress{1, 1}
+
+	appDB.AddEvidence(address, Evidence{Type: "duplicate/vote", Height: 300, Time: time.Unix(3, 0)})
+	appDB.AddEvidence(address, Evidence{Type: "duplicate/vote", Height: 20, Time: time.Unix(2, 0)})
+	appDB.AddEvidence(address, Evidence{Type: "foreign/signature", Height: 20, Time: time.Unix(2, 0)})
+	appDB.AddEvidence(other, Evidence{Type: "duplicate/vote", Height: 1, Time: time.Unix(1, 0)})
+
+	// evidence of the same type and height is stored once
+	appDB.AddEvidence(address, Evidence{Type: "duplicate/vote", Height: 20, Time: time.Unix(2, 0)})
+
+	list := appDB.GetEvidence(address)
+	if len(list) != 3 || list[0].Height != 20 || list[1].Height != 20 || list[2].Height != 300 {
+		t.Fatalf("Wrong evidence: %v", list)
+	}
+
+	if list := appDB.GetEvidence(other); len(list) != 1 || list[0].Height != 1 {
+		t.Fatalf("Wrong evidence of other validator: %v", list)
+	}
+
+	if list := appDB.GetEvidence(types.TmAddress{2}); len(list) != 0 {
+		t.Fatalf("Unexpected evidence: %v", list)
+	}
+}
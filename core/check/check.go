@@ -17,6 +17,9 @@ var (
 	ErrInvalidSig = errors.New("invalid transaction v, r, s values")
 )
 
+// ModePartial allows to redeem the check in several transactions until its value is exhausted
+const ModePartial uint64 = 1
+
 // Check is like an ordinary bank check.
 // Each user of network can issue check with any amount of coins and pass it to another person.
 // Receiver will be able to cash a check from arbitrary account.
@@ -28,6 +31,7 @@ var (
 // DueBlock - defines last block height in which the check can be used.
 // Lock - secret to prevent hijacking.
 // V, R, S - signature of issuer.
+// Mode - optional mode of redemption signed by issuer, empty for checks redeemed once in full.
 type Check struct {
 	Nonce    []byte
 	ChainID  types.ChainID
@@ -39,6 +43,12 @@ type Check struct {
 	V        *big.Int
 	R        *big.Int
 	S        *big.Int
+	Mode     []uint64 `rlp:"tail"`
+}
+
+// IsPartial returns true if the check can be redeemed partially
+func (check *Check) IsPartial() bool {
+	return len(check.Mode) == 1 && check.Mode[0] == ModePartial
 }
 
 // Sender returns sender's address of a Check, recovered from signature
@@ -69,19 +79,19 @@ func (check *Check) LockPubKey() ([]byte, error) {
 
 // HashWithoutLock returns a types.Hash to be used in process of signing and checking Lock
 func (check *Check) HashWithoutLock() types.Hash {
-	return rlpHash([]interface{}{
+	return rlpHash(check.withMode([]interface{}{
 		check.Nonce,
 		check.ChainID,
 		check.DueBlock,
 		check.Coin,
 		check.Value,
 		check.GasCoin,
-	})
+	}))
 }
 
 // Hash returns a types.Hash to be used in process of signing a Check by sender
 func (check *Check) Hash() types.Hash {
-	return rlpHash([]interface{}{
+	return rlpHash(check.withMode([]interface{}{
 		check.Nonce,
 		check.ChainID,
 		check.DueBlock,
@@ -89,7 +99,16 @@ func (check *Check) Hash() types.Hash {
 		check.Value,
 		check.GasCoin,
 		check.Lock,
-	})
+	}))
+}
+
+// withMode appends mode to signed fields, so hashes of checks without mode are not changed
+func (check *Check) withMode(fields []interface{}) []interface{} {
+	if len(check.Mode) == 0 {
+		return fields
+	}
+
+	return append(fields, check.Mode)
 }
 
 // Sign signs the check with given private key, returns error
@@ -130,6 +149,10 @@ func DecodeFromBytes(buf []byte) (*Check, error) {
 		return nil, errors.New("incorrect tx signature")
 	}
 
+	if len(check.Mode) != 0 && !check.IsPartial() {
+		return nil, errors.New("unknown check mode")
+	}
+
 	return &check, nil
 }
 
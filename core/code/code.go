@@ -24,6 +24,8 @@ const (
 	CoinReserveUnderflow         uint32 = 116
 	WrongHaltHeight              uint32 = 117
 	HaltAlreadyExists            uint32 = 118
+	TooManyTxsFromSender         uint32 = 119
+	TxExpired                    uint32 = 120
 
 	// coin creation
 	CoinAlreadyExists uint32 = 201
@@ -41,18 +43,19 @@ const (
 	MinimumValueToBuyReached  uint32 = 303
 
 	// candidate
-	CandidateExists       uint32 = 401
-	WrongCommission       uint32 = 402
-	CandidateNotFound     uint32 = 403
-	StakeNotFound         uint32 = 404
-	InsufficientStake     uint32 = 405
-	IsNotOwnerOfCandidate uint32 = 406
-	IncorrectPubKey       uint32 = 407
-	StakeShouldBePositive uint32 = 408
-	TooLowStake           uint32 = 409
-	PublicKeyInBlockList  uint32 = 410
-	NewPublicKeyIsBad     uint32 = 411
-	InsufficientWaitList  uint32 = 412
+	CandidateExists        uint32 = 401
+	WrongCommission        uint32 = 402
+	CandidateNotFound      uint32 = 403
+	StakeNotFound          uint32 = 404
+	InsufficientStake      uint32 = 405
+	IsNotOwnerOfCandidate  uint32 = 406
+	IncorrectPubKey        uint32 = 407
+	StakeShouldBePositive  uint32 = 408
+	TooLowStake            uint32 = 409
+	PublicKeyInBlockList   uint32 = 410
+	NewPublicKeyIsBad      uint32 = 411
+	InsufficientWaitList   uint32 = 412
+	CommissionUpdateExists uint32 = 413
 
 	// check
 	CheckInvalidLock uint32 = 501
@@ -61,6 +64,9 @@ const (
 	TooHighGasPrice  uint32 = 504
 	WrongGasCoin     uint32 = 505
 	TooLongNonce     uint32 = 506
+	IsNotCheckIssuer uint32 = 507
+	CheckNotPartial  uint32 = 508
+	WrongCheckValue  uint32 = 509
 
 	// multisig
 	IncorrectWeights                  uint32 = 601
@@ -213,6 +219,27 @@ func NewTooLowGasPrice(minGasPrice string, gotGasPrice string) *tooLowGasPrice {
 	return &tooLowGasPrice{Code: strconv.Itoa(int(TooLowGasPrice)), MinGasPrice: minGasPrice, GotGasPrice: gotGasPrice}
 }
 
+type tooManyTxsFromSender struct {
+	Code   string `json:"code,omitempty"`
+	Sender string `json:"sender,omitempty"`
+	Limit  string `json:"limit,omitempty"`
+	Blocks string `json:"blocks,omitempty"`
+}
+
+func NewTooManyTxsFromSender(sender string, limit string, blocks string) *tooManyTxsFromSender {
+	return &tooManyTxsFromSender{Code: strconv.Itoa(int(TooManyTxsFromSender)), Sender: sender, Limit: limit, Blocks: blocks}
+}
+
+type txExpired struct {
+	Code         string `json:"code,omitempty"`
+	ValidUntil   string `json:"valid_until,omitempty"`
+	CurrentBlock string `json:"current_block,omitempty"`
+}
+
+func NewTxExpired(validUntil string, currentBlock string) *txExpired {
+	return &txExpired{Code: strconv.Itoa(int(TxExpired)), ValidUntil: validUntil, CurrentBlock: currentBlock}
+}
+
 type wrongChainID struct {
 	Code           string `json:"code,omitempty"`
 	CurrentChainId string `json:"current_chain_id,omitempty"`
@@ -498,6 +525,34 @@ func NewCheckUsed() *checkUsed {
 	return &checkUsed{Code: strconv.Itoa(int(CheckUsed))}
 }
 
+type isNotCheckIssuer struct {
+	Code   string `json:"code,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	Sender string `json:"sender,omitempty"`
+}
+
+func NewIsNotCheckIssuer(issuer string, sender string) *isNotCheckIssuer {
+	return &isNotCheckIssuer{Code: strconv.Itoa(int(IsNotCheckIssuer)), Issuer: issuer, Sender: sender}
+}
+
+type checkNotPartial struct {
+	Code string `json:"code,omitempty"`
+}
+
+func NewCheckNotPartial() *checkNotPartial {
+	return &checkNotPartial{Code: strconv.Itoa(int(CheckNotPartial))}
+}
+
+type wrongCheckValue struct {
+	Code           string `json:"code,omitempty"`
+	Value          string `json:"value,omitempty"`
+	RemainingValue string `json:"remaining_value,omitempty"`
+}
+
+func NewWrongCheckValue(value string, remainingValue string) *wrongCheckValue {
+	return &wrongCheckValue{Code: strconv.Itoa(int(WrongCheckValue)), Value: value, RemainingValue: remainingValue}
+}
+
 type notEnoughMultisigVotes struct {
 	Code        string `json:"code,omitempty"`
 	NeededVotes string `json:"needed_votes,omitempty"`
@@ -570,6 +625,17 @@ func NewWrongCommission(got string, min string, max string) *wrongCommission {
 	return &wrongCommission{Code: strconv.Itoa(int(WrongCommission)), MaxCommission: max, MinCommission: min, GotCommission: got}
 }
 
+type commissionUpdateExists struct {
+	Code       string `json:"code,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Commission string `json:"commission,omitempty"`
+	Height     string `json:"height,omitempty"`
+}
+
+func NewCommissionUpdateExists(pubKey string, commission string, height string) *commissionUpdateExists {
+	return &commissionUpdateExists{Code: strconv.Itoa(int(CommissionUpdateExists)), PublicKey: pubKey, Commission: commission, Height: height}
+}
+
 type multisigNotExists struct {
 	Code    string `json:"code,omitempty"`
 	Address string `json:"address,omitempty"`
@@ -0,0 +1,361 @@
+package coinhistory
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/formula"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	db "github.com/tendermint/tm-db"
+)
+
+// MaxCandles is the maximum number of intervals in a query of candles
+const MaxCandles = 1000
+
+const (
+	coinPrefix = byte('c')
+	// timePrefix indexes records by time, so old records of all coins are pruned without scanning them
+	timePrefix = byte('t')
+	// lastPrefix keeps the last record of every coin, it is not pruned and opens candles of coins unchanged for a long time
+	lastPrefix = byte('l')
+)
+
+var (
+	ErrInvalidInterval = errors.New("interval should be positive")
+	ErrInvalidRange    = errors.New("from should be before to")
+	ErrTooManyCandles  = fmt.Errorf("range should contain at most %d intervals", MaxCandles)
+)
+
+// oneCoin is the amount of coin which price is recorded
+var oneCoin = big.NewInt(1e18)
+
+type record struct {
+	Height  uint64
+	Volume  *big.Int
+	Reserve *big.Int
+	Price   *big.Int
+}
+
+type timedRecord struct {
+	Time   uint64
+	Record record
+}
+
+// Candle is OHLC of price of a coin in base coin over an interval, volume and reserve are the values at close.
+// Prices are in the smallest units of base coin for one coin. The candle opens at the close of the previous one,
+// the candle of an interval without changes repeats the last close and has the height of the last change.
+type Candle struct {
+	Time       int64  `json:"time"`
+	Open       string `json:"open"`
+	High       string `json:"high"`
+	Low        string `json:"low"`
+	Close      string `json:"close"`
+	Volume     string `json:"volume"`
+	Reserve    string `json:"reserve"`
+	FromHeight uint64 `json:"from_height"`
+	ToHeight   uint64 `json:"to_height"`
+}
+
+// Store records volume, reserve and price of coins changed by committed blocks
+type Store struct {
+	db        db.DB
+	retention time.Duration
+
+	lock    sync.Mutex
+	height  uint64
+	time    time.Time
+	pending map[types.CoinID]record
+}
+
+// NewStore creates Store in given DB, records older than retention are pruned on commit, 0 keeps all records
+func NewStore(db db.DB, retention time.Duration) *Store {
+	return &Store{
+		db:        db,
+		retention: retention,
+		pending:   map[types.CoinID]record{},
+	}
+}
+
+// BeginBlock starts recording of the block, records of the previous block which is not committed are dropped
+func (s *Store) BeginBlock(height uint64, blockTime time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.height = height
+	s.time = blockTime
+	s.pending = map[types.CoinID]record{}
+}
+
+// AddCoin records volume and reserve of the coin and its price implied by them,
+// the coin written to the state several times in a block is recorded with the last values
+func (s *Store) AddCoin(id types.CoinID, volume *big.Int, reserve *big.Int, crr uint32) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.pending[id] = record{
+		Height:  s.height,
+		Volume:  volume,
+		Reserve: reserve,
+		Price:   price(volume, reserve, crr),
+	}
+}
+
+// Commit writes records of the block. Records are keyed by the time of the block,
+// so the block committed again after restart replaces its records.
+// Records of the block are dropped on failure, so they are not written with the time of the next block.
+func (s *Store) Commit() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	pending := s.pending
+	s.pending = map[types.CoinID]record{}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	for id, item := range pending {
+		data, err := rlp.EncodeToBytes(item)
+		if err != nil {
+			return err
+		}
+
+		batch.Set(recordKey(id, s.time), data)
+		batch.Set(timeKey(s.time, id), []byte{})
+
+		last, err := rlp.EncodeToBytes(timedRecord{Time: uint64(s.time.UnixNano()), Record: item})
+		if err != nil {
+			return err
+		}
+		batch.Set(lastKey(id), last)
+	}
+
+	if s.retention > 0 {
+		if err := s.prune(batch, s.time.Add(-s.retention)); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write()
+}
+
+// prune adds deletion of records older than the time to the batch
+func (s *Store) prune(batch db.Batch, before time.Time) error {
+	start := []byte{timePrefix}
+	iterator, err := s.db.Iterator(start, timeKey(before, 0))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		t := time.Unix(0, int64(binary.BigEndian.Uint64(key[1:9])))
+		id := types.CoinID(binary.BigEndian.Uint32(key[9:]))
+
+		batch.Delete(recordKey(id, t))
+		batch.Delete(append([]byte(nil), key...))
+	}
+
+	return iterator.Error()
+}
+
+// Candles returns candles of the coin over intervals aligned to unix time which start in [from, to).
+// Intervals before the first recorded change of the coin are skipped.
+func (s *Store) Candles(id types.CoinID, from, to time.Time, interval time.Duration) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	if !from.Before(to) {
+		return nil, ErrInvalidRange
+	}
+
+	if int64(to.Sub(from)/interval) >= MaxCandles {
+		return nil, ErrTooManyCandles
+	}
+
+	from = time.Unix(0, intervalStart(from.UnixNano(), interval))
+
+	// the last record before the range opens the first candle
+	last, err := s.previousRecord(id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := s.db.Iterator(recordKey(id, from), recordKey(id, to))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var candles []Candle
+	next := from.UnixNano()
+	// fill adds candles repeating the last close to intervals without changes which start before the time
+	fill := func(before int64) {
+		for ; next < before; next += int64(interval) {
+			if last != nil {
+				candles = append(candles, flatCandle(next, last))
+			}
+		}
+	}
+
+	var high, low *big.Int
+	for ; iterator.Valid(); iterator.Next() {
+		item := new(record)
+		if err := rlp.DecodeBytes(iterator.Value(), item); err != nil {
+			return nil, err
+		}
+
+		start := intervalStart(int64(binary.BigEndian.Uint64(iterator.Key()[5:])), interval)
+		if start >= next {
+			fill(start)
+
+			open := item.Price
+			if last != nil {
+				open = last.Price
+			}
+
+			candles = append(candles, Candle{
+				Time:       start / int64(time.Second),
+				Open:       open.String(),
+				FromHeight: item.Height,
+			})
+			high, low = open, open
+			next = start + int64(interval)
+		}
+
+		if item.Price.Cmp(high) > 0 {
+			high = item.Price
+		}
+		if item.Price.Cmp(low) < 0 {
+			low = item.Price
+		}
+
+		candle := &candles[len(candles)-1]
+		candle.High = high.String()
+		candle.Low = low.String()
+		candle.Close = item.Price.String()
+		candle.Volume = item.Volume.String()
+		candle.Reserve = item.Reserve.String()
+		candle.ToHeight = item.Height
+
+		last = item
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	fill(to.UnixNano())
+
+	return candles, nil
+}
+
+// previousRecord returns the last record of the coin before the time, nil if there is no such record
+func (s *Store) previousRecord(id types.CoinID, before time.Time) (*record, error) {
+	iterator, err := s.db.ReverseIterator(recordKey(id, time.Unix(0, 0)), recordKey(id, before))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	if iterator.Valid() {
+		item := new(record)
+		if err := rlp.DecodeBytes(iterator.Value(), item); err != nil {
+			return nil, err
+		}
+
+		return item, nil
+	}
+
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	// records before the time may be pruned while the last record of the coin is kept
+	data, err := s.db.Get(lastKey(id))
+	if err != nil || data == nil {
+		return nil, err
+	}
+
+	var last timedRecord
+	if err := rlp.DecodeBytes(data, &last); err != nil {
+		return nil, err
+	}
+
+	if last.Time >= uint64(before.UnixNano()) {
+		return nil, nil
+	}
+
+	return &last.Record, nil
+}
+
+func flatCandle(start int64, last *record) Candle {
+	return Candle{
+		Time:       start / int64(time.Second),
+		Open:       last.Price.String(),
+		High:       last.Price.String(),
+		Low:        last.Price.String(),
+		Close:      last.Price.String(),
+		Volume:     last.Volume.String(),
+		Reserve:    last.Reserve.String(),
+		FromHeight: last.Height,
+		ToHeight:   last.Height,
+	}
+}
+
+// price returns amount of base coin received by selling one coin, for volume less than one coin
+// the price is the average price of selling the whole volume
+func price(volume *big.Int, reserve *big.Int, crr uint32) *big.Int {
+	if volume.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	if volume.Cmp(oneCoin) >= 0 {
+		return formula.CalculateSaleReturn(volume, reserve, crr, oneCoin)
+	}
+
+	result := formula.CalculateSaleReturn(volume, reserve, crr, volume)
+	result.Mul(result, oneCoin)
+
+	return result.Div(result, volume)
+}
+
+func intervalStart(t int64, interval time.Duration) int64 {
+	return t - t%int64(interval)
+}
+
+func timeKey(t time.Time, id types.CoinID) []byte {
+	key := make([]byte, 13)
+	key[0] = timePrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint32(key[9:], id.Uint32())
+
+	return key
+}
+
+func lastKey(id types.CoinID) []byte {
+	key := make([]byte, 5)
+	key[0] = lastPrefix
+	binary.BigEndian.PutUint32(key[1:], id.Uint32())
+
+	return key
+}
+
+func recordKey(id types.CoinID, t time.Time) []byte {
+	key := make([]byte, 13)
+	key[0] = coinPrefix
+	binary.BigEndian.PutUint32(key[1:], id.Uint32())
+	binary.BigEndian.PutUint64(key[5:], uint64(t.UnixNano()))
+
+	return key
+}
@@ -0,0 +1,167 @@
+package coinhistory
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func commitBlock(t *testing.T, store *Store, height uint64, blockTime time.Time, reserves map[types.CoinID]int64) {
+	store.BeginBlock(height, blockTime)
+	for id, reserve := range reserves {
+		// price of coin with crr 100 is reserve divided by volume
+		store.AddCoin(id, big.NewInt(1e18), big.NewInt(reserve), 100)
+	}
+
+	if err := store.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_Candles(t *testing.T) {
+	store := NewStore(db.NewMemDB(), 0)
+
+	start := time.Unix(3600, 0)
+	commitBlock(t, store, 1, start.Add(-time.Second), map[types.CoinID]int64{1: 1})
+	commitBlock(t, store, 2, start, map[types.CoinID]int64{1: 10, 2: 100})
+	commitBlock(t, store, 3, start.Add(10*time.Second), map[types.CoinID]int64{1: 30})
+	commitBlock(t, store, 4, start.Add(20*time.Second), map[types.CoinID]int64{1: 5})
+	commitBlock(t, store, 5, start.Add(30*time.Second), map[types.CoinID]int64{1: 20})
+	commitBlock(t, store, 6, start.Add(time.Minute), map[types.CoinID]int64{1: 25})
+
+	// block is committed again after restart
+	commitBlock(t, store, 6, start.Add(time.Minute), map[types.CoinID]int64{1: 25})
+
+	candles, err := store.Candles(1, start.Add(time.Second), start.Add(2*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 {
+		t.Fatalf("expected 2 candles, got %v", candles)
+	}
+
+	// candles open at the close of the previous record
+	expected := Candle{Time: 3600, Open: "1", High: "30", Low: "1", Close: "20", Volume: "1000000000000000000", Reserve: "20", FromHeight: 2, ToHeight: 5}
+	if candles[0] != expected {
+		t.Fatalf("unexpected candle: %v", candles[0])
+	}
+	if candles[1].Time != 3660 || candles[1].Open != "20" || candles[1].Low != "20" || candles[1].Close != "25" || candles[1].FromHeight != 6 {
+		t.Fatalf("unexpected candle: %v", candles[1])
+	}
+
+	candles, err = store.Candles(2, start, start.Add(time.Hour), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 || candles[0].Open != "100" || candles[0].Close != "100" {
+		t.Fatalf("unexpected candles of other coin: %v", candles)
+	}
+
+	// intervals without changes repeat the last close
+	candles, err = store.Candles(2, start, start.Add(5*time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 5 {
+		t.Fatalf("expected 5 candles, got %v", candles)
+	}
+	for i, candle := range candles[1:] {
+		expected := Candle{Time: 3660 + int64(i)*60, Open: "100", High: "100", Low: "100", Close: "100", Volume: "1000000000000000000", Reserve: "100", FromHeight: 2, ToHeight: 2}
+		if candle != expected {
+			t.Fatalf("unexpected candle without changes: %v", candle)
+		}
+	}
+
+	candles, err = store.Candles(2, start.Add(time.Hour), start.Add(time.Hour+time.Minute), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 1 || candles[0].Open != "100" || candles[0].Close != "100" {
+		t.Fatalf("unexpected candles after the last change: %v", candles)
+	}
+
+	if _, err := store.Candles(1, start, start.Add(time.Hour), time.Second); err != ErrTooManyCandles {
+		t.Fatalf("expected error of range, got %v", err)
+	}
+	if _, err := store.Candles(1, start, start, time.Second); err != ErrInvalidRange {
+		t.Fatalf("expected error of range, got %v", err)
+	}
+	if _, err := store.Candles(1, start, start.Add(time.Hour), 0); err != ErrInvalidInterval {
+		t.Fatalf("expected error of interval, got %v", err)
+	}
+}
+
+func TestStore_Retention(t *testing.T) {
+	store := NewStore(db.NewMemDB(), time.Minute)
+
+	commitBlock(t, store, 1, time.Unix(0, 0), map[types.CoinID]int64{1: 1})
+	commitBlock(t, store, 2, time.Unix(10, 0), map[types.CoinID]int64{1: 2, 2: 3})
+	commitBlock(t, store, 3, time.Unix(120, 0), map[types.CoinID]int64{2: 4})
+
+	for _, key := range [][]byte{recordKey(1, time.Unix(0, 0)), recordKey(1, time.Unix(10, 0)), timeKey(time.Unix(10, 0), 2)} {
+		if value, err := store.db.Get(key); err != nil || value != nil {
+			t.Fatalf("record %X is not pruned", key)
+		}
+	}
+
+	candles, err := store.Candles(1, time.Unix(0, 0), time.Unix(60, 0), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 0 {
+		t.Fatalf("unexpected pruned candles: %v", candles)
+	}
+
+	// the last record of the coin is kept after pruning
+	candles, err = store.Candles(1, time.Unix(60, 0), time.Unix(180, 0), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 2 || candles[0].Close != "2" || candles[1].Close != "2" || candles[1].ToHeight != 2 {
+		t.Fatalf("unexpected candles of coin without changes: %v", candles)
+	}
+
+	candles, err = store.Candles(2, time.Unix(60, 0), time.Unix(180, 0), time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pruned records do not open candles
+	if len(candles) != 1 || candles[0].Time != 120 || candles[0].Open != "4" {
+		t.Fatalf("unexpected candles: %v", candles)
+	}
+}
+
+func TestStore_BeginBlock(t *testing.T) {
+	store := NewStore(db.NewMemDB(), 0)
+
+	// records of block which is not committed are dropped
+	store.BeginBlock(1, time.Unix(10, 0))
+	store.AddCoin(1, big.NewInt(1e18), big.NewInt(1), 100)
+	commitBlock(t, store, 1, time.Unix(20, 0), nil)
+
+	candles, err := store.Candles(1, time.Unix(0, 0), time.Unix(100, 0), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 0 {
+		t.Fatalf("unexpected candles: %v", candles)
+	}
+}
+
+func TestPrice(t *testing.T) {
+	if price(big.NewInt(0), big.NewInt(100), 50).Sign() != 0 {
+		t.Fatal("price of coin without volume is not zero")
+	}
+
+	// volume less than one coin is sold entirely
+	if result := price(big.NewInt(5e17), big.NewInt(1e18), 100); result.Cmp(big.NewInt(2e18)) != 0 {
+		t.Fatalf("unexpected price: %s", result)
+	}
+
+	if result := price(big.NewInt(2e18), big.NewInt(1e18), 100); result.Cmp(big.NewInt(5e17)) != 0 {
+		t.Fatalf("unexpected price: %s", result)
+	}
+}
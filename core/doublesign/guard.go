@@ -0,0 +1,210 @@
+package doublesign
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/tempfile"
+	"github.com/tendermint/tendermint/store"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// MaxScanBlocks is the maximum number of latest blocks checked for validator's signatures on start
+const MaxScanBlocks = 1000
+
+// EvidenceTypeForeignSignature is the type of evidence stored when validator's signature is found
+// in a block not signed by this node
+const EvidenceTypeForeignSignature = "doublesign/foreign_signature"
+
+// ErrLocked is returned on attempt to sign with locked guard
+var ErrLocked = errors.New("double sign guard is locked")
+
+// Watermark is the state of guard stored on disk
+type Watermark struct {
+	// Height is the latest height the validator has signed or has been about to sign on this node
+	Height int64 `json:"height"`
+	// Locked is the reason why signing has been disabled, empty if signing is allowed
+	Locked string `json:"locked,omitempty"`
+}
+
+// Guard wraps PrivValidator and refuses to sign votes and proposals when it is possible
+// that the same validator key is used by another node
+type Guard struct {
+	pv      tmTypes.PrivValidator
+	address crypto.Address
+	path    string
+
+	watermark Watermark
+	lock      sync.RWMutex
+}
+
+// NewGuard creates Guard for given PrivValidator. Watermark is loaded from given file,
+// lastSignedHeight is used if the file is missing or contains lower height
+func NewGuard(pv tmTypes.PrivValidator, path string, lastSignedHeight int64) (*Guard, error) {
+	guard := &Guard{
+		pv:      pv,
+		address: pv.GetPubKey().Address(),
+		path:    path,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, &guard.watermark); err != nil {
+			return nil, fmt.Errorf("cannot read sign watermark from %s: %s", path, err)
+		}
+	}
+
+	if guard.watermark.Height < lastSignedHeight {
+		guard.watermark.Height = lastSignedHeight
+	}
+
+	if err := guard.save(); err != nil {
+		return nil, err
+	}
+
+	return guard, nil
+}
+
+// Address returns tendermint address of guarded validator
+func (g *Guard) Address() crypto.Address {
+	return g.address
+}
+
+// Watermark returns current state of guard
+func (g *Guard) Watermark() Watermark {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	return g.watermark
+}
+
+// CheckLocked returns error if signing is disabled by guard
+func (g *Guard) CheckLocked() error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if g.watermark.Locked != "" {
+		return fmt.Errorf("%s: %s. Make sure the validator key is not used by another node and remove the lock from %s", ErrLocked, g.watermark.Locked, g.path)
+	}
+
+	return nil
+}
+
+// CheckBlockStore looks for signatures of guarded validator in latest blocks stored on disk
+// above the watermark and locks guard if any found
+func (g *Guard) CheckBlockStore(blockStore *store.BlockStore) error {
+	height := blockStore.Height()
+	from := g.Watermark().Height + 1
+	if height-MaxScanBlocks > from {
+		from = height - MaxScanBlocks
+	}
+
+	for h := from; h <= height; h++ {
+		commit := blockStore.LoadBlockCommit(h)
+		if commit == nil {
+			commit = blockStore.LoadSeenCommit(h)
+		}
+		if commit == nil {
+			continue
+		}
+
+		for _, sig := range commit.Signatures {
+			if sig.Absent() {
+				continue
+			}
+
+			if err := g.CheckSignature(h, sig.ValidatorAddress); err != nil {
+				return err
+			}
+		}
+	}
+
+	return g.CheckLocked()
+}
+
+// CheckSignature checks signature of validator with given address found in commit of given height.
+// If the signature belongs to guarded validator and was not made by this node guard gets locked.
+func (g *Guard) CheckSignature(height int64, address []byte) error {
+	if !bytes.Equal(address, g.address) {
+		return nil
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if height <= g.watermark.Height {
+		return nil
+	}
+
+	g.watermark.Locked = fmt.Sprintf("block %d is signed by validator %s, but not by this node", height, g.address)
+	if err := g.save(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%s: %s", ErrLocked, g.watermark.Locked)
+}
+
+// GetPubKey returns public key of guarded validator
+func (g *Guard) GetPubKey() crypto.PubKey {
+	return g.pv.GetPubKey()
+}
+
+// SignVote signs vote if guard is not locked and vote is not below the watermark
+func (g *Guard) SignVote(chainID string, vote *tmTypes.Vote) error {
+	if err := g.reserveHeight(vote.Height); err != nil {
+		return err
+	}
+
+	return g.pv.SignVote(chainID, vote)
+}
+
+// SignProposal signs proposal if guard is not locked and proposal is not below the watermark
+func (g *Guard) SignProposal(chainID string, proposal *tmTypes.Proposal) error {
+	if err := g.reserveHeight(proposal.Height); err != nil {
+		return err
+	}
+
+	return g.pv.SignProposal(chainID, proposal)
+}
+
+// reserveHeight moves the watermark to given height before signing, so signatures made by this node
+// are never mistaken for foreign ones even if the node crashes right after signing
+func (g *Guard) reserveHeight(height int64) error {
+	if err := g.CheckLocked(); err != nil {
+		return err
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if height < g.watermark.Height {
+		return fmt.Errorf("height %d is below sign watermark %d", height, g.watermark.Height)
+	}
+
+	if height == g.watermark.Height {
+		return nil
+	}
+
+	g.watermark.Height = height
+
+	return g.save()
+}
+
+func (g *Guard) save() error {
+	data, err := json.MarshalIndent(g.watermark, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return tempfile.WriteFileAtomic(g.path, data, 0600)
+}
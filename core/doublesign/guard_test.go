@@ -0,0 +1,76 @@
+package doublesign
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+func TestGuard_SignVote(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doublesign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pv := tmTypes.NewMockPV()
+	guard, err := NewGuard(pv, filepath.Join(dir, "sign_watermark.json"), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := guard.SignVote("test", &tmTypes.Vote{Height: 9, Type: tmTypes.PrecommitType}); err == nil {
+		t.Fatal("vote below watermark is signed")
+	}
+
+	if err := guard.SignVote("test", &tmTypes.Vote{Height: 11, Type: tmTypes.PrecommitType}); err != nil {
+		t.Fatal(err)
+	}
+
+	if height := guard.Watermark().Height; height != 11 {
+		t.Fatalf("Wrong watermark height. Expected %d, got %d", 11, height)
+	}
+
+	if err := guard.CheckSignature(11, pv.GetPubKey().Address()); err != nil {
+		t.Fatalf("own signature is reported as foreign: %s", err)
+	}
+}
+
+func TestGuard_CheckSignature(t *testing.T) {
+	dir, err := ioutil.TempDir("", "doublesign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "sign_watermark.json")
+	pv := tmTypes.NewMockPV()
+	guard, err := NewGuard(pv, path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := guard.CheckSignature(12, tmTypes.NewMockPV().GetPubKey().Address()); err != nil {
+		t.Fatalf("signature of another validator locks guard: %s", err)
+	}
+
+	if err := guard.CheckSignature(12, pv.GetPubKey().Address()); err == nil {
+		t.Fatal("foreign signature does not lock guard")
+	}
+
+	if err := guard.SignVote("test", &tmTypes.Vote{Height: 13, Type: tmTypes.PrecommitType}); err == nil {
+		t.Fatal("vote is signed by locked guard")
+	}
+
+	reloaded, err := NewGuard(pv, path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloaded.CheckLocked(); err == nil {
+		t.Fatal("lock is not persisted")
+	}
+}
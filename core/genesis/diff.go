@@ -0,0 +1,156 @@
+package genesis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+// Kinds of changes between two states
+const (
+	KindBalance   = "balance"
+	KindCoin      = "coin"
+	KindStake     = "stake"
+	KindValidator = "validator"
+)
+
+// Change is a difference of a single value between two states.
+// Old is empty if the value has been added, New is empty if the value has been removed.
+type Change struct {
+	Kind  string `json:"kind"`
+	Key   string `json:"key"`
+	Field string `json:"field,omitempty"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+func (c Change) String() string {
+	key := c.Key
+	if c.Field != "" {
+		key += " " + c.Field
+	}
+
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("%s %s: added %s", c.Kind, key, c.New)
+	case c.New == "":
+		return fmt.Sprintf("%s %s: removed %s", c.Kind, key, c.Old)
+	}
+
+	return fmt.Sprintf("%s %s: %s -> %s", c.Kind, key, c.Old, c.New)
+}
+
+// Diff returns changes of balances, coins, stakes and validators between two states.
+// Changes are sorted by kind and key.
+func Diff(old, new *types.AppState) []Change {
+	var changes []Change
+	changes = append(changes, diffValues(KindBalance, balances(old), balances(new))...)
+	changes = append(changes, diffValues(KindCoin, coins(old), coins(new))...)
+	changes = append(changes, diffValues(KindStake, stakes(old), stakes(new))...)
+	changes = append(changes, diffValues(KindValidator, validators(old), validators(new))...)
+
+	return changes
+}
+
+// values maps key of an entity to its fields
+type values map[string]map[string]string
+
+func (v values) set(key, field, value string) {
+	if v[key] == nil {
+		v[key] = map[string]string{}
+	}
+
+	v[key][field] = value
+}
+
+func diffValues(kind string, old, new values) []Change {
+	keys := map[string]struct{}{}
+	for key := range old {
+		keys[key] = struct{}{}
+	}
+	for key := range new {
+		keys[key] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var changes []Change
+	for _, key := range sorted {
+		fields := map[string]struct{}{}
+		for field := range old[key] {
+			fields[field] = struct{}{}
+		}
+		for field := range new[key] {
+			fields[field] = struct{}{}
+		}
+
+		sortedFields := make([]string, 0, len(fields))
+		for field := range fields {
+			sortedFields = append(sortedFields, field)
+		}
+		sort.Strings(sortedFields)
+
+		for _, field := range sortedFields {
+			oldValue, newValue := old[key][field], new[key][field]
+			if oldValue != newValue {
+				changes = append(changes, Change{Kind: kind, Key: key, Field: field, Old: oldValue, New: newValue})
+			}
+		}
+	}
+
+	return changes
+}
+
+func balances(state *types.AppState) values {
+	result := values{}
+	for _, account := range state.Accounts {
+		for _, balance := range account.Balance {
+			result.set(account.Address.String(), coinField(balance.Coin), balance.Value)
+		}
+	}
+
+	return result
+}
+
+func coins(state *types.AppState) values {
+	result := values{}
+	for _, coin := range state.Coins {
+		key := fmt.Sprintf("%d", coin.ID)
+		result.set(key, "symbol", coin.Symbol.String())
+		result.set(key, "volume", coin.Volume)
+		result.set(key, "reserve", coin.Reserve)
+		result.set(key, "crr", fmt.Sprintf("%d", coin.Crr))
+		result.set(key, "max_supply", coin.MaxSupply)
+	}
+
+	return result
+}
+
+func stakes(state *types.AppState) values {
+	result := values{}
+	for _, candidate := range state.Candidates {
+		for _, stake := range candidate.Stakes {
+			result.set(candidate.PubKey.String()+" "+stake.Owner.String(), coinField(stake.Coin), stake.Value)
+		}
+	}
+
+	return result
+}
+
+func validators(state *types.AppState) values {
+	result := values{}
+	for _, validator := range state.Validators {
+		result.set(validator.PubKey.String(), "total_noah_stake", validator.TotalNoahStake)
+	}
+
+	return result
+}
+
+func coinField(id uint64) string {
+	return fmt.Sprintf("coin %d", id)
+}
@@ -0,0 +1,143 @@
+package genesis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+func testState() *types.AppState {
+	return &types.AppState{
+		Validators: []types.Validator{
+			{PubKey: types.Pubkey{1}, TotalNoahStake: "100"},
+		},
+		Candidates: []types.Candidate{
+			{PubKey: types.Pubkey{1}, Stakes: []types.Stake{
+				{Owner: types.Address{1}, Coin: 0, Value: "100"},
+				{Owner: types.Address{2}, Coin: 1, Value: "5"},
+			}},
+		},
+		Accounts: []types.Account{
+			{Address: types.Address{1}, Balance: []types.Balance{{Coin: 0, Value: "10"}, {Coin: 1, Value: "20"}}},
+		},
+		Coins: []types.Coin{
+			{ID: 1, Symbol: types.StrToCoinSymbol("TEST"), Volume: "25", Reserve: "1000", Crr: 50, MaxSupply: "1000"},
+		},
+		FrozenFunds: []types.FrozenFund{
+			{Address: types.Address{3}, Coin: 1, Value: "7"},
+		},
+		TotalSlashed: "0",
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old, new := testState(), testState()
+
+	if changes := Diff(old, new); len(changes) != 0 {
+		t.Fatalf("Equal states have changes: %v", changes)
+	}
+
+	new.Accounts[0].Balance[1].Value = "30"
+	new.Accounts = append(new.Accounts, types.Account{Address: types.Address{4}, Balance: []types.Balance{{Coin: 0, Value: "1"}}})
+	new.Coins[0].Volume = "35"
+	new.Candidates[0].Stakes = new.Candidates[0].Stakes[:1]
+	new.Validators[0].PubKey = types.Pubkey{2}
+
+	expected := []Change{
+		{Kind: KindBalance, Key: types.Address{1}.String(), Field: "coin 1", Old: "20", New: "30"},
+		{Kind: KindBalance, Key: types.Address{4}.String(), Field: "coin 0", Old: "", New: "1"},
+		{Kind: KindCoin, Key: "1", Field: "volume", Old: "25", New: "35"},
+		{Kind: KindStake, Key: types.Pubkey{1}.String() + " " + types.Address{2}.String(), Field: "coin 1", Old: "5", New: ""},
+		{Kind: KindValidator, Key: types.Pubkey{1}.String(), Field: "total_noah_stake", Old: "100", New: ""},
+		{Kind: KindValidator, Key: types.Pubkey{2}.String(), Field: "total_noah_stake", Old: "", New: "100"},
+	}
+
+	changes := Diff(old, new)
+	if len(changes) != len(expected) {
+		t.Fatalf("Wrong number of changes. Expected %d, got %d: %v", len(expected), len(changes), changes)
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("Wrong change %d. Expected %s, got %s", i, expected[i], changes[i])
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	defer func(registered map[string]Migration) {
+		migrations = registered
+	}(migrations)
+
+	migrations = map[string]Migration{}
+	Register(Migration{From: "1", To: "2", Apply: func(state *types.AppState) error {
+		state.RecalculateCoinsVolume()
+		return nil
+	}})
+
+	state := testState()
+	state.Coins[0].Volume = "0"
+
+	if err := Migrate(state, "1", "2"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 20 in balance, 5 in stake, 7 in frozen funds
+	if state.Coins[0].Volume != "32" {
+		t.Fatalf("Wrong volume of coin. Expected 32, got %s", state.Coins[0].Volume)
+	}
+
+	if err := Migrate(state, "2", "2"); err != nil {
+		t.Fatalf("Migration to the same version failed: %s", err)
+	}
+
+	if err := Migrate(state, "0", "2"); err == nil {
+		t.Fatal("Migration from unknown version succeeded")
+	}
+}
+
+func TestPath(t *testing.T) {
+	defer func(registered map[string]Migration) {
+		migrations = registered
+	}(migrations)
+
+	migrations = map[string]Migration{}
+	for _, versions := range [][2]string{{"1", "2"}, {"2", "3"}, {"3", "4"}} {
+		Register(Migration{From: versions[0], To: versions[1]})
+	}
+
+	path, err := Path("1", "4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(path) != 3 || path[0].From != "1" || path[2].To != "4" {
+		t.Fatalf("Wrong path: %v", path)
+	}
+
+	Register(Migration{From: "4", To: "2"})
+	if _, err := Path("1", "5"); err == nil {
+		t.Fatal("Loop of migrations is not detected")
+	}
+}
+
+func TestMigrations_Order(t *testing.T) {
+	defer func(registered map[string]Migration) {
+		migrations = registered
+	}(migrations)
+
+	migrations = map[string]Migration{}
+	for _, versions := range [][2]string{{"1.10", "1.11"}, {"1.9", "1.10"}, {"2.0", "2.1"}, {"1.2", "1.9"}, {"1.2.1", "1.9"}} {
+		Register(Migration{From: versions[0], To: versions[1]})
+	}
+
+	var order []string
+	for _, migration := range Migrations() {
+		order = append(order, migration.From)
+	}
+
+	if strings.Join(order, " ") != "1.2 1.2.1 1.9 1.10 2.0" {
+		t.Fatalf("Wrong order of migrations: %v", order)
+	}
+}
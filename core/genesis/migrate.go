@@ -0,0 +1,109 @@
+package genesis
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+// Migration converts the state of genesis from one version of the network to the next one.
+// Migrations change the state decoded into the current types.AppState, so versions of the state with another layout
+// can't be migrated here. The state of 1.1 is converted to the current layout from the database by noah export.
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(state *types.AppState) error
+}
+
+var migrations = map[string]Migration{}
+
+// Register adds migration to the registry. There can be only one migration from each version.
+func Register(migration Migration) {
+	if migration.From == migration.To {
+		panic(fmt.Sprintf("migration from %s to itself", migration.From))
+	}
+
+	if _, exists := migrations[migration.From]; exists {
+		panic(fmt.Sprintf("migration from %s is already registered", migration.From))
+	}
+
+	migrations[migration.From] = migration
+}
+
+// Migrations returns registered migrations sorted by version they migrate from, versions are compared by numbers of their parts
+func Migrations() []Migration {
+	result := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		result = append(result, migration)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return compareVersions(result[i].From, result[j].From) < 0
+	})
+
+	return result
+}
+
+// compareVersions compares dot separated versions part by part, e.g. 1.2 < 1.10.
+// Parts which are not numbers are compared as strings, the version which is a prefix of another one is less.
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.ParseUint(aParts[i], 10, 64)
+		bNum, bErr := strconv.ParseUint(bParts[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil && aNum != bNum:
+			if aNum < bNum {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && aParts[i] != bParts[i]:
+			return strings.Compare(aParts[i], bParts[i])
+		}
+	}
+
+	return len(aParts) - len(bParts)
+}
+
+// Path returns migrations which should be applied one by one to migrate state from one version to another
+func Path(from, to string) ([]Migration, error) {
+	var path []Migration
+	visited := map[string]bool{}
+
+	for version := from; version != to; {
+		if visited[version] {
+			return nil, fmt.Errorf("migrations from %s form a loop", from)
+		}
+		visited[version] = true
+
+		migration, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from %s to %s", version, to)
+		}
+
+		path = append(path, migration)
+		version = migration.To
+	}
+
+	return path, nil
+}
+
+// Migrate applies all migrations between given versions to the state
+func Migrate(state *types.AppState, from, to string) error {
+	path, err := Path(from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range path {
+		if err := migration.Apply(state); err != nil {
+			return fmt.Errorf("migration from %s to %s failed: %s", migration.From, migration.To, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,677 @@
+package indexer
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/state/accounts"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/transaction/encoder"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// MaxPerPage is the maximum number of items returned by a query
+const MaxPerPage = 100
+
+var (
+	ErrInvalidOrder = errors.New("order should be asc or desc")
+	ErrInvalidRole  = errors.New("unknown role of address")
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	height  INTEGER PRIMARY KEY,
+	hash    TEXT NOT NULL,
+	time    INTEGER NOT NULL,
+	num_txs INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+	height      INTEGER NOT NULL,
+	idx         INTEGER NOT NULL,
+	hash        TEXT NOT NULL,
+	raw_tx      TEXT NOT NULL,
+	sender      TEXT NOT NULL,
+	nonce       INTEGER NOT NULL,
+	gas         INTEGER NOT NULL,
+	gas_price   INTEGER NOT NULL,
+	gas_coin    INTEGER NOT NULL,
+	type        INTEGER NOT NULL,
+	data        TEXT,
+	payload     BLOB,
+	tags        TEXT NOT NULL,
+	code        INTEGER NOT NULL,
+	log         TEXT NOT NULL,
+	PRIMARY KEY (height, idx)
+);
+CREATE INDEX IF NOT EXISTS transactions_hash ON transactions (hash);
+CREATE INDEX IF NOT EXISTS transactions_sender ON transactions (sender, height);
+CREATE INDEX IF NOT EXISTS transactions_type ON transactions (type, height);
+
+CREATE TABLE IF NOT EXISTS address_transactions (
+	address TEXT NOT NULL,
+	role    TEXT NOT NULL,
+	height  INTEGER NOT NULL,
+	idx     INTEGER NOT NULL,
+	PRIMARY KEY (address, height, idx, role)
+);
+CREATE INDEX IF NOT EXISTS address_transactions_height ON address_transactions (height);
+
+CREATE TABLE IF NOT EXISTS events (
+	height            INTEGER NOT NULL,
+	idx               INTEGER NOT NULL,
+	type              TEXT NOT NULL,
+	address           TEXT NOT NULL,
+	validator_pub_key TEXT NOT NULL,
+	data              TEXT NOT NULL,
+	PRIMARY KEY (height, idx)
+);
+CREATE INDEX IF NOT EXISTS events_address ON events (address, height);
+CREATE INDEX IF NOT EXISTS events_validator_pub_key ON events (validator_pub_key, height);
+
+CREATE TABLE IF NOT EXISTS balance_changes (
+	height  INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	coin    INTEGER NOT NULL,
+	value   TEXT NOT NULL,
+	PRIMARY KEY (height, address, coin)
+);
+CREATE INDEX IF NOT EXISTS balance_changes_address ON balance_changes (address, coin, height);
+`
+
+// Tx is a decoded transaction of a committed block
+type Tx struct {
+	Hash     string            `json:"hash"`
+	RawTx    string            `json:"raw_tx"`
+	Height   uint64            `json:"height"`
+	Index    uint32            `json:"index"`
+	Time     time.Time         `json:"time"`
+	From     string            `json:"from"`
+	Nonce    uint64            `json:"nonce"`
+	Gas      int64             `json:"gas"`
+	GasPrice uint32            `json:"gas_price"`
+	GasCoin  uint32            `json:"gas_coin"`
+	Type     uint8             `json:"type"`
+	Data     json.RawMessage   `json:"data"`
+	Payload  []byte            `json:"payload"`
+	Tags     map[string]string `json:"tags"`
+	Code     uint32            `json:"code,omitempty"`
+	Log      string            `json:"log,omitempty"`
+
+	addresses []addressRole
+}
+
+// AddressTx is a transaction with roles of the address in it
+type AddressTx struct {
+	Tx
+	Roles []string `json:"roles"`
+}
+
+// Event is an app event of a committed block
+type Event struct {
+	Height          uint64          `json:"height"`
+	Index           uint32          `json:"index"`
+	Type            string          `json:"type"`
+	Address         string          `json:"address"`
+	ValidatorPubKey string          `json:"validator_pub_key"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// BalanceChange is a balance of the address at the end of the block in which it was changed
+type BalanceChange struct {
+	Height  uint64 `json:"height"`
+	Address string `json:"address"`
+	Coin    uint32 `json:"coin"`
+	Value   string `json:"value"`
+}
+
+// Page selects a page of query results ordered by heights, results are ordered by descending heights by default
+type Page struct {
+	Order   string
+	Page    int
+	PerPage int
+}
+
+// TxFilter selects transactions, zero values of fields are not used as conditions
+type TxFilter struct {
+	From       string
+	Type       uint8
+	Code       *uint32
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+// EventFilter selects events, zero values of fields are not used as conditions
+type EventFilter struct {
+	Type            string
+	Address         string
+	ValidatorPubKey string
+	FromHeight      uint64
+	ToHeight        uint64
+}
+
+// BalanceFilter selects balance changes, zero values of fields are not used as conditions
+type BalanceFilter struct {
+	Address    string
+	Coin       *uint32
+	FromHeight uint64
+	ToHeight   uint64
+}
+
+type block struct {
+	height   uint64
+	hash     string
+	time     time.Time
+	txs      []Tx
+	balances []BalanceChange
+}
+
+// Indexer writes transactions, events and balance changes of committed blocks into SQLite database
+// and serves queries over them. Blocks are collected by BeginBlock, AddTx and EndBlock and written by Commit.
+type Indexer struct {
+	db *sql.DB
+
+	lock  sync.Mutex
+	block *block
+
+	// height of the first block which failed to be written, blocks are not indexed from it until restart
+	failedHeight uint64
+}
+
+// NewIndexer opens or creates the database at the path
+func NewIndexer(path string) (*Indexer, error) {
+	if driverName == "" {
+		return nil, errors.New("indexer requires the node built with cgo")
+	}
+
+	db, err := sql.Open(driverName, fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000", path))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Indexer{db: db}, nil
+}
+
+// Close closes the database
+func (i *Indexer) Close() error {
+	return i.db.Close()
+}
+
+// NewTx decodes the delivered transaction, data of the transaction is encoded with resources of the API
+func NewTx(rawTx []byte, response transaction.Response, cState *state.CheckState) Tx {
+	tx := Tx{
+		Hash:  "Mt" + strings.ToLower(hex.EncodeToString(tmTypes.Tx(rawTx).Hash())),
+		RawTx: hex.EncodeToString(rawTx),
+		Tags:  map[string]string{},
+		Code:  response.Code,
+		Log:   response.Log,
+	}
+
+	for _, tag := range response.Tags {
+		tx.Tags[string(tag.Key)] = string(tag.Value)
+	}
+
+	decodedTx, err := transaction.TxDecoder.DecodeFromBytes(rawTx)
+	if err != nil {
+		return tx
+	}
+
+	sender, err := decodedTx.Sender()
+	if err != nil {
+		return tx
+	}
+
+	tx.From = sender.String()
+	tx.Nonce = decodedTx.Nonce
+	tx.Gas = decodedTx.Gas()
+	tx.GasPrice = decodedTx.GasPrice
+	tx.GasCoin = decodedTx.GasCoin.Uint32()
+	tx.Type = uint8(decodedTx.Type)
+	tx.Payload = decodedTx.Payload
+	tx.addresses = addressRoles(decodedTx, sender, response.Code == 0)
+
+	// coins of failed transactions may not exist, so their data is not encoded
+	if response.Code == 0 {
+		tx.Data = encodeData(decodedTx, cState)
+	}
+
+	return tx
+}
+
+func encodeData(decodedTx *transaction.Transaction, cState *state.CheckState) (data json.RawMessage) {
+	// resources expect the state of API, so a broken resource should not stop delivering of blocks
+	defer func() {
+		if r := recover(); r != nil {
+			data = nil
+		}
+	}()
+
+	data, err := encoder.NewTxEncoderJSON(cState).EncodeData(decodedTx)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// BeginBlock starts collecting the block
+func (i *Indexer) BeginBlock(height uint64, hash []byte, time time.Time) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.failedHeight != 0 {
+		return
+	}
+
+	i.block = &block{
+		height: height,
+		hash:   strings.ToUpper(hex.EncodeToString(hash)),
+		time:   time,
+	}
+}
+
+// AddTx adds the delivered transaction to the block
+func (i *Indexer) AddTx(tx Tx) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.block == nil {
+		return
+	}
+
+	tx.Height = i.block.height
+	tx.Index = uint32(len(i.block.txs))
+	tx.Time = i.block.time
+	i.block.txs = append(i.block.txs, tx)
+}
+
+// EndBlock adds balances changed by the block
+func (i *Indexer) EndBlock(balances []accounts.ChangedBalance) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.block == nil {
+		return
+	}
+
+	for _, balance := range balances {
+		i.block.balances = append(i.block.balances, BalanceChange{
+			Height:  i.block.height,
+			Address: balance.Address.String(),
+			Coin:    balance.Coin.Uint32(),
+			Value:   balance.Value.String(),
+		})
+	}
+}
+
+// Commit writes the collected block with its events in one database transaction.
+// Data of the height written before is replaced, so the block can be committed again after restart.
+// On failure indexing is stopped until restart, see FailedHeight.
+func (i *Indexer) Commit(events eventsdb.Events) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.block == nil {
+		return nil
+	}
+
+	dbTx, err := i.db.Begin()
+	if err == nil {
+		if err = i.writeBlock(dbTx, events); err != nil {
+			_ = dbTx.Rollback()
+		} else {
+			err = dbTx.Commit()
+		}
+	}
+	if err != nil {
+		i.failedHeight = i.block.height
+	}
+
+	i.block = nil
+
+	return err
+}
+
+// FailedHeight returns height of the first block which failed to be written, 0 if all blocks are written.
+// Blocks from this height are not indexed until restart and have to be re-indexed by replaying them, see noah reindex.
+func (i *Indexer) FailedHeight() uint64 {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return i.failedHeight
+}
+
+func (i *Indexer) writeBlock(dbTx *sql.Tx, events eventsdb.Events) error {
+	b := i.block
+
+	for _, table := range []string{"blocks", "transactions", "address_transactions", "events", "balance_changes"} {
+		if _, err := dbTx.Exec("DELETE FROM "+table+" WHERE height = ?", b.height); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dbTx.Exec("INSERT INTO blocks (height, hash, time, num_txs) VALUES (?, ?, ?, ?)",
+		b.height, b.hash, b.time.UnixNano(), len(b.txs)); err != nil {
+		return err
+	}
+
+	for _, tx := range b.txs {
+		tags, err := json.Marshal(tx.Tags)
+		if err != nil {
+			return err
+		}
+
+		var data interface{}
+		if tx.Data != nil {
+			data = string(tx.Data)
+		}
+
+		if _, err := dbTx.Exec(`INSERT INTO transactions
+			(height, idx, hash, raw_tx, sender, nonce, gas, gas_price, gas_coin, type, data, payload, tags, code, log)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			tx.Height, tx.Index, tx.Hash, tx.RawTx, tx.From, tx.Nonce, tx.Gas, tx.GasPrice, tx.GasCoin, tx.Type,
+			data, tx.Payload, string(tags), tx.Code, tx.Log); err != nil {
+			return err
+		}
+
+		for _, address := range tx.addresses {
+			if _, err := dbTx.Exec("INSERT OR IGNORE INTO address_transactions (address, role, height, idx) VALUES (?, ?, ?, ?)",
+				address.address.String(), address.role, tx.Height, tx.Index); err != nil {
+				return err
+			}
+		}
+	}
+
+	for index, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if _, err := dbTx.Exec(`INSERT INTO events (height, idx, type, address, validator_pub_key, data)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			b.height, index, event.Type(), event.AddressString(), event.ValidatorPubKeyString(), string(data)); err != nil {
+			return err
+		}
+	}
+
+	for _, balance := range b.balances {
+		if _, err := dbTx.Exec("INSERT INTO balance_changes (height, address, coin, value) VALUES (?, ?, ?, ?)",
+			balance.Height, balance.Address, balance.Coin, balance.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LastHeight returns the height of the last indexed block, 0 if no blocks are indexed
+func (i *Indexer) LastHeight() (uint64, error) {
+	var height sql.NullInt64
+	if err := i.db.QueryRow("SELECT MAX(height) FROM blocks").Scan(&height); err != nil {
+		return 0, err
+	}
+
+	return uint64(height.Int64), nil
+}
+
+// Transactions returns a page of transactions matching the filter and the total count of them
+func (i *Indexer) Transactions(filter TxFilter, page Page) ([]Tx, int, error) {
+	var where conditions
+	where.add(filter.From != "", "t.sender = ?", filter.From)
+	where.add(filter.Type != 0, "t.type = ?", filter.Type)
+	where.add(filter.Code != nil, "t.code = ?", filter.Code)
+	where.addHeights("t.height", filter.FromHeight, filter.ToHeight)
+
+	count, err := i.count("transactions t", where)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order, limit, offset, err := page.clause()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := i.db.Query("SELECT "+txColumns+" FROM transactions t JOIN blocks b ON b.height = t.height"+where.clause()+
+		" ORDER BY t.height "+order+", t.idx "+order+" LIMIT ? OFFSET ?", append(where.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	txs := make([]Tx, 0, limit)
+	for rows.Next() {
+		var tx Tx
+		if err := scanTx(rows, &tx); err != nil {
+			return nil, 0, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, count, rows.Err()
+}
+
+// AddressTransactions returns a page of transactions in which the address has the role, in any role if role is empty,
+// and the total count of them
+func (i *Indexer) AddressTransactions(address string, role string, page Page) ([]AddressTx, int, error) {
+	if role != "" && !IsRole(role) {
+		return nil, 0, ErrInvalidRole
+	}
+
+	var where conditions
+	where.add(true, "a.address = ?", address)
+	where.add(role != "", "a.role = ?", role)
+
+	var count int
+	if err := i.db.QueryRow("SELECT COUNT(DISTINCT a.height || '-' || a.idx) FROM address_transactions a"+where.clause(),
+		where.args...).Scan(&count); err != nil {
+		return nil, 0, err
+	}
+
+	order, limit, offset, err := page.clause()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := i.db.Query("SELECT "+txColumns+", GROUP_CONCAT(a.role) FROM address_transactions a"+
+		" JOIN transactions t ON t.height = a.height AND t.idx = a.idx JOIN blocks b ON b.height = t.height"+where.clause()+
+		" GROUP BY a.height, a.idx ORDER BY a.height "+order+", a.idx "+order+" LIMIT ? OFFSET ?", append(where.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	txs := make([]AddressTx, 0, limit)
+	for rows.Next() {
+		var (
+			tx    AddressTx
+			roles string
+		)
+		if err := scanTx(rows, &tx.Tx, &roles); err != nil {
+			return nil, 0, err
+		}
+
+		tx.Roles = strings.Split(roles, ",")
+		sort.Strings(tx.Roles)
+		txs = append(txs, tx)
+	}
+
+	return txs, count, rows.Err()
+}
+
+// txColumns are columns of transactions scanned by scanTx
+const txColumns = `t.height, t.idx, b.time, t.hash, t.raw_tx, t.sender, t.nonce, t.gas, t.gas_price, t.gas_coin, t.type,
+	t.data, t.payload, t.tags, t.code, t.log`
+
+// scanTx scans the row of txColumns followed by extra columns
+func scanTx(rows *sql.Rows, tx *Tx, extra ...interface{}) error {
+	var (
+		blockTime  int64
+		data, tags sql.NullString
+	)
+	dest := append([]interface{}{&tx.Height, &tx.Index, &blockTime, &tx.Hash, &tx.RawTx, &tx.From, &tx.Nonce, &tx.Gas,
+		&tx.GasPrice, &tx.GasCoin, &tx.Type, &data, &tx.Payload, &tags, &tx.Code, &tx.Log}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	tx.Time = time.Unix(0, blockTime).UTC()
+	if data.Valid {
+		tx.Data = json.RawMessage(data.String)
+	}
+
+	return json.Unmarshal([]byte(tags.String), &tx.Tags)
+}
+
+// Events returns a page of events matching the filter and the total count of them
+func (i *Indexer) Events(filter EventFilter, page Page) ([]Event, int, error) {
+	var where conditions
+	where.add(filter.Type != "", "type = ?", filter.Type)
+	where.add(filter.Address != "", "address = ?", filter.Address)
+	where.add(filter.ValidatorPubKey != "", "validator_pub_key = ?", filter.ValidatorPubKey)
+	where.addHeights("height", filter.FromHeight, filter.ToHeight)
+
+	count, err := i.count("events", where)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order, limit, offset, err := page.clause()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := i.db.Query("SELECT height, idx, type, address, validator_pub_key, data FROM events"+where.clause()+
+		" ORDER BY height "+order+", idx "+order+" LIMIT ? OFFSET ?", append(where.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := make([]Event, 0, limit)
+	for rows.Next() {
+		var (
+			event Event
+			data  string
+		)
+		if err := rows.Scan(&event.Height, &event.Index, &event.Type, &event.Address, &event.ValidatorPubKey, &data); err != nil {
+			return nil, 0, err
+		}
+
+		event.Data = json.RawMessage(data)
+		events = append(events, event)
+	}
+
+	return events, count, rows.Err()
+}
+
+// BalanceChanges returns a page of balance changes matching the filter and the total count of them
+func (i *Indexer) BalanceChanges(filter BalanceFilter, page Page) ([]BalanceChange, int, error) {
+	var where conditions
+	where.add(filter.Address != "", "address = ?", filter.Address)
+	where.add(filter.Coin != nil, "coin = ?", filter.Coin)
+	where.addHeights("height", filter.FromHeight, filter.ToHeight)
+
+	count, err := i.count("balance_changes", where)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	order, limit, offset, err := page.clause()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := i.db.Query("SELECT height, address, coin, value FROM balance_changes"+where.clause()+
+		" ORDER BY height "+order+", address, coin LIMIT ? OFFSET ?", append(where.args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	balances := make([]BalanceChange, 0, limit)
+	for rows.Next() {
+		var balance BalanceChange
+		if err := rows.Scan(&balance.Height, &balance.Address, &balance.Coin, &balance.Value); err != nil {
+			return nil, 0, err
+		}
+
+		balances = append(balances, balance)
+	}
+
+	return balances, count, rows.Err()
+}
+
+func (i *Indexer) count(table string, where conditions) (int, error) {
+	var count int
+	err := i.db.QueryRow("SELECT COUNT(*) FROM "+table+where.clause(), where.args...).Scan(&count)
+
+	return count, err
+}
+
+// conditions is a list of conditions of WHERE clause joined with AND
+type conditions struct {
+	list []string
+	args []interface{}
+}
+
+func (c *conditions) add(ok bool, condition string, arg interface{}) {
+	if !ok {
+		return
+	}
+
+	c.list = append(c.list, condition)
+	c.args = append(c.args, arg)
+}
+
+func (c *conditions) addHeights(column string, from, to uint64) {
+	c.add(from != 0, column+" >= ?", from)
+	c.add(to != 0, column+" <= ?", to)
+}
+
+func (c *conditions) clause() string {
+	if len(c.list) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(c.list, " AND ")
+}
+
+func (p Page) clause() (order string, limit, offset int, err error) {
+	switch strings.ToLower(p.Order) {
+	case "", "desc":
+		order = "DESC"
+	case "asc":
+		order = "ASC"
+	default:
+		return "", 0, 0, ErrInvalidOrder
+	}
+
+	limit = p.PerPage
+	if limit <= 0 || limit > MaxPerPage {
+		limit = MaxPerPage
+	}
+
+	page := p.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	return order, limit, (page - 1) * limit, nil
+}
@@ -0,0 +1,273 @@
+// +build cgo
+
+package indexer
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/state/accounts"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/tendermint/tendermint/libs/kv"
+	db "github.com/tendermint/tm-db"
+)
+
+func newTestIndexer(t *testing.T) (*Indexer, func()) {
+	dir, err := ioutil.TempDir("", "indexer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexer, err := NewIndexer(filepath.Join(dir, "indexer.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return indexer, func() {
+		_ = indexer.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func commitBlock(t *testing.T, indexer *Indexer, height uint64, txs []Tx, balances []accounts.ChangedBalance, events eventsdb.Events) {
+	indexer.BeginBlock(height, []byte{byte(height)}, time.Unix(int64(height), 0))
+	for _, tx := range txs {
+		indexer.AddTx(tx)
+	}
+	indexer.EndBlock(balances)
+
+	if err := indexer.Commit(events); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndexer_Transactions(t *testing.T) {
+	indexer, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	failed := uint32(107)
+	commitBlock(t, indexer, 1, []Tx{
+		{Hash: "Mt01", From: "NOAHx01", Type: 1, Data: []byte(`{"value":"1"}`), Tags: map[string]string{"tx.type": "01"}},
+		{Hash: "Mt02", From: "NOAHx02", Type: 1, Code: failed, Tags: map[string]string{}},
+	}, nil, nil)
+	commitBlock(t, indexer, 2, []Tx{
+		{Hash: "Mt03", From: "NOAHx01", Type: 7, Tags: map[string]string{}},
+	}, nil, nil)
+
+	txs, count, err := indexer.Transactions(TxFilter{From: "NOAHx01"}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(txs) != 2 || txs[0].Hash != "Mt03" || txs[1].Hash != "Mt01" {
+		t.Fatalf("unexpected transactions of sender: %d %v", count, txs)
+	}
+	if string(txs[1].Data) != `{"value":"1"}` || txs[1].Tags["tx.type"] != "01" || !txs[1].Time.Equal(time.Unix(1, 0)) {
+		t.Fatalf("unexpected transaction: %v", txs[1])
+	}
+
+	txs, count, err = indexer.Transactions(TxFilter{Code: &failed}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || txs[0].Hash != "Mt02" || txs[0].Index != 1 || txs[0].Data != nil {
+		t.Fatalf("unexpected failed transactions: %d %v", count, txs)
+	}
+
+	txs, count, err = indexer.Transactions(TxFilter{Type: 1, ToHeight: 1}, Page{Order: "asc", Page: 2, PerPage: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(txs) != 1 || txs[0].Hash != "Mt02" {
+		t.Fatalf("unexpected page of transactions: %d %v", count, txs)
+	}
+
+	if _, _, err := indexer.Transactions(TxFilter{}, Page{Order: "random"}); err != ErrInvalidOrder {
+		t.Fatalf("expected error of order, got %v", err)
+	}
+}
+
+func TestIndexer_AddressTransactions(t *testing.T) {
+	indexer, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	address := types.Address{1}
+	commitBlock(t, indexer, 1, []Tx{
+		{Hash: "Mt01", From: address.String(), Tags: map[string]string{}, addresses: []addressRole{
+			{address, RoleSender}, {types.Address{2}, RoleMultisendRecipient},
+		}},
+		{Hash: "Mt02", From: types.Address{2}.String(), Tags: map[string]string{}, addresses: []addressRole{
+			{types.Address{2}, RoleSender}, {address, RoleMultisendRecipient}, {address, RoleMultisendRecipient}, {address, RoleCheckIssuer},
+		}},
+	}, nil, nil)
+	commitBlock(t, indexer, 2, []Tx{
+		{Hash: "Mt03", From: types.Address{3}.String(), Tags: map[string]string{}, addresses: []addressRole{
+			{types.Address{3}, RoleSender}, {address, RoleRecipient},
+		}},
+	}, nil, nil)
+
+	txs, count, err := indexer.AddressTransactions(address.String(), "", Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 || len(txs) != 3 || txs[0].Hash != "Mt03" || txs[1].Hash != "Mt02" || txs[2].Hash != "Mt01" {
+		t.Fatalf("unexpected transactions of address: %d %v", count, txs)
+	}
+	if len(txs[1].Roles) != 2 || txs[1].Roles[0] != RoleCheckIssuer || txs[1].Roles[1] != RoleMultisendRecipient {
+		t.Fatalf("unexpected roles of address: %v", txs[1].Roles)
+	}
+
+	txs, count, err = indexer.AddressTransactions(address.String(), RoleMultisendRecipient, Page{Order: "asc", PerPage: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || len(txs) != 1 || txs[0].Hash != "Mt02" || len(txs[0].Roles) != 1 {
+		t.Fatalf("unexpected transactions of address with role: %d %v", count, txs)
+	}
+
+	if _, _, err := indexer.AddressTransactions(address.String(), "owner", Page{}); err != ErrInvalidRole {
+		t.Fatalf("expected error of role, got %v", err)
+	}
+}
+
+func TestIndexer_EventsAndBalances(t *testing.T) {
+	indexer, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	address := types.Address{1}
+	pubKey := types.Pubkey{2}
+
+	balances := []accounts.ChangedBalance{
+		{Address: address, Coin: 0, Value: big.NewInt(10)},
+		{Address: address, Coin: 1, Value: big.NewInt(0)},
+	}
+	events := eventsdb.Events{
+		&eventsdb.RewardEvent{Role: eventsdb.RoleDelegator.String(), Address: address, Amount: "10", ValidatorPubKey: pubKey},
+	}
+	commitBlock(t, indexer, 5, nil, balances, events)
+
+	// block is written again after restart
+	commitBlock(t, indexer, 5, nil, balances, events)
+
+	height, err := indexer.LastHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 5 {
+		t.Fatalf("last height is %d, not 5", height)
+	}
+
+	result, count, err := indexer.Events(EventFilter{ValidatorPubKey: pubKey.String()}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || result[0].Address != address.String() || result[0].Type != eventsdb.TypeRewardEvent {
+		t.Fatalf("unexpected events: %d %v", count, result)
+	}
+
+	coin := uint32(1)
+	changes, count, err := indexer.BalanceChanges(BalanceFilter{Address: address.String(), Coin: &coin}, Page{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || changes[0].Value != "0" || changes[0].Height != 5 {
+		t.Fatalf("unexpected balance changes: %d %v", count, changes)
+	}
+}
+
+func TestIndexer_CommitFailure(t *testing.T) {
+	indexer, cleanup := newTestIndexer(t)
+	defer cleanup()
+
+	commitBlock(t, indexer, 1, nil, nil, nil)
+	if _, err := indexer.db.Exec("DROP TABLE blocks"); err != nil {
+		t.Fatal(err)
+	}
+
+	indexer.BeginBlock(2, []byte{2}, time.Unix(2, 0))
+	indexer.AddTx(Tx{Hash: "Mt01", From: "NOAHx01", Type: 1, Tags: map[string]string{}})
+	indexer.EndBlock(nil)
+	if err := indexer.Commit(nil); err == nil {
+		t.Fatal("expected error of commit")
+	}
+	if indexer.FailedHeight() != 2 {
+		t.Fatalf("expected failed height 2, got %d", indexer.FailedHeight())
+	}
+
+	commitBlock(t, indexer, 3, []Tx{{Hash: "Mt02", From: "NOAHx01", Type: 1, Tags: map[string]string{}}}, nil, nil)
+	if indexer.FailedHeight() != 2 {
+		t.Fatalf("expected failed height 2, got %d", indexer.FailedHeight())
+	}
+
+	var count int
+	if err := indexer.db.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no transactions after failure, got %d", count)
+	}
+}
+
+func TestNewTx(t *testing.T) {
+	s, err := state.NewState(0, db.NewMemDB(), nil, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(privateKey.PublicKey)
+	to := types.Address{1}
+
+	data, err := rlp.EncodeToBytes(transaction.SendData{Coin: types.GetBaseCoinID(), To: to, Value: big.NewInt(10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decodedTx := transaction.Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          transaction.TypeSend,
+		Data:          data,
+		SignatureType: transaction.SigTypeSingle,
+	}
+	if err := decodedTx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawTx, err := rlp.EncodeToBytes(decodedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := NewTx(rawTx, transaction.Response{Tags: []kv.Pair{{Key: []byte("tx.type"), Value: []byte("01")}}}, state.NewCheckState(s))
+	if tx.From != sender.String() || tx.Nonce != 1 || tx.Type != uint8(transaction.TypeSend) || tx.Tags["tx.type"] != "01" {
+		t.Fatalf("unexpected transaction: %v", tx)
+	}
+	if string(tx.Data) != `{"coin":{"id":0,"symbol":"`+types.GetBaseCoin().String()+`"},"to":"`+to.String()+`","value":"10"}` {
+		t.Fatalf("unexpected data of transaction: %s", tx.Data)
+	}
+
+	if len(tx.addresses) != 2 || tx.addresses[0] != (addressRole{sender, RoleSender}) || tx.addresses[1] != (addressRole{to, RoleRecipient}) {
+		t.Fatalf("unexpected roles of addresses: %v", tx.addresses)
+	}
+
+	tx = NewTx(rawTx, transaction.Response{Code: 107}, state.NewCheckState(s))
+	if len(tx.addresses) != 1 || tx.addresses[0].role != RoleSender {
+		t.Fatalf("unexpected roles of addresses of failed transaction: %v", tx.addresses)
+	}
+
+	tx = NewTx([]byte{1, 2, 3}, transaction.Response{Code: 101, Log: "decode error"}, state.NewCheckState(s))
+	if tx.From != "" || tx.Data != nil || tx.Code != 101 {
+		t.Fatalf("unexpected invalid transaction: %v", tx)
+	}
+}
@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/state/accounts"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+// Roles of addresses in transactions
+const (
+	RoleSender             = "sender"
+	RoleRecipient          = "recipient"
+	RoleMultisendRecipient = "multisend_recipient"
+	RoleCheckIssuer        = "check_issuer"
+	RoleDelegator          = "delegator"
+	RoleCoinOwner          = "coin_owner"
+	RoleCandidateAddress   = "candidate_address"
+	RoleMultisig           = "multisig"
+	RoleMultisigOwner      = "multisig_owner"
+)
+
+// IsRole reports whether the role is one of the roles of addresses
+func IsRole(role string) bool {
+	switch role {
+	case RoleSender, RoleRecipient, RoleMultisendRecipient, RoleCheckIssuer, RoleDelegator,
+		RoleCoinOwner, RoleCandidateAddress, RoleMultisig, RoleMultisigOwner:
+		return true
+	}
+
+	return false
+}
+
+type addressRole struct {
+	address types.Address
+	role    string
+}
+
+// addressRoles returns addresses involved in the transaction with their roles, the sender of the transaction
+// is always included, other addresses are included only for successful transactions
+func addressRoles(tx *transaction.Transaction, sender types.Address, success bool) []addressRole {
+	roles := []addressRole{{sender, RoleSender}}
+	if !success {
+		return roles
+	}
+
+	add := func(role string, addresses ...types.Address) {
+		for _, address := range addresses {
+			roles = append(roles, addressRole{address, role})
+		}
+	}
+
+	switch data := tx.GetDecodedData().(type) {
+	case *transaction.SendData:
+		add(RoleRecipient, data.To)
+	case *transaction.MultisendData:
+		for _, item := range data.List {
+			add(RoleMultisendRecipient, item.To)
+		}
+	case *transaction.RedeemCheckData:
+		if issuer, ok := checkIssuer(data.RawCheck); ok {
+			add(RoleCheckIssuer, issuer)
+		}
+	case *transaction.CancelCheckData:
+		add(RoleCheckIssuer, sender)
+	case *transaction.DeclareCandidacyData:
+		add(RoleCandidateAddress, data.Address, sender)
+		add(RoleDelegator, sender)
+	case *transaction.EditCandidateData:
+		add(RoleCandidateAddress, data.RewardAddress, data.OwnerAddress, data.ControlAddress)
+	case *transaction.DelegateData, *transaction.UnbondData:
+		add(RoleDelegator, sender)
+	case *transaction.CreateCoinData, *transaction.RecreateCoinData:
+		add(RoleCoinOwner, sender)
+	case *transaction.EditCoinOwnerData:
+		add(RoleCoinOwner, data.NewOwner)
+	case *transaction.CreateMultisigData:
+		add(RoleMultisig, accounts.CreateMultisigAddress(sender, tx.Nonce))
+		add(RoleMultisigOwner, data.Addresses...)
+	case *transaction.EditMultisigData:
+		add(RoleMultisig, sender)
+		add(RoleMultisigOwner, data.Addresses...)
+	}
+
+	return roles
+}
+
+func checkIssuer(rawCheck []byte) (types.Address, bool) {
+	decodedCheck, err := check.DecodeFromBytes(rawCheck)
+	if err != nil {
+		return types.Address{}, false
+	}
+
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return types.Address{}, false
+	}
+
+	return issuer, true
+}
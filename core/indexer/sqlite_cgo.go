@@ -0,0 +1,10 @@
+// +build cgo
+
+package indexer
+
+import (
+	// registers SQLite driver of database/sql
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const driverName = "sqlite3"
@@ -0,0 +1,6 @@
+// +build !cgo
+
+package indexer
+
+// driverName is empty as SQLite driver requires cgo
+const driverName = ""
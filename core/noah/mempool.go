@@ -0,0 +1,119 @@
+package noah
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+// mempoolPolicy holds node-local rules of accepting transactions to mempool
+type mempoolPolicy struct {
+	minGasPriceByType     map[transaction.TxType]uint32
+	payloadSurcharge      uint32
+	payloadSurchargeBytes int
+	senderRateLimit       int
+	senderRateLimitBlocks uint64
+
+	// heights of blocks at which transactions of senders were accepted to mempool
+	senderTxs map[types.Address][]uint64
+	lock      sync.Mutex
+}
+
+func newMempoolPolicy(cfg *config.Config) *mempoolPolicy {
+	policy := &mempoolPolicy{
+		minGasPriceByType:     map[transaction.TxType]uint32{},
+		payloadSurcharge:      cfg.PayloadGasPriceSurcharge,
+		payloadSurchargeBytes: cfg.PayloadSurchargeBytes,
+		senderRateLimit:       cfg.SenderRateLimit,
+		senderRateLimitBlocks: uint64(cfg.SenderRateLimitBlocks),
+		senderTxs:             map[types.Address][]uint64{},
+	}
+
+	for name, price := range cfg.MinGasPriceByType {
+		txType, ok := transaction.TxTypeByName[name]
+		if !ok {
+			panic(fmt.Sprintf("Unknown transaction type %q in min_gas_price_by_type", name))
+		}
+
+		policy.minGasPriceByType[txType] = price
+	}
+
+	return policy
+}
+
+// minGasPrice returns minimal gas price of given tx to be accepted to mempool
+func (p *mempoolPolicy) minGasPrice(tx *transaction.Transaction, mempoolMinGasPrice uint32) uint32 {
+	price := p.minGasPriceForType(tx.Type, mempoolMinGasPrice)
+
+	if p.payloadSurchargeBytes > 0 {
+		size := len(tx.Payload) + len(tx.ServiceData)
+		price += uint32((size+p.payloadSurchargeBytes-1)/p.payloadSurchargeBytes) * p.payloadSurcharge
+	}
+
+	return price
+}
+
+func (p *mempoolPolicy) minGasPriceForType(txType transaction.TxType, mempoolMinGasPrice uint32) uint32 {
+	if price := p.minGasPriceByType[txType]; price > mempoolMinGasPrice {
+		return price
+	}
+
+	return mempoolMinGasPrice
+}
+
+// checkRateLimit returns error response if sender exceeded limit of transactions accepted to mempool
+func (p *mempoolPolicy) checkRateLimit(sender types.Address) *transaction.Response {
+	if p.senderRateLimit <= 0 {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if len(p.senderTxs[sender]) < p.senderRateLimit {
+		return nil
+	}
+
+	return &transaction.Response{
+		Code: code.TooManyTxsFromSender,
+		Log:  fmt.Sprintf("Too many transactions from %s. Limit is %d per %d blocks", sender.String(), p.senderRateLimit, p.senderRateLimitBlocks),
+		Info: transaction.EncodeError(code.NewTooManyTxsFromSender(sender.String(), strconv.Itoa(p.senderRateLimit), strconv.FormatUint(p.senderRateLimitBlocks, 10))),
+	}
+}
+
+// registerTx records transaction of sender accepted to mempool at given height
+func (p *mempoolPolicy) registerTx(sender types.Address, height uint64) {
+	if p.senderRateLimit <= 0 {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.senderTxs[sender] = append(p.senderTxs[sender], height)
+}
+
+// prune forgets transactions accepted to mempool before the rate limit window of given height
+func (p *mempoolPolicy) prune(height uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for sender, heights := range p.senderTxs {
+		i := 0
+		for i < len(heights) && heights[i]+p.senderRateLimitBlocks <= height {
+			i++
+		}
+
+		if i == len(heights) {
+			delete(p.senderTxs, sender)
+			continue
+		}
+
+		p.senderTxs[sender] = heights[i:]
+	}
+}
@@ -0,0 +1,132 @@
+package noah
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
+)
+
+func TestMempoolPolicy_MinGasPrice(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.MinGasPriceByType = map[string]uint32{"multisend": 5}
+	cfg.PayloadGasPriceSurcharge = 2
+	cfg.PayloadSurchargeBytes = 100
+
+	policy := newMempoolPolicy(cfg)
+
+	tests := []struct {
+		tx       *transaction.Transaction
+		mempool  uint32
+		expected uint32
+	}{
+		{tx: &transaction.Transaction{Type: transaction.TypeSend}, mempool: 1, expected: 1},
+		{tx: &transaction.Transaction{Type: transaction.TypeMultisend}, mempool: 1, expected: 5},
+		{tx: &transaction.Transaction{Type: transaction.TypeMultisend}, mempool: 10, expected: 10},
+		{tx: &transaction.Transaction{Type: transaction.TypeSend, Payload: make([]byte, 150)}, mempool: 1, expected: 5},
+	}
+
+	for i, test := range tests {
+		if price := policy.minGasPrice(test.tx, test.mempool); price != test.expected {
+			t.Errorf("Test %d: wrong min gas price. Expected %d, got %d", i, test.expected, price)
+		}
+	}
+}
+
+func TestMempoolPolicy_Defaults(t *testing.T) {
+	policy := newMempoolPolicy(config.DefaultConfig())
+	sender := types.Address{1}
+
+	tx := &transaction.Transaction{Type: transaction.TypeMultisend, Payload: make([]byte, 1000)}
+	if price := policy.minGasPrice(tx, 1); price != 1 {
+		t.Fatalf("Wrong min gas price by default. Expected 1, got %d", price)
+	}
+
+	for i := 0; i < 100; i++ {
+		policy.registerTx(sender, 1)
+	}
+
+	if response := policy.checkRateLimit(sender); response != nil {
+		t.Fatalf("Rate limit is applied by default: %s", response.Log)
+	}
+}
+
+func TestMempoolPolicy_RateLimit(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.SenderRateLimit = 2
+	cfg.SenderRateLimitBlocks = 10
+
+	policy := newMempoolPolicy(cfg)
+	sender := types.Address{1}
+
+	policy.registerTx(sender, 1)
+	policy.registerTx(sender, 2)
+
+	response := policy.checkRateLimit(sender)
+	if response == nil || response.Code != code.TooManyTxsFromSender {
+		t.Fatalf("Rate limit is not applied")
+	}
+
+	if response := policy.checkRateLimit(types.Address{2}); response != nil {
+		t.Fatalf("Rate limit is applied to another sender: %s", response.Log)
+	}
+
+	policy.prune(11)
+	if response := policy.checkRateLimit(sender); response != nil {
+		t.Fatalf("Rate limit is applied after window passed: %s", response.Log)
+	}
+}
+
+func TestBlockchain_RecheckTx(t *testing.T) {
+	defer upgrades.SetSchedule(types.CurrentChainID, upgrades.Schedule())
+	upgrades.SetSchedule(types.CurrentChainID, []upgrades.Upgrade{
+		{Name: upgrades.TxExpiry, Supported: true},
+	})
+
+	privateKey, _ := crypto.GenerateKey()
+
+	encodedData, err := rlp.EncodeToBytes(transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    types.Address{1},
+		Value: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          transaction.TypeSend,
+		Data:          encodedData,
+		SignatureType: transaction.SigTypeSingle,
+	}
+	tx.SetValidUntil(100)
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &Blockchain{height: 99}
+	if response := app.recheckTx(encodedTx); response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	app.height = 100
+	if response := app.recheckTx(encodedTx); response.Code != code.TxExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.TxExpired, response.Log)
+	}
+}
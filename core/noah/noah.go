@@ -2,27 +2,43 @@ package noah
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"github.com/noah-blockchain/noah-go-node/cmd/utils"
 	"github.com/noah-blockchain/noah-go-node/config"
 	"github.com/noah-blockchain/noah-go-node/core/appdb"
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/coinhistory"
+	"github.com/noah-blockchain/noah-go-node/core/doublesign"
 	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/indexer"
 	"github.com/noah-blockchain/noah-go-node/core/rewards"
 	"github.com/noah-blockchain/noah-go-node/core/state"
 	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
 	"github.com/noah-blockchain/noah-go-node/core/statistics"
+	"github.com/noah-blockchain/noah-go-node/core/storage"
 	"github.com/noah-blockchain/noah-go-node/core/transaction"
 	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/noah-blockchain/noah-go-node/core/validators"
+	"github.com/noah-blockchain/noah-go-node/core/webhooks"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 	"github.com/noah-blockchain/noah-go-node/version"
-	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/tendermint/go-amino"
 	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmLog "github.com/tendermint/tendermint/libs/log"
 	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/store"
 	"github.com/tendermint/tm-db"
+	"io"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -56,6 +72,7 @@ type Blockchain struct {
 	stateDB            db.DB
 	appDB              *appdb.AppDB
 	eventsDB           eventsdb.IEventsDB
+	coinHistory        *coinhistory.Store
 	stateDeliver       *state.State
 	stateCheck         *state.CheckState
 	height             uint64   // current Blockchain height
@@ -65,9 +82,26 @@ type Blockchain struct {
 	// local rpc client for Tendermint
 	tmNode *tmNode.Node
 
+	// signGuard protects validator of this node from double signing, nil if node is not a validator
+	signGuard *doublesign.Guard
+
 	// currentMempool is responsive for prevent sending multiple transactions from one address in one block
 	currentMempool *sync.Map
 
+	// mempoolPolicy holds minimal gas prices and rate limits of transactions accepted to mempool
+	mempoolPolicy *mempoolPolicy
+
+	// readViews serves immutable states of committed heights to API
+	readViews *readViews
+
+	// indexer writes transactions, events and balance changes of committed blocks to SQL database, nil if disabled
+	indexer *indexer.Indexer
+
+	// webhooks collects activity of addresses and candidates watched by webhooks and delivers it
+	webhooks *webhooks.Notifier
+
+	logger tmLog.Logger
+
 	lock sync.RWMutex
 
 	haltHeight uint64
@@ -78,7 +112,7 @@ type Blockchain struct {
 func NewNoahBlockchain(cfg *config.Config) *Blockchain {
 	var err error
 
-	ldb, err := db.NewGoLevelDBWithOpts("state", utils.GetNoahHome()+"/data", getDbOpts(cfg.StateMemAvailable))
+	ldb, err := storage.NewDB("state", db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", cfg.StateMemAvailable)
 	if err != nil {
 		panic(err)
 	}
@@ -86,7 +120,7 @@ func NewNoahBlockchain(cfg *config.Config) *Blockchain {
 	// Initiate Application DB. Used for persisting data like current block, validators, etc.
 	applicationDB := appdb.NewAppDB(cfg)
 
-	edb, err := db.NewGoLevelDBWithOpts("events", utils.GetNoahHome()+"/data", getDbOpts(1024))
+	edb, err := storage.NewDB("events", db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", 1024)
 	if err != nil {
 		panic(err)
 	}
@@ -97,15 +131,38 @@ func NewNoahBlockchain(cfg *config.Config) *Blockchain {
 		height:         applicationDB.GetLastHeight(),
 		eventsDB:       eventsdb.NewEventsStore(edb),
 		currentMempool: &sync.Map{},
+		mempoolPolicy:  newMempoolPolicy(cfg),
+		readViews:      newReadViews(ldb, cfg.KeepLastStates),
+		webhooks:       webhooks.NewNotifier(applicationDB, cfg.WebhookMaxAttempts, cfg.WebhookTimeout),
+		logger:         tmLog.NewNopLogger(),
 		cfg:            cfg,
 	}
 
+	// Volume, reserve and price of coins changed by blocks are recorded for coin_history of API
+	if cfg.CoinHistory {
+		chdb, err := storage.NewDB("coin_history", db.BackendType(cfg.DBBackend), utils.GetNoahHome()+"/data", 0)
+		if err != nil {
+			panic(err)
+		}
+		blockchain.coinHistory = coinhistory.NewStore(chdb, cfg.CoinHistoryRetention)
+	}
+
+	if cfg.SQLIndexer {
+		blockchain.indexer, err = indexer.NewIndexer(utils.GetNoahHome() + "/data/indexer.db")
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	// Set stateDeliver and stateCheck
 	blockchain.stateDeliver, err = state.NewState(blockchain.height, blockchain.stateDB, blockchain.eventsDB, cfg.StateCacheSize, cfg.KeepLastStates)
 	if err != nil {
 		panic(err)
 	}
 
+	if blockchain.coinHistory != nil {
+		blockchain.stateDeliver.SetCoinHistory(blockchain.coinHistory)
+	}
 	blockchain.stateCheck = state.NewCheckState(blockchain.stateDeliver)
 
 	// Set start height for rewards and validators
@@ -123,7 +180,9 @@ func (app *Blockchain) InitChain(req abciTypes.RequestInitChain) abciTypes.Respo
 		panic(err)
 	}
 
-	if err := app.stateDeliver.Import(genesisState); err != nil {
+	if genesisState.StateFile != nil {
+		genesisState = app.importStateFile(genesisState.StateFile, genesisState.StartHeight)
+	} else if err := app.stateDeliver.Import(genesisState); err != nil {
 		panic(err)
 	}
 
@@ -138,6 +197,43 @@ func (app *Blockchain) InitChain(req abciTypes.RequestInitChain) abciTypes.Respo
 	}
 }
 
+// importStateFile imports the state written by streaming export, start height of genesis replaces the one of the file
+// if it is set. Relative name of the file is resolved against directory of genesis file.
+// Panics if the file can't be imported, the state is not valid or hash of the file doesn't match.
+func (app *Blockchain) importStateFile(stateFile *types.StateFile, startHeight uint64) types.AppState {
+	name := stateFile.Name
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(filepath.Dir(app.cfg.GenesisFile()), name)
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot open state file: %s", err))
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	reader := io.TeeReader(file, hash)
+
+	header, err := app.stateDeliver.ImportStream(reader, startHeight)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot import state file %s: %s", name, err))
+	}
+
+	// the rest of the file is read to get its hash
+	if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+		panic(fmt.Sprintf("Cannot read state file %s: %s", name, err))
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != strings.ToLower(stateFile.Sha256) {
+		panic(fmt.Sprintf("Wrong hash of state file %s. Expected %s, got %s", name, stateFile.Sha256, sum))
+	}
+
+	app.logger.Info("State imported", "file", name, "sha256", stateFile.Sha256)
+
+	return *header
+}
+
 // BeginBlock signals the beginning of a block.
 func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.ResponseBeginBlock {
 	height := uint64(req.Header.Height)
@@ -154,6 +250,14 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 	atomic.StoreUint64(&app.height, height)
 	app.rewards = big.NewInt(0)
 
+	if app.indexer != nil {
+		app.indexer.BeginBlock(height, req.Hash, req.Header.Time)
+	}
+	app.webhooks.BeginBlock()
+	if app.coinHistory != nil {
+		app.coinHistory.BeginBlock(height, req.Header.Time)
+	}
+
 	// clear absent candidates
 	app.lock.Lock()
 	app.validatorsStatuses = map[types.TmAddress]int8{}
@@ -166,8 +270,13 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 		if v.SignedLastBlock {
 			app.stateDeliver.Validators.SetValidatorPresent(height, address)
 			app.validatorsStatuses[address] = ValidatorPresent
+			app.checkSignature(height-1, address, req.Header.Time)
 		} else {
-			app.stateDeliver.Validators.SetValidatorAbsent(height, address)
+			if app.stateDeliver.Validators.SetValidatorAbsent(height, address) {
+				if candidate := app.stateDeliver.Candidates.GetCandidateByTendermintAddress(address); candidate != nil {
+					app.webhooks.CandidateOffline(candidate.PubKey)
+				}
+			}
 			app.validatorsStatuses[address] = ValidatorAbsent
 		}
 	}
@@ -177,11 +286,28 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 		panic(fmt.Sprintf("Application halted at height %d", height))
 	}
 
+	// refuse to process blocks of upgrades which are not implemented by this build
+	if err := upgrades.CheckSupported(height); err != nil {
+		panic(fmt.Sprintf("Application halted at height %d: %s", height, err))
+	}
+
+	for _, upgrade := range upgrades.Schedule() {
+		if upgrade.Height == height {
+			app.logger.Info("Upgrade activated", "name", upgrade.Name, "version", upgrade.Version, "height", height)
+		}
+	}
+
 	// give penalty to Byzantine validators
 	for _, byzVal := range req.ByzantineValidators {
 		var address types.TmAddress
 		copy(address[:], byzVal.Validator.Address)
 
+		app.appDB.AddEvidence(address, appdb.Evidence{
+			Type:   byzVal.Type,
+			Height: uint64(byzVal.Height),
+			Time:   byzVal.Time,
+		})
+
 		// skip already offline candidates to prevent double punishing
 		candidate := app.stateDeliver.Candidates.GetCandidateByTendermintAddress(address)
 		if candidate == nil || candidate.Status == candidates.CandidateStatusOffline || app.stateDeliver.Validators.GetByTmAddress(address) == nil {
@@ -212,6 +338,9 @@ func (app *Blockchain) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.Res
 
 	app.stateDeliver.Halts.Delete(height)
 
+	// apply scheduled changes of candidates' commissions
+	app.stateDeliver.Candidates.ApplyCommissionUpdates(height)
+
 	return abciTypes.ResponseBeginBlock{}
 }
 
@@ -290,6 +419,10 @@ func (app *Blockchain) EndBlock(req abciTypes.RequestEndBlock) abciTypes.Respons
 		updates = app.updateValidators(height)
 	}
 
+	if app.indexer != nil {
+		app.indexer.EndBlock(app.stateDeliver.Accounts.ChangedBalances())
+	}
+
 	defer func() {
 		app.StatisticData().PushEndBlock(&statistics.EndRequest{TimeEnd: time.Now(), Height: int64(app.height)})
 	}()
@@ -368,10 +501,23 @@ func (app *Blockchain) updateValidators(height uint64) []abciTypes.ValidatorUpda
 
 // Info return application info. Used for synchronization between Tendermint and Noah
 func (app *Blockchain) Info(req abciTypes.RequestInfo) (resInfo abciTypes.ResponseInfo) {
+	lastHeight := app.appDB.GetLastHeight()
+
+	// data contains the next scheduled upgrade, if any
+	var data string
+	if next := upgrades.Next(lastHeight); next != nil {
+		encoded, err := json.Marshal(next)
+		if err != nil {
+			panic(err)
+		}
+		data = string(encoded)
+	}
+
 	return abciTypes.ResponseInfo{
+		Data:             data,
 		Version:          version.Version,
 		AppVersion:       version.AppVer,
-		LastBlockHeight:  int64(app.appDB.GetLastHeight()),
+		LastBlockHeight:  int64(lastHeight),
 		LastBlockAppHash: app.appDB.GetLastBlockHash(),
 	}
 }
@@ -380,6 +526,11 @@ func (app *Blockchain) Info(req abciTypes.RequestInfo) (resInfo abciTypes.Respon
 func (app *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeliverTx {
 	response := transaction.RunTx(app.stateDeliver, req.Tx, app.rewards, app.height, &sync.Map{}, 0)
 
+	if app.indexer != nil {
+		app.indexer.AddTx(indexer.NewTx(req.Tx, response, state.NewCheckState(app.stateDeliver)))
+	}
+	app.webhooks.AddTx(req.Tx, response)
+
 	return abciTypes.ResponseDeliverTx{
 		Code:      response.Code,
 		Data:      response.Data,
@@ -398,7 +549,7 @@ func (app *Blockchain) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.Respo
 
 // CheckTx validates a tx for the mempool
 func (app *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseCheckTx {
-	response := transaction.RunTx(app.stateCheck, req.Tx, nil, app.height, app.currentMempool, app.MinGasPrice())
+	response := app.checkTx(req)
 
 	return abciTypes.ResponseCheckTx{
 		Code:      response.Code,
@@ -416,6 +567,55 @@ func (app *Blockchain) CheckTx(req abciTypes.RequestCheckTx) abciTypes.ResponseC
 	}
 }
 
+func (app *Blockchain) checkTx(req abciTypes.RequestCheckTx) transaction.Response {
+	if req.Type == abciTypes.CheckTxType_Recheck {
+		return app.recheckTx(req.Tx)
+	}
+
+	minGasPrice := app.MinGasPrice()
+
+	// rate limits are applied only to transactions coming to mempool for the first time
+	var sender *types.Address
+	if tx, err := transaction.TxDecoder.DecodeFromBytes(req.Tx); err == nil {
+		minGasPrice = app.mempoolPolicy.minGasPrice(tx, minGasPrice)
+		if address, err := tx.Sender(); err == nil && req.Type == abciTypes.CheckTxType_New {
+			sender = &address
+		}
+	}
+
+	if sender != nil {
+		if response := app.mempoolPolicy.checkRateLimit(*sender); response != nil {
+			return *response
+		}
+	}
+
+	response := transaction.RunTx(app.stateCheck, req.Tx, nil, app.height, app.currentMempool, minGasPrice)
+	if sender != nil && response.Code == code.OK {
+		app.mempoolPolicy.registerTx(*sender, app.height)
+	}
+
+	return response
+}
+
+// recheckTx evicts expired transactions from mempool after a block is committed,
+// other transactions are kept without full check
+func (app *Blockchain) recheckTx(rawTx []byte) transaction.Response {
+	tx, err := transaction.TxDecoder.DecodeFromBytes(rawTx)
+	if err != nil {
+		return transaction.Response{
+			Code: code.DecodeError,
+			Log:  err.Error(),
+			Info: transaction.EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if response := transaction.CheckExpiry(tx, app.Height()+1); response != nil {
+		return *response
+	}
+
+	return transaction.Response{Code: code.OK}
+}
+
 // Commit the state and return the application Merkle root hash
 func (app *Blockchain) Commit() abciTypes.ResponseCommit {
 	if app.height > app.appDB.GetStartHeight()+1 {
@@ -436,9 +636,27 @@ func (app *Blockchain) Commit() abciTypes.ResponseCommit {
 		panic(err)
 	}
 
+	// Coin history and SQL indexer are optional, their failures do not halt the chain
+	if app.coinHistory != nil {
+		if err := app.coinHistory.Commit(); err != nil {
+			app.logger.Error("Failed to write coin history, records of the block are lost", "height", app.height, "err", err)
+		}
+	}
+
+	// Block is indexed and its webhook payloads are written to outbox before the height is persisted,
+	// so it is processed again if the node stops here
+	events := app.eventsDB.LoadEvents(uint32(app.height))
+	if app.indexer != nil {
+		if err := app.indexer.Commit(events); err != nil {
+			app.logger.Error("Failed to index block, SQL indexer is stopped until restart, blocks from the height have to be re-indexed by noah reindex", "height", app.height, "err", err)
+		}
+	}
+	app.webhooks.Commit(app.height, events)
+
 	// Persist application hash and height
 	app.appDB.SetLastBlockHash(hash)
 	app.appDB.SetLastHeight(app.height)
+	app.readViews.prune(app.height)
 
 	app.stateDeliver.Unlock()
 
@@ -447,6 +665,7 @@ func (app *Blockchain) Commit() abciTypes.ResponseCommit {
 
 	// Clear mempool
 	app.currentMempool = &sync.Map{}
+	app.mempoolPolicy.prune(app.height)
 
 	return abciTypes.ResponseCommit{
 		Data: hash,
@@ -465,7 +684,13 @@ func (app *Blockchain) SetOption(req abciTypes.RequestSetOption) abciTypes.Respo
 
 // Stop gracefully stopping Noah Blockchain instance
 func (app *Blockchain) Stop() {
+	app.webhooks.Stop()
 	app.appDB.Close()
+	if app.indexer != nil {
+		if err := app.indexer.Close(); err != nil {
+			panic(err)
+		}
+	}
 	if err := app.stateDB.Close(); err != nil {
 		panic(err)
 	}
@@ -479,16 +704,43 @@ func (app *Blockchain) CurrentState() *state.CheckState {
 	return app.stateCheck
 }
 
-// GetStateForHeight returns immutable state of Noah Blockchain for given height
+// GetStateForHeight returns read view of the state committed at given height, 0 - the latest committed height.
+// Read views are immutable and shared between readers, so they do not block CheckTx and Commit.
+// Height of the returned state is the version of its tree, the latest committed height may change after the call.
 func (app *Blockchain) GetStateForHeight(height uint64) (*state.CheckState, error) {
-	if height > 0 {
-		s, err := state.NewCheckStateAtHeight(height, app.stateDB)
-		if err != nil {
-			return nil, err
-		}
-		return s, nil
+	if height == 0 {
+		height = app.LastCommittedHeight()
 	}
-	return blockchain.CurrentState(), nil
+
+	if height == 0 {
+		return app.CurrentState(), nil
+	}
+
+	return app.readViews.get(height)
+}
+
+// ReadState returns read view of the latest committed state, current check state if the view can't be created
+func (app *Blockchain) ReadState() *state.CheckState {
+	cState, err := app.GetStateForHeight(0)
+	if err != nil {
+		return app.CurrentState()
+	}
+
+	return cState
+}
+
+// GetImmutableTreeAtHeight returns state tree committed at given height, 0 - the latest committed height
+func (app *Blockchain) GetImmutableTreeAtHeight(height uint64) (*tree.ImmutableTree, error) {
+	if height == 0 {
+		height = app.LastCommittedHeight()
+	}
+
+	return app.stateDeliver.Tree().GetImmutableAtHeight(int64(height))
+}
+
+// LastCommittedHeight returns height of the latest committed state, current height may be still being delivered
+func (app *Blockchain) LastCommittedHeight() uint64 {
+	return app.appDB.GetLastHeight()
 }
 
 // Height returns current height of Noah Blockchain
@@ -501,6 +753,37 @@ func (app *Blockchain) SetTmNode(node *tmNode.Node) {
 	app.tmNode = node
 }
 
+// SetLogger sets logger of background tasks of the Blockchain
+func (app *Blockchain) SetLogger(logger tmLog.Logger) {
+	app.logger = logger
+}
+
+// SetSignGuard sets double sign guard of validator running on this node
+func (app *Blockchain) SetSignGuard(guard *doublesign.Guard) {
+	app.signGuard = guard
+}
+
+// GetEvidence returns evidence of misbehaviour of validator with given address observed by the node
+func (app *Blockchain) GetEvidence(address types.TmAddress) []appdb.Evidence {
+	return app.appDB.GetEvidence(address)
+}
+
+// checkSignature reports signature of validator in commit of given height to the double sign guard
+// and stores evidence if the signature is not made by this node
+func (app *Blockchain) checkSignature(height uint64, address types.TmAddress, blockTime time.Time) {
+	if app.signGuard == nil {
+		return
+	}
+
+	if err := app.signGuard.CheckSignature(int64(height), address[:]); err != nil {
+		app.appDB.AddEvidence(address, appdb.Evidence{
+			Type:   doublesign.EvidenceTypeForeignSignature,
+			Height: height,
+			Time:   blockTime,
+		})
+	}
+}
+
 // MinGasPrice returns minimal acceptable gas price
 func (app *Blockchain) MinGasPrice() uint32 {
 	mempoolSize := app.tmNode.Mempool().Size()
@@ -524,6 +807,23 @@ func (app *Blockchain) MinGasPrice() uint32 {
 	return 1
 }
 
+// MinGasPriceByType returns minimal acceptable gas prices by names of transaction types
+func (app *Blockchain) MinGasPriceByType() map[string]uint32 {
+	minGasPrice := app.MinGasPrice()
+
+	prices := make(map[string]uint32, len(transaction.TxTypeByName))
+	for name, txType := range transaction.TxTypeByName {
+		prices[name] = app.mempoolPolicy.minGasPriceForType(txType, minGasPrice)
+	}
+
+	return prices
+}
+
+// PayloadGasPriceSurcharge returns surcharge to minimal gas price for every started given number of bytes of tx payload
+func (app *Blockchain) PayloadGasPriceSurcharge() (surcharge uint32, bytes int) {
+	return app.mempoolPolicy.payloadSurcharge, app.mempoolPolicy.payloadSurchargeBytes
+}
+
 func (app *Blockchain) resetCheckState() {
 	app.lock.Lock()
 	defer app.lock.Unlock()
@@ -545,12 +845,14 @@ func (app *Blockchain) updateBlocksTimeDelta(height uint64, count int64) {
 		return
 	}
 
+	app.updateBlocksTimeDeltaFromStore(app.tmNode.BlockStore(), height, count)
+}
+
+func (app *Blockchain) updateBlocksTimeDeltaFromStore(blockStore *store.BlockStore, height uint64, count int64) {
 	if int64(height)-count-1 < 1 {
 		return
 	}
 
-	blockStore := app.tmNode.BlockStore()
-
 	blockA := blockStore.LoadBlockMeta(int64(height) - count - 1)
 	blockB := blockStore.LoadBlockMeta(int64(height) - 1)
 
@@ -605,6 +907,26 @@ func (app *Blockchain) GetEventsDB() eventsdb.IEventsDB {
 	return app.eventsDB
 }
 
+// GetStateDB returns database of the state tree
+func (app *Blockchain) GetStateDB() db.DB {
+	return app.stateDB
+}
+
+// CoinHistory returns records of volume, reserve and price of coins, nil if coin history is disabled
+func (app *Blockchain) CoinHistory() *coinhistory.Store {
+	return app.coinHistory
+}
+
+// Webhooks returns notifier of webhooks registered on this node
+func (app *Blockchain) Webhooks() *webhooks.Notifier {
+	return app.webhooks
+}
+
+// Indexer returns SQL indexer of committed blocks, nil if indexer is disabled
+func (app *Blockchain) Indexer() *indexer.Indexer {
+	return app.indexer
+}
+
 // SetStatisticData used for collection statistics about blockchain operations
 func (app *Blockchain) SetStatisticData(statisticData *statistics.Data) *statistics.Data {
 	app.statisticData = statisticData
@@ -676,15 +998,3 @@ func (app *Blockchain) isApplicationHalted(height uint64) bool {
 
 	return false
 }
-
-func getDbOpts(memLimit int) *opt.Options {
-	if memLimit < 1024 {
-		panic(fmt.Sprintf("Not enough memory given to StateDB. Expected >1024M, given %d", memLimit))
-	}
-	return &opt.Options{
-		OpenFilesCacheCapacity: memLimit,
-		BlockCacheCapacity:     memLimit / 2 * opt.MiB,
-		WriteBuffer:            memLimit / 4 * opt.MiB, // Two of these are used internally
-		Filter:                 filter.NewBloomFilter(10),
-	}
-}
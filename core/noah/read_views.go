@@ -0,0 +1,86 @@
+package noah
+
+import (
+	"sync"
+
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	db "github.com/tendermint/tm-db"
+)
+
+// readViewsCacheSize is the number of read views of recently requested heights kept in memory
+const readViewsCacheSize = 8
+
+// readViews keeps read views of recently requested committed heights. Views are built on immutable trees on the first request
+// and do not share locks with CheckTx and Commit, so API queries do not slow down mempool processing.
+type readViews struct {
+	db db.DB
+	// keepLastStates is the number of the latest states kept in db, views of older states are dropped on commit
+	keepLastStates int64
+
+	lock    sync.Mutex
+	views   map[uint64]*state.CheckState
+	heights []uint64
+}
+
+func newReadViews(db db.DB, keepLastStates int64) *readViews {
+	return &readViews{
+		db:             db,
+		keepLastStates: keepLastStates,
+		views:          map[uint64]*state.CheckState{},
+	}
+}
+
+// get returns view of given height from cache or creates it
+func (v *readViews) get(height uint64) (*state.CheckState, error) {
+	v.lock.Lock()
+	cState, ok := v.views[height]
+	v.lock.Unlock()
+
+	if ok {
+		return cState, nil
+	}
+
+	// the view is built without the lock, concurrent requests of the same height may build it twice
+	cState, err := state.NewReadViewAtHeight(height, v.db)
+	if err != nil {
+		return nil, err
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if cached, ok := v.views[height]; ok {
+		return cached, nil
+	}
+
+	v.views[height] = cState
+	v.heights = append(v.heights, height)
+	if len(v.heights) > readViewsCacheSize {
+		delete(v.views, v.heights[0])
+		v.heights = v.heights[1:]
+	}
+
+	return cState, nil
+}
+
+// prune drops views of states which are pruned or going to be pruned after the committed height,
+// so the views do not read nodes of deleted versions
+func (v *readViews) prune(committedHeight uint64) {
+	if int64(committedHeight) <= v.keepLastStates {
+		return
+	}
+	minHeight := committedHeight - uint64(v.keepLastStates)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	heights := v.heights[:0]
+	for _, height := range v.heights {
+		if height < minHeight {
+			delete(v.views, height)
+			continue
+		}
+		heights = append(heights, height)
+	}
+	v.heights = heights
+}
@@ -0,0 +1,82 @@
+package noah
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestReadViews(t *testing.T) {
+	stateDB := db.NewMemDB()
+	s, err := state.NewState(0, stateDB, eventsdb.NewEventsStore(db.NewMemDB()), 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	views := newReadViews(stateDB, 100)
+	address := types.Address{1}
+
+	for height := uint64(1); height <= readViewsCacheSize+2; height++ {
+		s.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(1))
+		if _, err := s.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	view, err := views.get(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if view.Tree().Version() != 3 {
+		t.Fatalf("Wrong height of view: %d", view.Tree().Version())
+	}
+
+	if balance := view.Accounts().GetBalance(address, types.GetBaseCoinID()); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("Wrong balance in view of height 3: %s", balance)
+	}
+
+	// new commits do not change the view
+	s.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(1))
+	if balance := view.Accounts().GetBalance(address, types.GetBaseCoinID()); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("View has been changed: %s", balance)
+	}
+
+	if cached, err := views.get(3); err != nil || cached != view {
+		t.Fatal("View is not cached")
+	}
+
+	for height := uint64(1); height <= readViewsCacheSize+2; height++ {
+		if _, err := views.get(height); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(views.views) != readViewsCacheSize {
+		t.Fatalf("Wrong number of cached views. Expected %d, got %d", readViewsCacheSize, len(views.views))
+	}
+
+	if _, ok := views.views[3]; ok {
+		t.Fatal("The oldest view is not evicted")
+	}
+
+	if _, err := views.get(100); err == nil {
+		t.Fatal("View of uncommitted height is created")
+	}
+
+	// views of states which are pruned are dropped
+	views.keepLastStates = 5
+	views.prune(readViewsCacheSize + 2)
+	for height := range views.views {
+		if height < 5 {
+			t.Fatalf("View of pruned height %d is kept", height)
+		}
+	}
+	if len(views.views) != len(views.heights) || len(views.views) != 6 {
+		t.Fatalf("Wrong views after pruning: %v", views.heights)
+	}
+}
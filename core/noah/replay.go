@@ -0,0 +1,278 @@
+package noah
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/appdb"
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/indexer"
+	"github.com/noah-blockchain/noah-go-node/core/rewards"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/webhooks"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmLog "github.com/tendermint/tendermint/libs/log"
+	tmState "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/store"
+	tmTypes "github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tm-db"
+)
+
+// ReplayedTx is the result of the transaction delivered during replay and changes of the state made by it
+type ReplayedTx struct {
+	Hash    []byte
+	Code    uint32
+	Log     string
+	Changes []tree.Change
+}
+
+// ReplayedBlock is the result of the replayed block
+type ReplayedBlock struct {
+	Height uint64
+
+	// BeginBlock and EndBlock are changes of the state made by the application outside of transactions
+	BeginBlock []tree.Change
+	Txs        []ReplayedTx
+	EndBlock   []tree.Change
+
+	AppHash []byte
+	// ExpectedAppHash is taken from the header of the next stored block, nil if the next block is not stored yet
+	ExpectedAppHash []byte
+}
+
+// AppHashMatches reports whether the app hash of replayed block matches the hash stored by the network
+func (b *ReplayedBlock) AppHashMatches() bool {
+	return b.ExpectedAppHash == nil || bytes.Equal(b.AppHash, b.ExpectedAppHash)
+}
+
+// Replayer re-executes blocks stored by Tendermint over the state loaded through overlays of the state db,
+// so databases of the node are only read and the state is not copied.
+type Replayer struct {
+	app *Blockchain
+
+	// shadow delivers the same blocks and flushes its state after every transaction to record changes.
+	// Hash of IAVL tree depends on the order of writes, so the app flushes the state once per block
+	// the same way as the network does and only its hash is compared.
+	shadow     *Blockchain
+	shadowTree *tree.RecordingTree
+
+	blockStore *store.BlockStore
+	tmStateDB  db.DB
+	height     uint64
+}
+
+// NewReplayer creates replayer of blocks following the state of given height stored in the state db.
+// Start height is the height of genesis of the network, Tendermint state db is used to load sets of validators.
+func NewReplayer(cfg *config.Config, stateDB db.DB, height uint64, startHeight uint64, blockStore *store.BlockStore, tmStateDB db.DB) (*Replayer, error) {
+	// validators are only used to compute validator updates which do not affect app hash
+	vals, err := tmState.LoadValidators(tmStateDB, int64(height)+1)
+	if err != nil {
+		return nil, err
+	}
+	validators := tmTypes.TM2PB.ValidatorUpdates(vals)
+
+	rewards.SetStartHeight(startHeight)
+
+	app, _, err := newReplayBlockchain(cfg, stateDB, height, startHeight, validators, false)
+	if err != nil {
+		return nil, err
+	}
+
+	shadow, shadowTree, err := newReplayBlockchain(cfg, stateDB, height, startHeight, validators, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{
+		app:        app,
+		shadow:     shadow,
+		shadowTree: shadowTree,
+		blockStore: blockStore,
+		tmStateDB:  tmStateDB,
+		height:     height,
+	}, nil
+}
+
+// newReplayBlockchain loads the state of given height over the overlay of the state db, only new versions are kept in memory
+func newReplayBlockchain(cfg *config.Config, stateDB db.DB, height uint64, startHeight uint64, validators abciTypes.ValidatorUpdates, record bool) (*Blockchain, *tree.RecordingTree, error) {
+	overlayDB := tree.NewOverlayDB(stateDB)
+	mutableTree, err := tree.NewMutableTree(height, overlayDB, cfg.StateCacheSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var recordingTree *tree.RecordingTree
+	deliverTree := mutableTree
+	if record {
+		recordingTree = tree.NewRecordingTree(mutableTree)
+		deliverTree = recordingTree
+	}
+
+	eventsDB := eventsdb.NewEventsStore(db.NewMemDB())
+	stateDeliver, err := state.NewStateForTree(deliverTree, eventsDB, overlayDB, cfg.KeepLastStates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applicationDB := appdb.NewMemAppDB()
+	applicationDB.SetStartHeight(startHeight)
+	applicationDB.SetLastHeight(height)
+	applicationDB.SetLastBlockHash(mutableTree.Hash())
+	applicationDB.SaveValidators(validators)
+
+	return &Blockchain{
+		stateDB:        overlayDB,
+		appDB:          applicationDB,
+		height:         height,
+		eventsDB:       eventsDB,
+		stateDeliver:   stateDeliver,
+		stateCheck:     state.NewCheckState(stateDeliver),
+		currentMempool: &sync.Map{},
+		mempoolPolicy:  newMempoolPolicy(cfg),
+		readViews:      newReadViews(overlayDB, cfg.KeepLastStates),
+		webhooks:       webhooks.NewNotifier(applicationDB, cfg.WebhookMaxAttempts, cfg.WebhookTimeout),
+		logger:         tmLog.NewNopLogger(),
+		cfg:            cfg,
+	}, recordingTree, nil
+}
+
+// SetIndexer makes the replayer write replayed blocks to the SQL indexer the same way as the node does,
+// so blocks which failed to be written by the node can be indexed again. Failures of the indexer are logged by the logger.
+func (r *Replayer) SetIndexer(indexer *indexer.Indexer, logger tmLog.Logger) {
+	r.app.indexer = indexer
+	r.app.logger = logger
+}
+
+// Height returns the last replayed height
+func (r *Replayer) Height() uint64 {
+	return r.height
+}
+
+// Replay delivers and commits the block following the last replayed height.
+// Panics of the application, e.g. broken invariants, are returned as errors, the replayer can't be used after an error.
+func (r *Replayer) Replay() (result *ReplayedBlock, err error) {
+	height := r.height + 1
+
+	block := r.blockStore.LoadBlock(int64(height))
+	if block == nil {
+		return nil, fmt.Errorf("block %d is not stored", height)
+	}
+
+	lastCommitInfo, byzantineValidators, err := r.beginBlockValidatorInfo(block)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("application failed at height %d: %v", height, recovered)
+		}
+	}()
+
+	result = &ReplayedBlock{Height: height}
+
+	beginBlock := abciTypes.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              tmTypes.TM2PB.Header(&block.Header),
+		LastCommitInfo:      lastCommitInfo,
+		ByzantineValidators: byzantineValidators,
+	}
+	for _, app := range []*Blockchain{r.app, r.shadow} {
+		// Tendermint node is not available, so delta of blocks time is computed by the replayer
+		app.updateBlocksTimeDeltaFromStore(r.blockStore, height, 3)
+		app.BeginBlock(beginBlock)
+	}
+
+	if result.BeginBlock, err = r.changes(); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range block.Txs {
+		response := r.app.DeliverTx(abciTypes.RequestDeliverTx{Tx: tx})
+		if shadowResponse := r.shadow.DeliverTx(abciTypes.RequestDeliverTx{Tx: tx}); shadowResponse.Code != response.Code {
+			return nil, fmt.Errorf("recording of changes diverged at tx %X: code %d instead of %d", tx.Hash(), shadowResponse.Code, response.Code)
+		}
+
+		changes, err := r.changes()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Txs = append(result.Txs, ReplayedTx{
+			Hash:    tx.Hash(),
+			Code:    response.Code,
+			Log:     response.Log,
+			Changes: changes,
+		})
+	}
+
+	endBlock := abciTypes.RequestEndBlock{Height: int64(height)}
+	r.app.EndBlock(endBlock)
+	r.shadow.EndBlock(endBlock)
+
+	if result.EndBlock, err = r.changes(); err != nil {
+		return nil, err
+	}
+
+	result.AppHash = r.app.Commit().Data
+	r.shadow.Commit()
+	r.shadowTree.Changes()
+
+	if meta := r.blockStore.LoadBlockMeta(int64(height) + 1); meta != nil {
+		result.ExpectedAppHash = meta.Header.AppHash
+	}
+
+	r.height = height
+
+	return result, nil
+}
+
+// changes flushes the state of the shadow and returns changes made since the previous call
+func (r *Replayer) changes() ([]tree.Change, error) {
+	if err := r.shadow.stateDeliver.Flush(); err != nil {
+		return nil, err
+	}
+
+	return r.shadowTree.Changes(), nil
+}
+
+// beginBlockValidatorInfo builds votes of the last commit and evidence of the block the same way as Tendermint does
+func (r *Replayer) beginBlockValidatorInfo(block *tmTypes.Block) (abciTypes.LastCommitInfo, []abciTypes.Evidence, error) {
+	votes := make([]abciTypes.VoteInfo, block.LastCommit.Size())
+	if block.Height > 1 {
+		lastValidators, err := tmState.LoadValidators(r.tmStateDB, block.Height-1)
+		if err != nil {
+			return abciTypes.LastCommitInfo{}, nil, err
+		}
+
+		if len(lastValidators.Validators) != block.LastCommit.Size() {
+			return abciTypes.LastCommitInfo{}, nil, fmt.Errorf("commit size %d doesn't match validators count %d at height %d",
+				block.LastCommit.Size(), len(lastValidators.Validators), block.Height)
+		}
+
+		for i, val := range lastValidators.Validators {
+			votes[i] = abciTypes.VoteInfo{
+				Validator:       tmTypes.TM2PB.Validator(val),
+				SignedLastBlock: !block.LastCommit.Signatures[i].Absent(),
+			}
+		}
+	}
+
+	byzantineValidators := make([]abciTypes.Evidence, len(block.Evidence.Evidence))
+	for i, evidence := range block.Evidence.Evidence {
+		validators, err := tmState.LoadValidators(r.tmStateDB, evidence.Height())
+		if err != nil {
+			return abciTypes.LastCommitInfo{}, nil, err
+		}
+
+		byzantineValidators[i] = tmTypes.TM2PB.Evidence(evidence, validators, block.Time)
+	}
+
+	return abciTypes.LastCommitInfo{
+		Round: int32(block.LastCommit.Round),
+		Votes: votes,
+	}, byzantineValidators, nil
+}
@@ -0,0 +1,189 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/noah-blockchain/noah-go-node/tree"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/iavl"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+var cdc = amino.NewCodec()
+
+var ErrNotProven = errors.New("range is not proven")
+
+// Item is a key-value pair of the state tree
+type Item struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// RangeProof proves all state tree items in key range [Start, End) at given height.
+// Proof of a single key is an existence proof if Items contains the key and absence proof otherwise.
+//
+// Leaves contains proofs of consecutive tree leaves: the leaf preceding the range, the items and
+// the leaf following the range. Every leaf is proven separately, because IAVL range proofs omit keys
+// prefixed with the first leaf key, and state keys of accounts and candidates are such prefixes.
+type RangeProof struct {
+	Height uint64   `json:"height"`
+	Start  []byte   `json:"start"`
+	End    []byte   `json:"end"`
+	Items  []Item   `json:"items"`
+	Leaves [][]byte `json:"leaves"`
+}
+
+// NewRangeProof creates proof of items of given tree in range [start, end)
+func NewRangeProof(t *tree.ImmutableTree, start, end []byte) (*RangeProof, error) {
+	size := t.Size()
+	if size == 0 {
+		return nil, errors.New("state tree is empty")
+	}
+
+	first, _ := t.Get(start)
+	last, _ := t.Get(end)
+
+	rangeProof := &RangeProof{
+		Height: uint64(t.Version()),
+		Start:  start,
+		End:    end,
+	}
+
+	for index := first - 1; index <= last && index < size; index++ {
+		if index < 0 {
+			continue
+		}
+
+		key, value := t.GetByIndex(index)
+		_, proof, err := t.GetWithProof(key)
+		if err != nil {
+			return nil, err
+		}
+
+		encodedProof, err := cdc.MarshalBinaryBare(proof)
+		if err != nil {
+			return nil, err
+		}
+
+		rangeProof.Leaves = append(rangeProof.Leaves, encodedProof)
+		if index >= first && index < last {
+			rangeProof.Items = append(rangeProof.Items, Item{Key: key, Value: value})
+		}
+	}
+
+	return rangeProof, nil
+}
+
+// NewKeyProof creates existence or absence proof of given key
+func NewKeyProof(t *tree.ImmutableTree, key []byte) (*RangeProof, error) {
+	return NewRangeProof(t, key, keyEnd(key))
+}
+
+// Verify checks that proof items are all items of the range in the state tree with given root hash
+func (p *RangeProof) Verify(root []byte) error {
+	if p == nil {
+		return errors.New("proof is missing")
+	}
+
+	if len(p.Leaves) == 0 {
+		return ErrNotProven
+	}
+
+	leaves := make([]*iavl.RangeProof, len(p.Leaves))
+	for i, encodedLeaf := range p.Leaves {
+		leaf := &iavl.RangeProof{}
+		if err := cdc.UnmarshalBinaryBare(encodedLeaf, leaf); err != nil {
+			return err
+		}
+
+		if err := leaf.Verify(root); err != nil {
+			return err
+		}
+
+		if len(leaf.Leaves) != 1 {
+			return fmt.Errorf("proof of leaf %d contains %d leaves", i, len(leaf.Leaves))
+		}
+
+		if i > 0 && leaf.LeftIndex() != leaves[i-1].LeftIndex()+1 {
+			return fmt.Errorf("leaf %d is not adjacent to the previous one", i)
+		}
+
+		leaves[i] = leaf
+	}
+
+	// leaves are consecutive, so the range is covered if it is bounded by leaves outside of it or by the tree ends
+	first, last := leaves[0], leaves[len(leaves)-1]
+	if bytes.Compare(first.Leaves[0].Key, p.Start) >= 0 && first.LeftIndex() != 0 {
+		return ErrNotProven
+	}
+
+	if bytes.Compare(last.Leaves[0].Key, p.End) < 0 && last.LeftIndex() != treeSize(last)-1 {
+		return ErrNotProven
+	}
+
+	var inRange []*iavl.RangeProof
+	for _, leaf := range leaves {
+		if bytes.Compare(leaf.Leaves[0].Key, p.Start) >= 0 && bytes.Compare(leaf.Leaves[0].Key, p.End) < 0 {
+			inRange = append(inRange, leaf)
+		}
+	}
+
+	if len(inRange) != len(p.Items) {
+		return fmt.Errorf("proof contains %d items of range, but %d given", len(inRange), len(p.Items))
+	}
+
+	for i, item := range p.Items {
+		if err := inRange[i].VerifyItem(item.Key, item.Value); err != nil {
+			return fmt.Errorf("item %X is not proven: %s", item.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// Value returns proven value of given key, nil if the key is absent
+func (p *RangeProof) Value(key []byte) []byte {
+	for _, item := range p.Items {
+		if bytes.Equal(item.Key, key) {
+			return item.Value
+		}
+	}
+
+	return nil
+}
+
+// checkRange checks that proof covers given range of the state at height of trusted header.
+// App hash of the header at height H+1 is the state root hash at height H.
+func (p *RangeProof) checkRange(header *tmTypes.Header, start, end []byte) error {
+	if p == nil {
+		return errors.New("proof is missing")
+	}
+
+	if header.Height != int64(p.Height)+1 {
+		return fmt.Errorf("proof of height %d should be verified with header of height %d, got %d", p.Height, p.Height+1, header.Height)
+	}
+
+	if !bytes.Equal(p.Start, start) || !bytes.Equal(p.End, end) {
+		return fmt.Errorf("proof of range [%X, %X) instead of [%X, %X)", p.Start, p.End, start, end)
+	}
+
+	return p.Verify(header.AppHash)
+}
+
+// treeSize returns the number of leaves of the tree from verified proof, the root is the first node of the path
+func treeSize(proof *iavl.RangeProof) int64 {
+	if len(proof.LeftPath) == 0 {
+		return 1
+	}
+
+	return proof.LeftPath[0].Size
+}
+
+// keyEnd returns the smallest key greater than given key
+func keyEnd(key []byte) []byte {
+	end := make([]byte, len(key)+1)
+	copy(end, key)
+	return end
+}
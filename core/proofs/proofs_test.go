@@ -0,0 +1,213 @@
+package proofs
+
+import (
+	"math/big"
+	"testing"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	tmTypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+)
+
+func getCommittedState(t *testing.T) (*tree.ImmutableTree, *tmTypes.Header) {
+	s, err := state.NewState(0, db.NewMemDB(), eventsdb.NewEventsStore(db.NewMemDB()), 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := types.Address{1}
+	pubkey := types.Pubkey{1}
+
+	s.Accounts.AddBalance(address, types.GetBaseCoinID(), big.NewInt(100))
+	s.Accounts.AddBalance(address, types.CoinID(1), big.NewInt(200))
+	s.Accounts.AddBalance(types.Address{2}, types.GetBaseCoinID(), big.NewInt(300))
+	s.Accounts.SetNonce(address, 5)
+
+	s.Coins.Create(types.CoinID(1), types.StrToCoinSymbol("TEST"), "TEST", big.NewInt(1000), 10, big.NewInt(500), big.NewInt(10000), nil)
+
+	s.Candidates.Create(address, address, address, pubkey, 10)
+	s.Candidates.Delegate(address, pubkey, types.GetBaseCoinID(), big.NewInt(1000), big.NewInt(1000))
+	s.Candidates.RecalculateStakes(1)
+
+	hash, err := s.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateTree, err := s.Tree().GetImmutableAtHeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return stateTree, &tmTypes.Header{Height: 2, AppHash: hash}
+}
+
+func TestVerifyAddress(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+
+	proof, err := NewAddressProof(stateTree, types.Address{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := VerifyAddress(header, types.Address{1}, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Nonce != 5 || len(account.Balances) != 2 || account.Balances[types.CoinID(1)].Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("Wrong verified account: %v", account)
+	}
+
+	if _, err := VerifyAddress(header, types.Address{2}, proof); err == nil {
+		t.Fatal("Proof of another address is accepted")
+	}
+
+	proof.Balances.Items = proof.Balances.Items[1:]
+	if _, err := VerifyAddress(header, types.Address{1}, proof); err == nil {
+		t.Fatal("Proof with omitted balance is accepted")
+	}
+}
+
+func TestVerifyAddress_Absence(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+
+	proof, err := NewAddressProof(stateTree, types.Address{3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := VerifyAddress(header, types.Address{3}, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Nonce != 0 || len(account.Balances) != 0 {
+		t.Fatalf("Wrong verified account: %v", account)
+	}
+
+	proof.Balances.Items = append(proof.Balances.Items, Item{Key: proof.Balances.Start, Value: []byte{1}})
+	if _, err := VerifyAddress(header, types.Address{3}, proof); err == nil {
+		t.Fatal("Proof with forged balance is accepted")
+	}
+}
+
+func TestVerifyCandidate(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+
+	proof, err := NewCandidateProof(stateTree, types.Pubkey{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidate, err := VerifyCandidate(header, types.Pubkey{1}, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if candidate == nil || candidate.Commission != 10 || len(candidate.Stakes) != 1 || candidate.Stakes[0].Value.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Wrong verified candidate: %v", candidate)
+	}
+
+	if candidate.TotalStake.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Wrong total stake: %s", candidate.TotalStake)
+	}
+
+	header.AppHash = []byte{1, 2, 3}
+	if _, err := VerifyCandidate(header, types.Pubkey{1}, proof); err == nil {
+		t.Fatal("Proof is accepted with wrong app hash")
+	}
+}
+
+func TestVerifyCandidate_Absence(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+
+	proof, err := NewCandidateProof(stateTree, types.Pubkey{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if candidate, err := VerifyCandidate(header, types.Pubkey{2}, proof); err != nil || candidate != nil {
+		t.Fatalf("Absent candidate is not verified. Candidate %v, error %v", candidate, err)
+	}
+
+	if _, err := VerifyCandidate(header, types.Pubkey{1}, proof); err == nil {
+		t.Fatal("Proof of absent candidate is accepted for existing one")
+	}
+}
+
+func TestVerifyCoin(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+
+	proof, err := NewCoinProof(stateTree, types.CoinID(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coin, err := VerifyCoin(header, types.CoinID(1), proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coin == nil || coin.Crr != 10 || coin.Volume.Cmp(big.NewInt(1000)) != 0 || coin.Reserve.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("Wrong verified coin: %v", coin)
+	}
+
+	proof, err = NewCoinProof(stateTree, types.CoinID(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coin, err := VerifyCoin(header, types.CoinID(2), proof); err != nil || coin != nil {
+		t.Fatalf("Absent coin is not verified. Coin %v, error %v", coin, err)
+	}
+}
+
+func TestVerifyCoinBySymbol(t *testing.T) {
+	stateTree, header := getCommittedState(t)
+	symbol := types.StrToCoinSymbol("TEST")
+
+	proof, err := NewCoinSymbolProof(stateTree, symbol, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coin, err := VerifyCoinBySymbol(header, symbol, 0, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coin == nil || coin.Symbol != symbol || coin.Volume.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Wrong verified coin: %v", coin)
+	}
+
+	if _, err := VerifyCoinBySymbol(header, symbol, 1, proof); err == nil {
+		t.Fatal("Proof of coin is accepted for another version")
+	}
+
+	for _, absent := range []struct {
+		symbol  types.CoinSymbol
+		version types.CoinVersion
+	}{{symbol, 1}, {types.StrToCoinSymbol("ABSENT"), 0}} {
+		proof, err := NewCoinSymbolProof(stateTree, absent.symbol, absent.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if coin, err := VerifyCoinBySymbol(header, absent.symbol, absent.version, proof); err != nil || coin != nil {
+			t.Fatalf("Absent coin %s-%d is not verified. Coin %v, error %v", absent.symbol, absent.version, coin, err)
+		}
+	}
+
+	proof, err = NewCoinSymbolProof(stateTree, symbol, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := VerifyCoinBySymbol(header, symbol, 0, proof); err == nil {
+		t.Fatal("Proof of absent version is accepted for existing one")
+	}
+}
@@ -0,0 +1,411 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/noah-blockchain/noah-go-node/core/state/accounts"
+	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
+	"github.com/noah-blockchain/noah-go-node/core/state/coins"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// AddressProof proves account data and all balances of the address
+type AddressProof struct {
+	Account  *RangeProof `json:"account"`
+	Balances *RangeProof `json:"balances"`
+}
+
+// CoinProof proves coin model, volume and reserve of the coin. Proof of the coin requested by symbol
+// also proves the list of coins of the symbol and, if there is no coin of requested version, models of all of them.
+type CoinProof struct {
+	Coin   *RangeProof   `json:"coin"`
+	Info   *RangeProof   `json:"info"`
+	Symbol *RangeProof   `json:"symbol,omitempty"`
+	Others []*RangeProof `json:"others,omitempty"`
+}
+
+// CandidateProof proves candidate data, its total stake and all stakes.
+// Proof of absent candidate contains only the list of candidates.
+type CandidateProof struct {
+	Candidates *RangeProof `json:"candidates"`
+	TotalStake *RangeProof `json:"total_stake"`
+	Stakes     *RangeProof `json:"stakes"`
+}
+
+// Account is verified state of the address
+type Account struct {
+	Nonce        uint64
+	MultisigData accounts.Multisig
+	Balances     map[types.CoinID]*big.Int
+}
+
+// Coin is verified state of the coin
+type Coin struct {
+	Name      string
+	Crr       uint32
+	MaxSupply *big.Int
+	Version   types.CoinVersion
+	Symbol    types.CoinSymbol
+	Volume    *big.Int
+	Reserve   *big.Int
+}
+
+// Stake is verified stake of delegator
+type Stake struct {
+	Owner     types.Address
+	Coin      types.CoinID
+	Value     *big.Int
+	NoahValue *big.Int
+}
+
+// Candidate is verified state of the candidate
+type Candidate struct {
+	candidates.Candidate
+	TotalStake *big.Int
+	Stakes     []Stake
+}
+
+// NewAddressProof creates proof of the address state in given tree
+func NewAddressProof(t *tree.ImmutableTree, address types.Address) (*AddressProof, error) {
+	account, err := NewKeyProof(t, accounts.AccountPath(address))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := accounts.BalancesRange(address)
+	balances, err := NewRangeProof(t, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressProof{Account: account, Balances: balances}, nil
+}
+
+// NewCoinProof creates proof of the coin state in given tree
+func NewCoinProof(t *tree.ImmutableTree, id types.CoinID) (*CoinProof, error) {
+	coin, err := NewKeyProof(t, coins.CoinPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := NewKeyProof(t, coins.CoinInfoPath(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CoinProof{Coin: coin, Info: info}, nil
+}
+
+// NewCoinSymbolProof creates proof of the coin with given symbol and version in given tree
+func NewCoinSymbolProof(t *tree.ImmutableTree, symbol types.CoinSymbol, version types.CoinVersion) (*CoinProof, error) {
+	if symbol.IsBaseCoin() {
+		return NewCoinProof(t, types.GetBaseCoinID())
+	}
+
+	symbolPath := coins.SymbolCoinsPath(symbol)
+	symbolProof, err := NewKeyProof(t, symbolPath)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := decodeCoinIDs(symbolProof.Value(symbolPath))
+	if err != nil {
+		return nil, err
+	}
+
+	others := make([]*RangeProof, 0, len(ids))
+	for _, id := range ids {
+		coinPath := coins.CoinPath(id)
+		coinProof, err := NewKeyProof(t, coinPath)
+		if err != nil {
+			return nil, err
+		}
+
+		model, err := decodeCoinModel(coinProof.Value(coinPath))
+		if err != nil {
+			return nil, err
+		}
+
+		if model != nil && model.CVersion == version {
+			proof, err := NewCoinProof(t, id)
+			if err != nil {
+				return nil, err
+			}
+
+			proof.Symbol = symbolProof
+			return proof, nil
+		}
+
+		others = append(others, coinProof)
+	}
+
+	return &CoinProof{Symbol: symbolProof, Others: others}, nil
+}
+
+// NewCandidateProof creates proof of state of the candidate with given public key in given tree
+func NewCandidateProof(t *tree.ImmutableTree, pubKey types.Pubkey) (*CandidateProof, error) {
+	listPath := candidates.ListPath()
+	list, err := NewKeyProof(t, listPath)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := findCandidate(list.Value(listPath), pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return &CandidateProof{Candidates: list}, nil
+	}
+
+	totalStake, err := NewKeyProof(t, candidates.TotalStakePath(candidate.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := candidates.StakesRange(candidate.ID)
+	stakes, err := NewRangeProof(t, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CandidateProof{Candidates: list, TotalStake: totalStake, Stakes: stakes}, nil
+}
+
+// VerifyAddress verifies proof of the address state against trusted header
+func VerifyAddress(header *tmTypes.Header, address types.Address, proof *AddressProof) (*Account, error) {
+	if proof == nil {
+		return nil, errors.New("proof is missing")
+	}
+
+	accountPath := accounts.AccountPath(address)
+	if err := proof.Account.checkRange(header, accountPath, keyEnd(accountPath)); err != nil {
+		return nil, err
+	}
+
+	start, end := accounts.BalancesRange(address)
+	if err := proof.Balances.checkRange(header, start, end); err != nil {
+		return nil, err
+	}
+
+	account := &Account{Balances: map[types.CoinID]*big.Int{}}
+	if data := proof.Account.Value(accountPath); data != nil {
+		model := &accounts.Model{}
+		if err := rlp.DecodeBytes(data, model); err != nil {
+			return nil, err
+		}
+
+		account.Nonce = model.Nonce
+		account.MultisigData = model.MultisigData
+	}
+
+	for _, item := range proof.Balances.Items {
+		coin := types.BytesToCoinID(item.Key[len(start):])
+		account.Balances[coin] = big.NewInt(0).SetBytes(item.Value)
+	}
+
+	return account, nil
+}
+
+// VerifyCoin verifies proof of the coin state against trusted header. Returns nil if the coin does not exist.
+func VerifyCoin(header *tmTypes.Header, id types.CoinID, proof *CoinProof) (*Coin, error) {
+	if proof == nil {
+		return nil, errors.New("proof is missing")
+	}
+
+	coinPath, infoPath := coins.CoinPath(id), coins.CoinInfoPath(id)
+	if err := proof.Coin.checkRange(header, coinPath, keyEnd(coinPath)); err != nil {
+		return nil, err
+	}
+	if err := proof.Info.checkRange(header, infoPath, keyEnd(infoPath)); err != nil {
+		return nil, err
+	}
+
+	model, err := decodeCoinModel(proof.Coin.Value(coinPath))
+	if err != nil || model == nil {
+		return nil, err
+	}
+
+	coin := &Coin{
+		Name:      model.CName,
+		Crr:       model.CCrr,
+		MaxSupply: model.CMaxSupply,
+		Version:   model.CVersion,
+		Symbol:    model.CSymbol,
+		Volume:    big.NewInt(0),
+		Reserve:   big.NewInt(0),
+	}
+
+	if data := proof.Info.Value(infoPath); data != nil {
+		info := &coins.Info{}
+		if err := rlp.DecodeBytes(data, info); err != nil {
+			return nil, err
+		}
+
+		coin.Volume, coin.Reserve = info.Volume, info.Reserve
+	}
+
+	return coin, nil
+}
+
+// VerifyCoinBySymbol verifies proof of the coin with given symbol and version against trusted header.
+// Returns nil if the coin does not exist.
+func VerifyCoinBySymbol(header *tmTypes.Header, symbol types.CoinSymbol, version types.CoinVersion, proof *CoinProof) (*Coin, error) {
+	if proof == nil {
+		return nil, errors.New("proof is missing")
+	}
+
+	if symbol.IsBaseCoin() {
+		return VerifyCoin(header, types.GetBaseCoinID(), proof)
+	}
+
+	symbolPath := coins.SymbolCoinsPath(symbol)
+	if err := proof.Symbol.checkRange(header, symbolPath, keyEnd(symbolPath)); err != nil {
+		return nil, err
+	}
+
+	ids, err := decodeCoinIDs(proof.Symbol.Value(symbolPath))
+	if err != nil {
+		return nil, err
+	}
+
+	if proof.Coin != nil {
+		for _, id := range ids {
+			if !bytes.Equal(proof.Coin.Start, coins.CoinPath(id)) {
+				continue
+			}
+
+			coin, err := VerifyCoin(header, id, proof)
+			if err != nil {
+				return nil, err
+			}
+
+			if coin == nil || coin.Version != version {
+				return nil, fmt.Errorf("coin %d is not the coin of version %d", id, version)
+			}
+
+			return coin, nil
+		}
+
+		return nil, errors.New("proven coin is not in the list of coins of the symbol")
+	}
+
+	// there is no coin of the version if none of the coins of the symbol has it
+	if len(proof.Others) != len(ids) {
+		return nil, fmt.Errorf("proof contains %d coins of the symbol, but %d expected", len(proof.Others), len(ids))
+	}
+
+	for i, id := range ids {
+		coinPath := coins.CoinPath(id)
+		if err := proof.Others[i].checkRange(header, coinPath, keyEnd(coinPath)); err != nil {
+			return nil, err
+		}
+
+		model, err := decodeCoinModel(proof.Others[i].Value(coinPath))
+		if err != nil {
+			return nil, err
+		}
+
+		if model != nil && model.CVersion == version {
+			return nil, fmt.Errorf("coin %d of version %d is not proven", id, version)
+		}
+	}
+
+	return nil, nil
+}
+
+// VerifyCandidate verifies proof of the candidate state against trusted header. Returns nil if the candidate does not exist.
+func VerifyCandidate(header *tmTypes.Header, pubKey types.Pubkey, proof *CandidateProof) (*Candidate, error) {
+	if proof == nil {
+		return nil, errors.New("proof is missing")
+	}
+
+	listPath := candidates.ListPath()
+	if err := proof.Candidates.checkRange(header, listPath, keyEnd(listPath)); err != nil {
+		return nil, err
+	}
+
+	candidate, err := findCandidate(proof.Candidates.Value(listPath), pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, nil
+	}
+
+	totalStakePath := candidates.TotalStakePath(candidate.ID)
+	if err := proof.TotalStake.checkRange(header, totalStakePath, keyEnd(totalStakePath)); err != nil {
+		return nil, err
+	}
+
+	start, end := candidates.StakesRange(candidate.ID)
+	if err := proof.Stakes.checkRange(header, start, end); err != nil {
+		return nil, err
+	}
+
+	result := &Candidate{
+		Candidate:  *candidate,
+		TotalStake: big.NewInt(0).SetBytes(proof.TotalStake.Value(totalStakePath)),
+		Stakes:     make([]Stake, len(proof.Stakes.Items)),
+	}
+
+	for i, item := range proof.Stakes.Items {
+		if err := rlp.DecodeBytes(item.Value, &result.Stakes[i]); err != nil {
+			return nil, fmt.Errorf("can't decode stake %X: %s", item.Key, err)
+		}
+	}
+
+	return result, nil
+}
+
+func decodeCoinModel(data []byte) (*coins.Model, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	model := &coins.Model{}
+	if err := rlp.DecodeBytes(data, model); err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func decodeCoinIDs(data []byte) ([]types.CoinID, error) {
+	var ids []types.CoinID
+	if data == nil {
+		return ids, nil
+	}
+
+	if err := rlp.DecodeBytes(data, &ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// findCandidate returns candidate with given public key from encoded list of candidates, nil if it is absent
+func findCandidate(data []byte, pubKey types.Pubkey) (*candidates.Candidate, error) {
+	var list []*candidates.Candidate
+	if data != nil {
+		if err := rlp.DecodeBytes(data, &list); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range list {
+		if bytes.Equal(c.PubKey[:], pubKey[:]) {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -125,6 +125,38 @@ func (a *Accounts) getOrderedDirtyAccounts() []types.Address {
 	return keys
 }
 
+// ChangedBalance is a balance of the account set since the last commit
+type ChangedBalance struct {
+	Address types.Address
+	Coin    types.CoinID
+	Value   *big.Int
+}
+
+// ChangedBalances returns balances set since the last commit, zero values are returned for removed balances
+func (a *Accounts) ChangedBalances() []ChangedBalance {
+	var balances []ChangedBalance
+	for _, address := range a.getOrderedDirtyAccounts() {
+		account := a.getFromMap(address)
+		if !account.hasDirtyBalances() {
+			continue
+		}
+
+		for _, coin := range account.getOrderedCoins() {
+			if !account.isBalanceDirty(coin) {
+				continue
+			}
+
+			balances = append(balances, ChangedBalance{
+				Address: address,
+				Coin:    coin,
+				Value:   big.NewInt(0).Set(account.getBalance(coin)),
+			})
+		}
+	}
+
+	return balances
+}
+
 func (a *Accounts) AddBalance(address types.Address, coin types.CoinID, amount *big.Int) {
 	balance := a.GetBalance(address, coin)
 	a.SetBalance(address, coin, big.NewInt(0).Add(balance, amount))
@@ -316,53 +348,108 @@ func (a *Accounts) markDirty(addr types.Address) {
 }
 
 func (a *Accounts) Export(state *types.AppState) {
-	// todo: iterate range?
+	_ = a.ExportTo(func(account types.Account) error {
+		state.Accounts = append(state.Accounts, account)
+		return nil
+	})
+}
+
+// ExportTo calls fn for every account of the state in order of addresses. Accounts are read directly from the tree
+// and are not cached, so the whole state can be exported with constant memory.
+func (a *Accounts) ExportTo(fn func(account types.Account) error) error {
+	var (
+		current  *Model
+		balances map[types.CoinID]*big.Int
+		err      error
+	)
+
+	flush := func() {
+		if current != nil {
+			err = fn(exportAccount(current, balances))
+			current = nil
+		}
+	}
+
+	// keys of the account follow each other in order: model, balances, coins list
 	a.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] == mainPrefix {
-			addressPath := key[1:]
-			if len(addressPath) > types.AddressLength {
-				return false
+		if current != nil && (len(key) <= types.AddressLength+1 || !bytes.Equal(key[1:types.AddressLength+1], current.address[:])) {
+			if flush(); err != nil {
+				return true
 			}
+		}
 
-			address := types.BytesToAddress(addressPath)
-			account := a.get(address)
+		if key[0] != mainPrefix || len(key) < types.AddressLength+1 {
+			return false
+		}
 
-			var balance []types.Balance
-			for _, b := range a.GetBalances(account.address) {
-				balance = append(balance, types.Balance{
-					Coin:  uint64(b.Coin.ID),
-					Value: b.Value.String(),
-				})
+		switch {
+		case len(key) == types.AddressLength+1:
+			current = &Model{address: types.BytesToAddress(key[1:])}
+			if err = rlp.DecodeBytes(value, current); err != nil {
+				err = fmt.Errorf("failed to decode account at address %s: %s", current.address.String(), err)
+				return true
+			}
+			balances = map[types.CoinID]*big.Int{}
+		case current == nil:
+			return false
+		case key[types.AddressLength+1] == balancePrefix:
+			balances[types.BytesToCoinID(key[types.AddressLength+2:])] = big.NewInt(0).SetBytes(value)
+		case key[types.AddressLength+1] == coinsPrefix:
+			if err = rlp.DecodeBytes(value, &current.coins); err != nil {
+				err = fmt.Errorf("failed to decode coins list at address %s: %s", current.address.String(), err)
+				return true
 			}
+		}
 
-			// sort balances by coin symbol
-			sort.SliceStable(balance, func(i, j int) bool {
-				return bytes.Compare(types.CoinID(balance[i].Coin).Bytes(), types.CoinID(balance[j].Coin).Bytes()) == 1
-			})
+		return false
+	})
 
-			acc := types.Account{
-				Address: account.address,
-				Balance: balance,
-				Nonce:   account.Nonce,
-			}
+	if err != nil {
+		return err
+	}
 
-			if account.IsMultisig() {
-				var weights []uint64
-				for _, weight := range account.MultisigData.Weights {
-					weights = append(weights, uint64(weight))
-				}
-				acc.MultisigData = &types.Multisig{
-					Weights:   weights,
-					Threshold: uint64(account.MultisigData.Threshold),
-					Addresses: account.MultisigData.Addresses,
-				}
-			}
+	flush()
+	return err
+}
 
-			state.Accounts = append(state.Accounts, acc)
+func exportAccount(account *Model, balances map[types.CoinID]*big.Int) types.Account {
+	var balance []types.Balance
+	for _, coin := range account.coins {
+		value := balances[coin]
+		if value == nil {
+			value = big.NewInt(0)
 		}
 
-		return false
+		balance = append(balance, types.Balance{
+			Coin:  uint64(coin),
+			Value: value.String(),
+		})
+	}
+
+	// sort balances by coin symbol
+	sort.SliceStable(balance, func(i, j int) bool {
+		return bytes.Compare(types.CoinID(balance[i].Coin).Bytes(), types.CoinID(balance[j].Coin).Bytes()) == 1
 	})
+
+	acc := types.Account{
+		Address: account.address,
+		Balance: balance,
+		Nonce:   account.Nonce,
+	}
+
+	if account.IsMultisig() {
+		var weights []uint64
+		for _, weight := range account.MultisigData.Weights {
+			weights = append(weights, uint64(weight))
+		}
+		acc.MultisigData = &types.Multisig{
+			Weights:   weights,
+			Threshold: uint64(account.MultisigData.Threshold),
+			Addresses: account.MultisigData.Addresses,
+		}
+	}
+
+	return acc
 }
 
 func (a *Accounts) GetAccount(address types.Address) *Model {
@@ -382,3 +469,13 @@ func (a *Accounts) setToMap(address types.Address, model *Model) {
 
 	a.list[address] = model
 }
+
+// AccountPath returns the state tree key of account model containing nonce and multisig data
+func AccountPath(address types.Address) []byte {
+	return append([]byte{mainPrefix}, address[:]...)
+}
+
+// BalancesRange returns the state tree key range [start, end) containing all balances of the account
+func BalancesRange(address types.Address) (start, end []byte) {
+	return append(AccountPath(address), balancePrefix), append(AccountPath(address), balancePrefix+1)
+}
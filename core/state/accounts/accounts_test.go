@@ -129,6 +129,41 @@ func TestAccounts_SetBalance_0(t *testing.T) {
 	}
 }
 
+func TestAccounts_ChangedBalances(t *testing.T) {
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	b := bus.NewBus()
+	b.SetChecker(checker.NewChecker(b))
+	accounts, err := NewAccounts(b, mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accounts.SetBalance([20]byte{4}, 0, big.NewInt(100))
+	accounts.SetBalance([20]byte{4}, 1, big.NewInt(200))
+	err = accounts.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accounts.SetBalance([20]byte{4}, 1, big.NewInt(0))
+	accounts.SetNonce([20]byte{5}, 1)
+
+	balances := accounts.ChangedBalances()
+	if len(balances) != 1 {
+		t.Fatalf("count of changed balances is %d, not 1", len(balances))
+	}
+	if balances[0].Address != [20]byte{4} || balances[0].Coin != 1 || balances[0].Value.String() != "0" {
+		t.Fatalf("changed balance is %v", balances[0])
+	}
+
+	err = accounts.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts.ChangedBalances()) != 0 {
+		t.Fatal("changed balances are not reset by commit")
+	}
+}
+
 func TestAccounts_GetBalances(t *testing.T) {
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
 	b := bus.NewBus()
@@ -12,6 +12,7 @@ type Bus struct {
 	waitlist    WaitList
 	events      eventsdb.IEventsDB
 	checker     Checker
+	coinHistory CoinHistory
 }
 
 func NewBus() *Bus {
@@ -89,3 +90,11 @@ func (b *Bus) SetChecker(checker Checker) {
 func (b *Bus) Checker() Checker {
 	return b.checker
 }
+
+func (b *Bus) SetCoinHistory(coinHistory CoinHistory) {
+	b.coinHistory = coinHistory
+}
+
+func (b *Bus) CoinHistory() CoinHistory {
+	return b.coinHistory
+}
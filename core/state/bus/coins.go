@@ -12,6 +12,11 @@ type Coins interface {
 	SubCoinReserve(types.CoinID, *big.Int)
 }
 
+// CoinHistory records volume and reserve of coins changed by the block, nil if history is not recorded
+type CoinHistory interface {
+	AddCoin(id types.CoinID, volume *big.Int, reserve *big.Int, crr uint32)
+}
+
 type Coin struct {
 	ID      types.CoinID
 	Name    string
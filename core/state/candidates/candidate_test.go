@@ -13,6 +13,7 @@ import (
 	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/noah-blockchain/noah-go-node/helpers"
 	"github.com/noah-blockchain/noah-go-node/tree"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	db "github.com/tendermint/tm-db"
 	"math/big"
@@ -300,6 +301,85 @@ func TestCandidates_Commit_edit(t *testing.T) {
 
 }
 
+func TestCandidates_Commit_commissionUpdate(t *testing.T) {
+	defer upgrades.SetSchedule(types.CurrentChainID, upgrades.Schedule())
+	upgrades.SetSchedule(types.CurrentChainID, []upgrades.Upgrade{
+		{Name: upgrades.CommissionUpdate, Supported: true},
+	})
+
+	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
+	candidates, err := NewCandidates(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	candidates.Create([20]byte{1}, [20]byte{2}, [20]byte{3}, [32]byte{4}, 10)
+	candidates.ScheduleCommissionUpdate([32]byte{4}, 20, 100)
+
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewCandidates(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.LoadCandidates()
+
+	update := loaded.GetCommissionUpdate([32]byte{4})
+	if update == nil {
+		t.Fatal("commission update not found")
+	}
+
+	if update.Commission != 20 || update.Height != 100+CommissionUpdatePeriod {
+		t.Fatalf("commission update %d at %d", update.Commission, update.Height)
+	}
+
+	candidates.ApplyCommissionUpdates(100 + CommissionUpdatePeriod - 1)
+	if candidates.GetCandidate([32]byte{4}).Commission != 10 {
+		t.Fatal("commission is changed before scheduled height")
+	}
+
+	candidates.ApplyCommissionUpdates(100 + CommissionUpdatePeriod)
+	if candidates.GetCandidate([32]byte{4}).Commission != 20 {
+		t.Fatal("commission is not changed")
+	}
+
+	if candidates.GetCommissionUpdate([32]byte{4}) != nil {
+		t.Fatal("commission update is not removed")
+	}
+
+	err = candidates.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = mutableTree.SaveVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err = NewCandidates(bus.NewBus(), mutableTree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.LoadCandidates()
+
+	if loaded.GetCommissionUpdate([32]byte{4}) != nil {
+		t.Fatal("commission update is not removed from db")
+	}
+
+	if loaded.GetCandidate([32]byte{4}).Commission != 20 {
+		t.Fatal("commission is not saved")
+	}
+}
+
 func TestCandidates_Commit_createOneCandidateWithID(t *testing.T) {
 	mutableTree, _ := tree.NewMutableTree(0, db.NewMemDB(), 1024)
 	candidates, err := NewCandidates(bus.NewBus(), mutableTree)
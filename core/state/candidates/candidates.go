@@ -11,6 +11,7 @@ import (
 	"github.com/noah-blockchain/noah-go-node/helpers"
 	"github.com/noah-blockchain/noah-go-node/rlp"
 	"github.com/noah-blockchain/noah-go-node/tree"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 
 	"math/big"
 	"sort"
@@ -24,6 +25,9 @@ const (
 
 	UnbondPeriod              = 518400
 	MaxDelegatorsPerCandidate = 1000
+
+	CommissionUpdatePeriod   = 120960
+	MaxCommissionUpdateDelta = 10
 )
 
 const (
@@ -34,6 +38,7 @@ const (
 	stakesPrefix     = 's'
 	totalStakePrefix = 't'
 	updatesPrefix    = 'u'
+	commissionPrefix = 'm'
 )
 
 var (
@@ -59,6 +64,7 @@ type RCandidates interface {
 	LoadStakes()
 	GetCandidates() []*Candidate
 	GetStakes(pubkey types.Pubkey) []*stake
+	GetCommissionUpdate(pubkey types.Pubkey) *CommissionUpdate
 }
 
 // Candidates struct is a store of Candidates state
@@ -210,6 +216,25 @@ func (c *Candidates) Commit() error {
 			c.iavl.Set(path, data)
 			candidate.isUpdatesDirty = false
 		}
+
+		if candidate.isCommissionUpdateDirty {
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, commissionPrefix)
+			candidate.isCommissionUpdateDirty = false
+
+			if candidate.commissionUpdate == nil {
+				c.iavl.Remove(path)
+				continue
+			}
+
+			data, err := rlp.EncodeToBytes(candidate.commissionUpdate)
+			if err != nil {
+				return fmt.Errorf("can't encode candidate commission update: %v", err)
+			}
+
+			c.iavl.Set(path, data)
+		}
 	}
 
 	return nil
@@ -518,6 +543,46 @@ func (c *Candidates) Edit(pubkey types.Pubkey, rewardAddress types.Address, owne
 	candidate.setControl(controlAddress)
 }
 
+// ScheduleCommissionUpdate schedules change of candidate's commission, which will be applied after CommissionUpdatePeriod
+func (c *Candidates) ScheduleCommissionUpdate(pubkey types.Pubkey, commission uint32, height uint64) {
+	c.SetCommissionUpdate(pubkey, commission, height+CommissionUpdatePeriod)
+}
+
+// SetCommissionUpdate sets scheduled commission change of a candidate. Used in Import.
+func (c *Candidates) SetCommissionUpdate(pubkey types.Pubkey, commission uint32, height uint64) {
+	c.getFromMap(pubkey).setCommissionUpdate(&CommissionUpdate{
+		Commission: commission,
+		Height:     height,
+	})
+}
+
+// GetCommissionUpdate returns scheduled commission change of a candidate, nil if there is no such
+func (c *Candidates) GetCommissionUpdate(pubkey types.Pubkey) *CommissionUpdate {
+	candidate := c.getFromMap(pubkey)
+	if candidate == nil {
+		return nil
+	}
+
+	return candidate.GetCommissionUpdate()
+}
+
+// ApplyCommissionUpdates sets new commissions of candidates whose scheduled changes are due at given height
+func (c *Candidates) ApplyCommissionUpdates(height uint64) {
+	if !upgrades.IsActive(upgrades.CommissionUpdate, height) {
+		return
+	}
+
+	for _, candidate := range c.GetCandidates() {
+		update := candidate.commissionUpdate
+		if update == nil || update.Height > height {
+			continue
+		}
+
+		candidate.setCommission(update.Commission)
+		candidate.setCommissionUpdate(nil)
+	}
+}
+
 // SetOnline sets candidate status to CandidateStatusOnline
 func (c *Candidates) SetOnline(pubkey types.Pubkey) {
 	c.getFromMap(pubkey).setStatus(CandidateStatusOnline)
@@ -690,6 +755,18 @@ func (c *Candidates) loadCandidatesList() (maxID uint32) {
 				candidate.totalNoahStake = big.NewInt(0).SetBytes(enc)
 			}
 
+			// load scheduled commission update
+			path = append([]byte{mainPrefix}, candidate.idBytes()...)
+			path = append(path, commissionPrefix)
+			_, enc = c.iavl.Get(path)
+			if len(enc) != 0 {
+				update := &CommissionUpdate{}
+				if err := rlp.DecodeBytes(enc, update); err != nil {
+					panic(fmt.Sprintf("failed to decode candidate commission update: %s", err))
+				}
+				candidate.commissionUpdate = update
+			}
+
 			candidate.setTmAddress()
 			c.setToMap(candidate.PubKey, candidate)
 		}
@@ -854,53 +931,117 @@ func (c *Candidates) SetStakes(pubkey types.Pubkey, stakes []types.Stake, update
 
 // Export exports all data to the given state
 func (c *Candidates) Export(state *types.AppState) {
+	state.Candidates = make([]types.Candidate, 0, c.Count())
+	_ = c.ExportTo(func(candidate types.Candidate) error {
+		state.Candidates = append(state.Candidates, candidate)
+		return nil
+	})
+
+	_ = c.ExportBlockListTo(func(pubkey types.Pubkey) error {
+		state.BlockListCandidates = append(state.BlockListCandidates, pubkey)
+		return nil
+	})
+}
+
+// ExportTo calls fn for every candidate of the state with its stakes. Stakes are read from the tree candidate by candidate
+// and are not kept in memory, so candidates with all their stakes are never loaded at once.
+func (c *Candidates) ExportTo(fn func(candidate types.Candidate) error) error {
 	c.LoadCandidatesDeliver()
-	c.LoadStakes()
 
-	candidates := c.GetCandidates()
-	state.Candidates = make([]types.Candidate, 0, len(candidates))
-	for _, candidate := range candidates {
-		candidateStakes := c.GetStakes(candidate.PubKey)
-		stakes := make([]types.Stake, len(candidateStakes))
-		for i, s := range candidateStakes {
-			stakes[i] = types.Stake{
-				Owner:    s.Owner,
-				Coin:     uint64(s.Coin),
-				Value:    s.Value.String(),
-				NoahValue: s.NoahValue.String(),
+	for _, candidate := range c.GetCandidates() {
+		stakes := make([]types.Stake, 0)
+		for index := 0; index < MaxDelegatorsPerCandidate; index++ {
+			path := []byte{mainPrefix}
+			path = append(path, candidate.idBytes()...)
+			path = append(path, stakesPrefix)
+			path = append(path, []byte(fmt.Sprintf("%d", index))...)
+			_, enc := c.iavl.Get(path)
+			if len(enc) == 0 {
+				continue
 			}
+
+			s := &stake{}
+			if err := rlp.DecodeBytes(enc, s); err != nil {
+				return fmt.Errorf("failed to decode stake: %s", err)
+			}
+
+			stakes = append(stakes, exportStake(s))
 		}
 
-		updates := make([]types.Stake, len(candidate.updates))
-		for i, u := range candidate.updates {
-			updates[i] = types.Stake{
-				Owner:    u.Owner,
-				Coin:     uint64(u.Coin),
-				Value:    u.Value.String(),
-				NoahValue: u.NoahValue.String(),
+		path := []byte{mainPrefix}
+		path = append(path, candidate.idBytes()...)
+		path = append(path, updatesPrefix)
+		_, enc := c.iavl.Get(path)
+
+		updates := make([]types.Stake, 0)
+		if len(enc) != 0 {
+			var list []*stake
+			if err := rlp.DecodeBytes(enc, &list); err != nil {
+				return fmt.Errorf("failed to decode updates: %s", err)
+			}
+
+			for _, u := range list {
+				updates = append(updates, exportStake(u))
 			}
 		}
 
-		state.Candidates = append(state.Candidates, types.Candidate{
-			ID:             uint64(candidate.ID),
-			RewardAddress:  candidate.RewardAddress,
-			OwnerAddress:   candidate.OwnerAddress,
-			ControlAddress: candidate.ControlAddress,
-			totalNoahStake:  candidate.GetTotalNoahStake().String(),
-			PubKey:         candidate.PubKey,
-			Commission:     uint64(candidate.Commission),
-			Status:         uint64(candidate.Status),
-			Updates:        updates,
-			Stakes:         stakes,
+		var commissionUpdate *types.CommissionUpdate
+		if update := candidate.GetCommissionUpdate(); update != nil {
+			commissionUpdate = &types.CommissionUpdate{
+				Commission: uint64(update.Commission),
+				Height:     update.Height,
+			}
+		}
+
+		err := fn(types.Candidate{
+			ID:               uint64(candidate.ID),
+			RewardAddress:    candidate.RewardAddress,
+			OwnerAddress:     candidate.OwnerAddress,
+			ControlAddress:   candidate.ControlAddress,
+			TotalNoahStake:   c.GetTotalStake(candidate.PubKey).String(),
+			PubKey:           candidate.PubKey,
+			Commission:       uint64(candidate.Commission),
+			CommissionUpdate: commissionUpdate,
+			Status:           uint64(candidate.Status),
+			Updates:          updates,
+			Stakes:           stakes,
 		})
+		if err != nil {
+			return err
+		}
 	}
 
+	return nil
+}
+
+// ExportBlockListTo calls fn for every blocked public key of the state
+func (c *Candidates) ExportBlockListTo(fn func(pubkey types.Pubkey) error) error {
+	c.LoadCandidatesDeliver()
+
+	blockList := make([]types.Pubkey, 0, len(c.blockList))
 	for pubkey := range c.blockList {
-		state.BlockListCandidates = append(state.BlockListCandidates, pubkey)
+		blockList = append(blockList, pubkey)
 	}
-	sort.SliceStable(state.BlockListCandidates, func(i, j int) bool {
-		return bytes.Compare(state.BlockListCandidates[i].Bytes(), state.BlockListCandidates[j].Bytes()) == 1
+	sort.SliceStable(blockList, func(i, j int) bool {
+		return bytes.Compare(blockList[i].Bytes(), blockList[j].Bytes()) == 1
 	})
+
+	for _, pubkey := range blockList {
+		if err := fn(pubkey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func exportStake(s *stake) types.Stake {
+	return types.Stake{
+		Owner:     s.Owner,
+		Coin:      uint64(s.Coin),
+		Value:     s.Value.String(),
+		NoahValue: s.NoahValue.String(),
+	}
 }
 
 func (c *Candidates) getOrderedCandidates() []types.Pubkey {
@@ -1115,3 +1256,22 @@ func (c *Candidates) maxIDBytes() []byte {
 	binary.LittleEndian.PutUint32(bs, c.maxID)
 	return bs
 }
+
+// ListPath returns the state tree key of the list of all candidates
+func ListPath() []byte {
+	return []byte{mainPrefix}
+}
+
+// TotalStakePath returns the state tree key of total stake of candidate with given ID
+func TotalStakePath(id uint32) []byte {
+	return append(candidatePath(id), totalStakePrefix)
+}
+
+// StakesRange returns the state tree key range [start, end) containing all stakes of candidate with given ID
+func StakesRange(id uint32) (start, end []byte) {
+	return append(candidatePath(id), stakesPrefix), append(candidatePath(id), stakesPrefix+1)
+}
+
+func candidatePath(id uint32) []byte {
+	return append([]byte{mainPrefix}, (&Candidate{ID: id}).idBytes()...)
+}
@@ -29,10 +29,19 @@ type Candidate struct {
 	updates       []*stake
 	tmAddress     *types.TmAddress
 
-	isDirty           bool
-	isTotalStakeDirty bool
-	isUpdatesDirty    bool
-	dirtyStakes       [MaxDelegatorsPerCandidate]bool
+	commissionUpdate *CommissionUpdate
+
+	isDirty                 bool
+	isTotalStakeDirty       bool
+	isUpdatesDirty          bool
+	isCommissionUpdateDirty bool
+	dirtyStakes             [MaxDelegatorsPerCandidate]bool
+}
+
+// CommissionUpdate represents scheduled change of candidate's commission
+type CommissionUpdate struct {
+	Commission uint32
+	Height     uint64
 }
 
 func (candidate *Candidate) idBytes() []byte {
@@ -61,6 +70,26 @@ func (candidate *Candidate) setControl(address types.Address) {
 	candidate.ControlAddress = address
 }
 
+func (candidate *Candidate) setCommission(commission uint32) {
+	candidate.isDirty = true
+	candidate.Commission = commission
+}
+
+func (candidate *Candidate) setCommissionUpdate(update *CommissionUpdate) {
+	candidate.isCommissionUpdateDirty = true
+	candidate.commissionUpdate = update
+}
+
+// GetCommissionUpdate returns scheduled commission change of a candidate, nil if there is no such
+func (candidate *Candidate) GetCommissionUpdate() *CommissionUpdate {
+	if candidate.commissionUpdate == nil {
+		return nil
+	}
+
+	update := *candidate.commissionUpdate
+	return &update
+}
+
 func (candidate *Candidate) setPublicKey(pubKey types.Pubkey) {
 	candidate.isDirty = true
 	candidate.PubKey = pubKey
@@ -6,27 +6,35 @@ import (
 	"github.com/noah-blockchain/noah-go-node/core/check"
 	"github.com/noah-blockchain/noah-go-node/core/types"
 	"github.com/noah-blockchain/noah-go-node/tree"
+	"math/big"
 	"sort"
 	"sync"
 )
 
-const mainPrefix = byte('t')
+const (
+	mainPrefix      = byte('t')
+	remainingPrefix = byte('r')
+)
 
 type RChecks interface {
 	Export(state *types.AppState)
 	IsCheckUsed(check *check.Check) bool
+	RemainingValue(check *check.Check) *big.Int
 }
 
 type Checks struct {
 	usedChecks map[types.Hash]struct{}
 
+	// remaining values of partially redeemed checks
+	remaining map[types.Hash]*big.Int
+
 	iavl tree.MTree
 
 	lock sync.RWMutex
 }
 
 func NewChecks(iavl tree.MTree) (*Checks, error) {
-	return &Checks{iavl: iavl, usedChecks: map[types.Hash]struct{}{}}, nil
+	return &Checks{iavl: iavl, usedChecks: map[types.Hash]struct{}{}, remaining: map[types.Hash]*big.Int{}}, nil
 }
 
 func (c *Checks) Commit() error {
@@ -39,6 +47,15 @@ func (c *Checks) Commit() error {
 		c.iavl.Set(trieHash, []byte{0x1})
 	}
 
+	for _, hash := range c.getOrderedRemaining() {
+		c.lock.Lock()
+		value := c.remaining[hash]
+		delete(c.remaining, hash)
+		c.lock.Unlock()
+
+		c.iavl.Set(append([]byte{remainingPrefix}, hash.Bytes()...), value.Bytes())
+	}
+
 	return nil
 }
 
@@ -55,6 +72,34 @@ func (c *Checks) IsCheckUsed(check *check.Check) bool {
 	return len(data) != 0
 }
 
+// RemainingValue returns value of the check which is not redeemed yet
+func (c *Checks) RemainingValue(check *check.Check) *big.Int {
+	hash := check.Hash()
+
+	c.lock.RLock()
+	value, has := c.remaining[hash]
+	c.lock.RUnlock()
+
+	if has {
+		return big.NewInt(0).Set(value)
+	}
+
+	_, data := c.iavl.Get(append([]byte{remainingPrefix}, hash.Bytes()...))
+	if data == nil {
+		return big.NewInt(0).Set(check.Value)
+	}
+
+	return big.NewInt(0).SetBytes(data)
+}
+
+// SetRemainingValue sets value of partially redeemed check which is not redeemed yet
+func (c *Checks) SetRemainingValue(hash types.Hash, value *big.Int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.remaining[hash] = big.NewInt(0).Set(value)
+}
+
 func (c *Checks) UseCheck(check *check.Check) {
 	c.UseCheckHash(check.Hash())
 }
@@ -67,16 +112,42 @@ func (c *Checks) UseCheckHash(hash types.Hash) {
 }
 
 func (c *Checks) Export(state *types.AppState) {
-	// todo: iterate range?
-	c.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] == mainPrefix {
-			state.UsedChecks = append(state.UsedChecks, types.UsedCheck(fmt.Sprintf("%x", key[1:])))
-		}
+	_ = c.ExportUsedTo(func(check types.UsedCheck) error {
+		state.UsedChecks = append(state.UsedChecks, check)
+		return nil
+	})
 
-		return false
+	_ = c.ExportPartialTo(func(check types.PartialCheck) error {
+		state.PartialChecks = append(state.PartialChecks, check)
+		return nil
 	})
 }
 
+// ExportUsedTo calls fn for every used check of the state in order of hashes
+func (c *Checks) ExportUsedTo(fn func(check types.UsedCheck) error) error {
+	var err error
+	c.iavl.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		err = fn(types.UsedCheck(fmt.Sprintf("%x", key[1:])))
+		return err != nil
+	})
+
+	return err
+}
+
+// ExportPartialTo calls fn for every partially redeemed check of the state in order of hashes
+func (c *Checks) ExportPartialTo(fn func(check types.PartialCheck) error) error {
+	var err error
+	c.iavl.IterateRange([]byte{remainingPrefix}, []byte{remainingPrefix + 1}, true, func(key []byte, value []byte) bool {
+		err = fn(types.PartialCheck{
+			Hash:  fmt.Sprintf("%x", key[1:]),
+			Value: big.NewInt(0).SetBytes(value).String(),
+		})
+		return err != nil
+	})
+
+	return err
+}
+
 func (c *Checks) getOrderedHashes() []types.Hash {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
@@ -92,3 +163,19 @@ func (c *Checks) getOrderedHashes() []types.Hash {
 
 	return keys
 }
+
+func (c *Checks) getOrderedRemaining() []types.Hash {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var keys []types.Hash
+	for hash := range c.remaining {
+		keys = append(keys, hash)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].Bytes(), keys[j].Bytes()) == 1
+	})
+
+	return keys
+}
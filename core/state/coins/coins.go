@@ -131,6 +131,10 @@ func (c *Coins) Commit() error {
 
 			c.iavl.Set(getCoinInfoPath(id), data)
 			coin.info.isDirty = false
+
+			if history := c.bus.CoinHistory(); history != nil {
+				history.AddCoin(id, coin.Volume(), coin.Reserve(), coin.Crr())
+			}
 		}
 
 		if coin.IsSymbolInfoDirty() {
@@ -332,6 +336,14 @@ func (c *Coins) get(id types.CoinID) *Model {
 		return nil
 	}
 
+	coin := c.load(id, enc)
+	c.setToMap(id, coin)
+
+	return coin
+}
+
+// load decodes coin model and loads its info from the tree, the coin is not cached
+func (c *Coins) load(id types.CoinID, enc []byte) *Model {
 	coin := &Model{}
 	if err := rlp.DecodeBytes(enc, coin); err != nil {
 		panic(fmt.Sprintf("failed to decode coin at %d: %s", id, err))
@@ -351,8 +363,6 @@ func (c *Coins) get(id types.CoinID) *Model {
 		coin.info = &info
 	}
 
-	c.setToMap(id, coin)
-
 	return coin
 }
 
@@ -416,40 +426,53 @@ func (c *Coins) getOrderedDirtyCoins() []types.CoinID {
 }
 
 func (c *Coins) Export(state *types.AppState) {
-	c.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] == mainPrefix {
-			if len(key) > 5 {
-				return false
-			}
+	_ = c.ExportTo(func(coin types.Coin) error {
+		state.Coins = append(state.Coins, coin)
+		return nil
+	})
 
-			coinID := types.BytesToCoinID(key[1:])
-			coin := c.get(coinID)
+	sort.Slice(state.Coins[:], func(i, j int) bool {
+		return helpers.StringToBigInt(state.Coins[i].Reserve).Cmp(helpers.StringToBigInt(state.Coins[j].Reserve)) == 1
+	})
+}
 
-			owner := &types.Address{}
-			info := c.getSymbolInfo(coin.Symbol())
-			if info != nil {
-				owner = info.OwnerAddress()
-			}
+// ExportTo calls fn for every coin of the state in order of IDs. Coins not loaded before are read from the tree
+// and are not cached.
+func (c *Coins) ExportTo(fn func(coin types.Coin) error) error {
+	var err error
+	c.iavl.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		// coin infos and symbols are stored under the same prefix
+		if len(key) != 5 {
+			return false
+		}
 
-			state.Coins = append(state.Coins, types.Coin{
-				ID:           uint64(coin.ID()),
-				Name:         coin.Name(),
-				Symbol:       coin.Symbol(),
-				Volume:       coin.Volume().String(),
-				Crr:          uint64(coin.Crr()),
-				Reserve:      coin.Reserve().String(),
-				MaxSupply:    coin.MaxSupply().String(),
-				Version:      uint64(coin.Version()),
-				OwnerAddress: owner,
-			})
+		coinID := types.BytesToCoinID(key[1:])
+		coin := c.getFromMap(coinID)
+		if coin == nil {
+			coin = c.load(coinID, value)
 		}
 
-		return false
-	})
+		owner := &types.Address{}
+		if info := c.getSymbolInfo(coin.Symbol()); info != nil {
+			owner = info.OwnerAddress()
+		}
 
-	sort.Slice(state.Coins[:], func(i, j int) bool {
-		return helpers.StringToBigInt(state.Coins[i].Reserve).Cmp(helpers.StringToBigInt(state.Coins[j].Reserve)) == 1
+		err = fn(types.Coin{
+			ID:           uint64(coin.ID()),
+			Name:         coin.Name(),
+			Symbol:       coin.Symbol(),
+			Volume:       coin.Volume().String(),
+			Crr:          uint64(coin.Crr()),
+			Reserve:      coin.Reserve().String(),
+			MaxSupply:    coin.MaxSupply().String(),
+			Version:      uint64(coin.Version()),
+			OwnerAddress: owner,
+		})
+
+		return err != nil
 	})
+
+	return err
 }
 
 func (c *Coins) getFromMap(id types.CoinID) *Model {
@@ -514,3 +537,18 @@ func getCoinPath(id types.CoinID) []byte {
 func getCoinInfoPath(id types.CoinID) []byte {
 	return append(getCoinPath(id), infoPrefix)
 }
+
+// CoinPath returns the state tree key of coin model
+func CoinPath(id types.CoinID) []byte {
+	return getCoinPath(id)
+}
+
+// CoinInfoPath returns the state tree key of coin volume and reserve
+func CoinInfoPath(id types.CoinID) []byte {
+	return getCoinInfoPath(id)
+}
+
+// SymbolCoinsPath returns the state tree key of the list of IDs of all coins with given symbol
+func SymbolCoinsPath(symbol types.CoinSymbol) []byte {
+	return getSymbolCoinsPath(symbol)
+}
@@ -20,6 +20,7 @@ const mainPrefix = byte('f')
 type RFrozenFunds interface {
 	Export(state *types.AppState, height uint64)
 	GetFrozenFunds(height uint64) *Model
+	IterateFrozenFunds(fromHeight, toHeight uint64, fn func(*Model) bool)
 }
 
 type FrozenFunds struct {
@@ -74,6 +75,27 @@ func (f *FrozenFunds) GetFrozenFunds(height uint64) *Model {
 	return f.get(height)
 }
 
+// IterateFrozenFunds calls fn for committed frozen funds of heights from fromHeight to toHeight in ascending order
+// until fn returns true. Only heights having frozen funds are read.
+func (f *FrozenFunds) IterateFrozenFunds(fromHeight, toHeight uint64, fn func(*Model) bool) {
+	f.iavl.IterateRange(getPath(fromHeight), getPath(toHeight+1), true, func(key []byte, value []byte) bool {
+		height := binary.BigEndian.Uint64(key[1:])
+
+		ff := f.getFromMap(height)
+		if ff == nil {
+			ff = &Model{}
+			if err := rlp.DecodeBytes(value, ff); err != nil {
+				panic(fmt.Sprintf("failed to decode frozen funds at height %d: %s", height, err))
+			}
+
+			ff.height = height
+			ff.markDirty = f.markDirty
+		}
+
+		return fn(ff)
+	})
+}
+
 func (f *FrozenFunds) PunishFrozenFundsWithID(fromHeight uint64, toHeight uint64, candidateID uint32) {
 	for cBlock := fromHeight; cBlock <= toHeight; cBlock++ {
 		ff := f.get(cBlock)
@@ -194,23 +216,34 @@ func (f *FrozenFunds) Delete(height uint64) {
 }
 
 func (f *FrozenFunds) Export(state *types.AppState, height uint64) {
-	for i := height; i <= height+candidates.UnbondPeriod; i++ {
-		frozenFunds := f.get(i)
-		if frozenFunds == nil {
-			continue
-		}
+	_ = f.ExportTo(height, func(frozenFund types.FrozenFund) error {
+		state.FrozenFunds = append(state.FrozenFunds, frozenFund)
+		return nil
+	})
+}
 
+// ExportTo calls fn for every frozen fund of the unbond period starting at given height in order of heights
+func (f *FrozenFunds) ExportTo(height uint64, fn func(frozenFund types.FrozenFund) error) error {
+	var err error
+	f.IterateFrozenFunds(height, height+candidates.UnbondPeriod, func(frozenFunds *Model) bool {
 		for _, frozenFund := range frozenFunds.List {
-			state.FrozenFunds = append(state.FrozenFunds, types.FrozenFund{
-				Height:       i,
+			err = fn(types.FrozenFund{
+				Height:       frozenFunds.Height(),
 				Address:      frozenFund.Address,
 				CandidateKey: frozenFund.CandidateKey,
 				CandidateID:  uint64(frozenFund.CandidateID),
 				Coin:         uint64(frozenFund.Coin),
 				Value:        frozenFund.Value.String(),
 			})
+			if err != nil {
+				return true
+			}
 		}
-	}
+
+		return false
+	})
+
+	return err
 }
 
 func (f *FrozenFunds) getFromMap(height uint64) *Model {
@@ -158,26 +158,37 @@ func (hb *HaltBlocks) Delete(height uint64) {
 }
 
 func (hb *HaltBlocks) Export(state *types.AppState) {
-	hb.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] != mainPrefix {
-			return false
-		}
+	_ = hb.ExportTo(func(haltBlock types.HaltBlock) error {
+		state.HaltBlocks = append(state.HaltBlocks, haltBlock)
+		return nil
+	})
+}
 
+// ExportTo calls fn for every halt block of the state. Halt blocks not loaded before are read from the tree
+// and are not cached.
+func (hb *HaltBlocks) ExportTo(fn func(haltBlock types.HaltBlock) error) error {
+	var err error
+	hb.iavl.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
 		height := binary.LittleEndian.Uint64(key[1:])
-		halts := hb.get(height)
+		halts := hb.getFromMap(height)
 		if halts == nil {
-			return false
+			halts = &Model{}
+			if err = rlp.DecodeBytes(value, halts); err != nil {
+				err = fmt.Errorf("failed to decode halt blocks at height %d: %s", height, err)
+				return true
+			}
 		}
 
 		for _, haltBlock := range halts.List {
-			state.HaltBlocks = append(state.HaltBlocks, types.HaltBlock{
-				Height:       height,
-				CandidateKey: haltBlock.Pubkey,
-			})
+			if err = fn(types.HaltBlock{Height: height, CandidateKey: haltBlock.Pubkey}); err != nil {
+				return true
+			}
 		}
 
 		return false
 	})
+
+	return err
 }
 
 func (hb *HaltBlocks) getFromMap(height uint64) *Model {
@@ -175,6 +175,11 @@ func NewState(height uint64, db db.DB, events eventsdb.IEventsDB, cacheSize int,
 		return nil, err
 	}
 
+	return NewStateForTree(iavlTree, events, db, keepLastStates)
+}
+
+// NewStateForTree creates state for delivering transactions over given tree loaded at the last committed version
+func NewStateForTree(iavlTree tree.MTree, events eventsdb.IEventsDB, db db.DB, keepLastStates int64) (*State, error) {
 	state, err := newStateForTree(iavlTree, events, db, keepLastStates)
 	if err != nil {
 		return nil, err
@@ -195,10 +200,30 @@ func NewCheckStateAtHeight(height uint64, db db.DB) (*CheckState, error) {
 	return newCheckStateForTree(iavlTree, nil, db, 0)
 }
 
+// NewReadViewAtHeight creates immutable state of given height with candidates, stakes and validators loaded in advance.
+// Readers of the view do not modify it, so the view can be shared between concurrent readers.
+func NewReadViewAtHeight(height uint64, db db.DB) (*CheckState, error) {
+	cState, err := NewCheckStateAtHeight(height, db)
+	if err != nil {
+		return nil, err
+	}
+
+	cState.state.Candidates.LoadCandidates()
+	cState.state.Candidates.LoadStakes()
+	cState.state.Validators.LoadValidators()
+
+	return cState, nil
+}
+
 func (s *State) Tree() tree.MTree {
 	return s.tree
 }
 
+// SetCoinHistory sets recorder of volume and reserve of coins changed by committed blocks
+func (s *State) SetCoinHistory(coinHistory bus.CoinHistory) {
+	s.bus.SetCoinHistory(coinHistory)
+}
+
 func (s *State) Lock() {
 	s.lock.Lock()
 }
@@ -239,131 +264,286 @@ func (s *State) Commit() ([]byte, error) {
 	s.tree.GlobalLock()
 	defer s.tree.GlobalUnlock()
 
-	if err := s.Accounts.Commit(); err != nil {
+	if err := s.flush(); err != nil {
 		return nil, err
 	}
 
+	hash, version, err := s.tree.SaveVersion()
+	if err != nil {
+		return hash, err
+	}
+
+	if version%countBatchBlocksDelete == 30 && version-countBatchBlocksDelete > s.keepLastStates {
+		if err := s.tree.DeleteVersionsIfExists(version-countBatchBlocksDelete-s.keepLastStates, version-s.keepLastStates); err != nil {
+			return hash, err
+		}
+	}
+
+	return hash, nil
+}
+
+// Flush writes changes of the state to the tree without saving a new version.
+// Order of writes affects the hash of the tree, so states flushed in the middle of a block
+// have different hashes than states committed once per block.
+func (s *State) Flush() error {
+	s.tree.GlobalLock()
+	defer s.tree.GlobalUnlock()
+
+	return s.flush()
+}
+
+func (s *State) flush() error {
+	if err := s.Accounts.Commit(); err != nil {
+		return err
+	}
+
 	if err := s.App.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Coins.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Candidates.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Validators.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Checks.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.FrozenFunds.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Halts.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := s.Waitlist.Commit(); err != nil {
-		return nil, err
+		return err
 	}
 
-	hash, version, err := s.tree.SaveVersion()
-	if err != nil {
-		return hash, err
+	return nil
+}
+
+func (s *State) Import(state types.AppState) error {
+	imp := newImporter(s)
+	imp.header = state
+	imp.setHeader()
+
+	for i := range state.Accounts {
+		if err := imp.importItem(&state.Accounts[i]); err != nil {
+			return err
+		}
 	}
 
-	if version%countBatchBlocksDelete == 30 && version-countBatchBlocksDelete > s.keepLastStates {
-		if err := s.tree.DeleteVersionsIfExists(version-countBatchBlocksDelete-s.keepLastStates, version-s.keepLastStates); err != nil {
-			return hash, err
+	for i := range state.Coins {
+		if err := imp.importItem(&state.Coins[i]); err != nil {
+			return err
 		}
 	}
 
-	return hash, nil
-}
+	for i := range state.Validators {
+		if err := imp.importItem(&state.Validators[i]); err != nil {
+			return err
+		}
+	}
+	imp.endSection(sectionValidators)
 
-func (s *State) Import(state types.AppState) error {
-	s.App.SetMaxGas(state.MaxGas)
-	s.App.SetTotalSlashed(helpers.StringToBigInt(state.TotalSlashed))
-	s.App.SetCoinsCount(uint32(len(state.Coins)))
+	for i := range state.BlockListCandidates {
+		if err := imp.importItem(&state.BlockListCandidates[i]); err != nil {
+			return err
+		}
+	}
 
-	for _, a := range state.Accounts {
-		if a.MultisigData != nil {
-			var weights []uint32
-			for _, weight := range a.MultisigData.Weights {
-				weights = append(weights, uint32(weight))
-			}
-			s.Accounts.CreateMultisig(weights, a.MultisigData.Addresses, uint32(a.MultisigData.Threshold), a.Address)
+	for i := range state.Candidates {
+		if err := imp.importItem(&state.Candidates[i]); err != nil {
+			return err
 		}
+	}
+	imp.endSection(sectionCandidates)
 
-		s.Accounts.SetNonce(a.Address, a.Nonce)
+	for i := range state.Waitlist {
+		if err := imp.importItem(&state.Waitlist[i]); err != nil {
+			return err
+		}
+	}
 
-		for _, b := range a.Balance {
-			s.Accounts.SetBalance(a.Address, types.CoinID(b.Coin), helpers.StringToBigInt(b.Value))
+	for i := range state.UsedChecks {
+		if err := imp.importItem(&state.UsedChecks[i]); err != nil {
+			return err
 		}
 	}
 
-	for _, c := range state.Coins {
-		s.Coins.Create(types.CoinID(c.ID), c.Symbol, c.Name, helpers.StringToBigInt(c.Volume),
-			uint32(c.Crr), helpers.StringToBigInt(c.Reserve), helpers.StringToBigInt(c.MaxSupply), c.OwnerAddress)
+	for i := range state.PartialChecks {
+		if err := imp.importItem(&state.PartialChecks[i]); err != nil {
+			return err
+		}
 	}
 
-	var vals []*validators.Validator
-	for _, v := range state.Validators {
-		vals = append(vals, validators.NewValidator(
-			v.PubKey,
-			v.AbsentTimes,
-			helpers.StringToBigInt(v.TotalNoahStake),
-			helpers.StringToBigInt(v.AccumReward),
-			true,
-			true,
-			true,
-			s.bus))
+	for i := range state.FrozenFunds {
+		if err := imp.importItem(&state.FrozenFunds[i]); err != nil {
+			return err
+		}
+	}
+
+	imp.finish()
+	return nil
+}
+
+// importer imports state item by item, so the whole state is not required to be in memory.
+// The state is nil if items are only verified.
+type importer struct {
+	s          *State
+	header     types.AppState
+	coins      uint32
+	validators []*validators.Validator
+	ended      map[byte]bool
+
+	// startHeight replaces start height of the header if it is not zero
+	startHeight uint64
+	// verifier verifies items before they are imported, nil if items are not verified
+	verifier *types.AppStateVerifier
+}
+
+func newImporter(s *State) *importer {
+	return &importer{s: s, ended: map[byte]bool{}}
+}
+
+func (imp *importer) setHeader() {
+	if imp.startHeight != 0 {
+		imp.header.StartHeight = imp.startHeight
 	}
-	s.Validators.SetValidators(vals)
 
-	for _, pubkey := range state.BlockListCandidates {
-		s.Candidates.AddToBlockPubKey(pubkey)
+	if imp.s == nil {
+		return
 	}
 
-	for _, c := range state.Candidates {
-		s.Candidates.CreateWithID(c.OwnerAddress, c.RewardAddress, c.ControlAddress, c.PubKey, uint32(c.Commission), uint32(c.ID))
-		if c.Status == candidates.CandidateStatusOnline {
-			s.Candidates.SetOnline(c.PubKey)
+	imp.s.App.SetMaxGas(imp.header.MaxGas)
+	imp.s.App.SetTotalSlashed(helpers.StringToBigInt(imp.header.TotalSlashed))
+}
+
+func (imp *importer) importItem(item interface{}) error {
+	if imp.verifier != nil {
+		if err := imp.verifier.VerifyItem(item); err != nil {
+			return err
 		}
+	}
 
-		s.Candidates.SetTotalStake(c.PubKey, helpers.StringToBigInt(c.TotalNoahStake))
-		s.Candidates.SetStakes(c.PubKey, c.Stakes, c.Updates)
+	s := imp.s
+	if s == nil {
+		return nil
 	}
-	s.Candidates.RecalculateStakes(state.StartHeight)
 
-	for _, w := range state.Waitlist {
-		value, ok := big.NewInt(0).SetString(w.Value, 10)
-		if !ok {
-			return errors.Newf("Cannot decode %s into big.Int", w.Value)
+	switch item := item.(type) {
+	case *types.Account:
+		if item.MultisigData != nil {
+			var weights []uint32
+			for _, weight := range item.MultisigData.Weights {
+				weights = append(weights, uint32(weight))
+			}
+			s.Accounts.CreateMultisig(weights, item.MultisigData.Addresses, uint32(item.MultisigData.Threshold), item.Address)
 		}
-		s.Waitlist.AddWaitList(w.Owner, s.Candidates.PubKey(uint32(w.CandidateID)), types.CoinID(w.Coin), value)
-	}
 
-	for _, hashString := range state.UsedChecks {
-		bytes, _ := hex.DecodeString(string(hashString))
+		s.Accounts.SetNonce(item.Address, item.Nonce)
+
+		for _, b := range item.Balance {
+			s.Accounts.SetBalance(item.Address, types.CoinID(b.Coin), helpers.StringToBigInt(b.Value))
+		}
+	case *types.Coin:
+		s.Coins.Create(types.CoinID(item.ID), item.Symbol, item.Name, helpers.StringToBigInt(item.Volume),
+			uint32(item.Crr), helpers.StringToBigInt(item.Reserve), helpers.StringToBigInt(item.MaxSupply), item.OwnerAddress)
+		imp.coins++
+	case *types.Validator:
+		imp.validators = append(imp.validators, validators.NewValidator(
+			item.PubKey,
+			item.AbsentTimes,
+			helpers.StringToBigInt(item.TotalNoahStake),
+			helpers.StringToBigInt(item.AccumReward),
+			true,
+			true,
+			true,
+			s.bus))
+	case *types.Pubkey:
+		s.Candidates.AddToBlockPubKey(*item)
+	case *types.Candidate:
+		s.Candidates.CreateWithID(item.OwnerAddress, item.RewardAddress, item.ControlAddress, item.PubKey, uint32(item.Commission), uint32(item.ID))
+		if item.Status == candidates.CandidateStatusOnline {
+			s.Candidates.SetOnline(item.PubKey)
+		}
+
+		if item.CommissionUpdate != nil {
+			s.Candidates.SetCommissionUpdate(item.PubKey, uint32(item.CommissionUpdate.Commission), item.CommissionUpdate.Height)
+		}
+
+		s.Candidates.SetTotalStake(item.PubKey, helpers.StringToBigInt(item.TotalNoahStake))
+		s.Candidates.SetStakes(item.PubKey, item.Stakes, item.Updates)
+	case *types.Waitlist:
+		value, ok := big.NewInt(0).SetString(item.Value, 10)
+		if !ok {
+			return errors.Newf("Cannot decode %s into big.Int", item.Value)
+		}
+		s.Waitlist.AddWaitList(item.Owner, s.Candidates.PubKey(uint32(item.CandidateID)), types.CoinID(item.Coin), value)
+	case *types.UsedCheck:
+		bytes, _ := hex.DecodeString(string(*item))
 		var hash types.Hash
 		copy(hash[:], bytes)
 		s.Checks.UseCheckHash(hash)
+	case *types.PartialCheck:
+		bytes, _ := hex.DecodeString(item.Hash)
+		var hash types.Hash
+		copy(hash[:], bytes)
+		s.Checks.SetRemainingValue(hash, helpers.StringToBigInt(item.Value))
+	case *types.FrozenFund:
+		s.FrozenFunds.AddFund(item.Height, item.Address, *item.CandidateKey, uint32(item.CandidateID), types.CoinID(item.Coin), helpers.StringToBigInt(item.Value))
+	case *types.HaltBlock:
+		// halt blocks are not imported
+	default:
+		return fmt.Errorf("unknown state item %T", item)
 	}
 
-	for _, ff := range state.FrozenFunds {
-		s.FrozenFunds.AddFund(ff.Height, ff.Address, *ff.CandidateKey, uint32(ff.CandidateID), types.CoinID(ff.Coin), helpers.StringToBigInt(ff.Value))
+	return nil
+}
+
+// endSection completes import of the section when all its items are imported
+func (imp *importer) endSection(section byte) {
+	imp.ended[section] = true
+	if imp.s == nil {
+		return
 	}
 
-	return nil
+	switch section {
+	case sectionValidators:
+		imp.s.Validators.SetValidators(imp.validators)
+		imp.validators = nil
+	case sectionCandidates:
+		startHeight := imp.header.StartHeight
+		if imp.startHeight != 0 {
+			startHeight = imp.startHeight
+		}
+		imp.s.Candidates.RecalculateStakes(startHeight)
+	}
+}
+
+// finish completes import, sections which are absent are completed as empty ones
+func (imp *importer) finish() {
+	for _, section := range []byte{sectionValidators, sectionCandidates} {
+		if !imp.ended[section] {
+			imp.endSection(section)
+		}
+	}
+
+	if imp.s == nil {
+		return
+	}
+
+	imp.s.App.SetCoinsCount(imp.coins)
 }
 
 func (s *State) Export(height uint64) types.AppState {
@@ -292,3 +292,40 @@ func TestStateExport(t *testing.T) {
 		t.Fatal("Invalid waitlist data")
 	}
 }
+
+type coinHistory map[types.CoinID]string
+
+func (h coinHistory) AddCoin(id types.CoinID, volume *big.Int, reserve *big.Int, crr uint32) {
+	h[id] = volume.String() + "/" + reserve.String()
+}
+
+func TestState_SetCoinHistory(t *testing.T) {
+	state, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	history := coinHistory{}
+	state.SetCoinHistory(history)
+
+	state.Coins.Create(1, types.StrToCoinSymbol("TEST"), "TEST", big.NewInt(100), 10, big.NewInt(20), big.NewInt(1000), nil)
+	state.Coins.Create(2, types.StrToCoinSymbol("TEST2"), "TEST2", big.NewInt(100), 10, big.NewInt(20), big.NewInt(1000), nil)
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 || history[1] != "100/20" {
+		t.Fatalf("unexpected history of created coins: %v", history)
+	}
+
+	delete(history, 1)
+	delete(history, 2)
+
+	state.Coins.AddVolume(1, big.NewInt(5))
+	state.Coins.AddReserve(1, big.NewInt(1))
+	if _, err := state.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[1] != "105/21" {
+		t.Fatalf("unexpected history of changed coins: %v", history)
+	}
+}
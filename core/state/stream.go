@@ -0,0 +1,484 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/tendermint/go-amino"
+)
+
+// StreamFormat is encoding of the state written by ExportStream
+type StreamFormat string
+
+const (
+	// StreamJSON is JSON object of the same layout as app state of genesis
+	StreamJSON StreamFormat = "json"
+	// StreamBinary is a sequence of amino encoded records: section byte followed by length prefixed item
+	StreamBinary StreamFormat = "binary"
+)
+
+// maxStreamItemSize limits size of a single record of binary stream
+const maxStreamItemSize = 64 * 1024 * 1024
+
+var streamCdc = amino.NewCodec()
+
+// streamMagic starts the state written in binary format
+var streamMagic = []byte("NOAHSTATE\x01")
+
+// sections of the stream in order of writing, the same as the order of State.Import:
+// coins should precede candidates and waitlist should follow candidates
+const (
+	sectionHeader byte = iota
+	sectionAccounts
+	sectionCoins
+	sectionValidators
+	sectionBlockList
+	sectionCandidates
+	sectionWaitlist
+	sectionUsedChecks
+	sectionPartialChecks
+	sectionFrozenFunds
+	sectionHaltBlocks
+
+	sectionEnd byte = 0xff
+)
+
+// sectionNames are keys of sections in JSON, the same as in types.AppState
+var sectionNames = map[byte]string{
+	sectionValidators:    "validators",
+	sectionBlockList:     "block_list_candidates",
+	sectionCandidates:    "candidates",
+	sectionWaitlist:      "waitlist",
+	sectionAccounts:      "accounts",
+	sectionCoins:         "coins",
+	sectionFrozenFunds:   "frozen_funds",
+	sectionHaltBlocks:    "halt_blocks",
+	sectionUsedChecks:    "used_checks",
+	sectionPartialChecks: "partial_checks",
+}
+
+func newSectionItem(section byte) (interface{}, error) {
+	switch section {
+	case sectionValidators:
+		return &types.Validator{}, nil
+	case sectionBlockList:
+		return &types.Pubkey{}, nil
+	case sectionCandidates:
+		return &types.Candidate{}, nil
+	case sectionWaitlist:
+		return &types.Waitlist{}, nil
+	case sectionAccounts:
+		return &types.Account{}, nil
+	case sectionCoins:
+		return &types.Coin{}, nil
+	case sectionFrozenFunds:
+		return &types.FrozenFund{}, nil
+	case sectionHaltBlocks:
+		return &types.HaltBlock{}, nil
+	case sectionUsedChecks:
+		return new(types.UsedCheck), nil
+	case sectionPartialChecks:
+		return &types.PartialCheck{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown state section %d", section)
+}
+
+// ExportStream writes the state section by section. Unlike Export, the whole state is never kept in memory:
+// items of every section are written one by one while they are read from the tree.
+func (cs *CheckState) ExportStream(height uint64, w io.Writer, format StreamFormat) error {
+	writer, err := newStreamWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	header := new(types.AppState)
+	cs.App().Export(header, height)
+	if err := writer.writeHeader(header); err != nil {
+		return err
+	}
+
+	sections := []struct {
+		section byte
+		export  func(write func(item interface{}) error) error
+	}{
+		{sectionAccounts, func(write func(item interface{}) error) error {
+			return cs.state.Accounts.ExportTo(func(account types.Account) error { return write(account) })
+		}},
+		{sectionCoins, func(write func(item interface{}) error) error {
+			return cs.state.Coins.ExportTo(func(coin types.Coin) error { return write(coin) })
+		}},
+		{sectionValidators, func(write func(item interface{}) error) error {
+			return cs.state.Validators.ExportTo(func(validator types.Validator) error { return write(validator) })
+		}},
+		{sectionBlockList, func(write func(item interface{}) error) error {
+			return cs.state.Candidates.ExportBlockListTo(func(pubkey types.Pubkey) error { return write(pubkey) })
+		}},
+		{sectionCandidates, func(write func(item interface{}) error) error {
+			return cs.state.Candidates.ExportTo(func(candidate types.Candidate) error { return write(candidate) })
+		}},
+		{sectionWaitlist, func(write func(item interface{}) error) error {
+			return cs.state.Waitlist.ExportTo(func(item types.Waitlist) error { return write(item) })
+		}},
+		{sectionUsedChecks, func(write func(item interface{}) error) error {
+			return cs.state.Checks.ExportUsedTo(func(check types.UsedCheck) error { return write(check) })
+		}},
+		{sectionPartialChecks, func(write func(item interface{}) error) error {
+			return cs.state.Checks.ExportPartialTo(func(check types.PartialCheck) error { return write(check) })
+		}},
+		{sectionFrozenFunds, func(write func(item interface{}) error) error {
+			return cs.state.FrozenFunds.ExportTo(height, func(ff types.FrozenFund) error { return write(ff) })
+		}},
+		{sectionHaltBlocks, func(write func(item interface{}) error) error {
+			return cs.state.Halts.ExportTo(func(halt types.HaltBlock) error { return write(halt) })
+		}},
+	}
+
+	for _, s := range sections {
+		section := s.section
+		if err := s.export(func(item interface{}) error { return writer.writeItem(section, item) }); err != nil {
+			return fmt.Errorf("can't export %s: %s", sectionNames[section], err)
+		}
+	}
+
+	return writer.close()
+}
+
+// ImportStream imports the state written by ExportStream in any format and verifies it the same way as AppState.Verify.
+// Sections are imported in order of appearance, see order of sections above. Start height of the stream is replaced
+// with given one if it is not zero.
+// Returns the state without sections, i.e. start height, max gas and other values of the header.
+func (s *State) ImportStream(r io.Reader, startHeight uint64) (*types.AppState, error) {
+	imp := newImporter(s)
+	imp.startHeight = startHeight
+
+	return imp.readStream(r)
+}
+
+// VerifyStream verifies the state written by ExportStream without importing it.
+// Returns the state without sections, see ImportStream.
+func VerifyStream(r io.Reader) (*types.AppState, error) {
+	return newImporter(nil).readStream(r)
+}
+
+func (imp *importer) readStream(r io.Reader) (*types.AppState, error) {
+	reader := bufio.NewReader(r)
+	imp.verifier = types.NewAppStateVerifier()
+
+	magic, err := reader.Peek(len(streamMagic))
+	if err == nil && bytes.Equal(magic, streamMagic) {
+		err = imp.readBinary(reader)
+	} else {
+		err = imp.readJSON(reader)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	imp.finish()
+	if err := imp.verifier.Finish(&imp.header); err != nil {
+		return nil, err
+	}
+
+	return &imp.header, nil
+}
+
+type streamWriter interface {
+	writeHeader(header *types.AppState) error
+	writeItem(section byte, item interface{}) error
+	close() error
+}
+
+func newStreamWriter(w io.Writer, format StreamFormat) (streamWriter, error) {
+	switch format {
+	case StreamJSON:
+		return &jsonStreamWriter{w: bufio.NewWriter(w)}, nil
+	case StreamBinary:
+		return &binaryStreamWriter{w: bufio.NewWriter(w)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown state format %q", format)
+}
+
+type jsonStreamWriter struct {
+	w       *bufio.Writer
+	section byte
+	items   int
+}
+
+func (j *jsonStreamWriter) writeHeader(header *types.AppState) error {
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"note", header.Note},
+		{"start_height", header.StartHeight},
+		{"max_gas", header.MaxGas},
+		{"total_slashed", header.TotalSlashed},
+	}
+
+	for i, field := range fields {
+		value, err := streamCdc.MarshalJSON(field.value)
+		if err != nil {
+			return err
+		}
+
+		separator := ","
+		if i == 0 {
+			separator = "{"
+		}
+
+		if _, err := fmt.Fprintf(j.w, "%s%q:%s", separator, field.name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *jsonStreamWriter) writeItem(section byte, item interface{}) error {
+	value, err := streamCdc.MarshalJSON(item)
+	if err != nil {
+		return err
+	}
+
+	if section != j.section {
+		if err := j.closeSection(); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(j.w, ",%q:[", sectionNames[section]); err != nil {
+			return err
+		}
+		j.section, j.items = section, 0
+	}
+
+	if j.items > 0 {
+		if err := j.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	j.items++
+
+	_, err = j.w.Write(value)
+	return err
+}
+
+func (j *jsonStreamWriter) closeSection() error {
+	if j.section == sectionHeader {
+		return nil
+	}
+
+	return j.w.WriteByte(']')
+}
+
+func (j *jsonStreamWriter) close() error {
+	if err := j.closeSection(); err != nil {
+		return err
+	}
+
+	if err := j.w.WriteByte('}'); err != nil {
+		return err
+	}
+
+	return j.w.Flush()
+}
+
+type binaryStreamWriter struct {
+	w *bufio.Writer
+}
+
+func (b *binaryStreamWriter) writeHeader(header *types.AppState) error {
+	if _, err := b.w.Write(streamMagic); err != nil {
+		return err
+	}
+
+	return b.writeItem(sectionHeader, header)
+}
+
+func (b *binaryStreamWriter) writeItem(section byte, item interface{}) error {
+	value, err := streamCdc.MarshalBinaryLengthPrefixed(item)
+	if err != nil {
+		return err
+	}
+
+	if err := b.w.WriteByte(section); err != nil {
+		return err
+	}
+
+	_, err = b.w.Write(value)
+	return err
+}
+
+func (b *binaryStreamWriter) close() error {
+	if err := b.w.WriteByte(sectionEnd); err != nil {
+		return err
+	}
+
+	return b.w.Flush()
+}
+
+func (imp *importer) readBinary(r *bufio.Reader) error {
+	if _, err := r.Discard(len(streamMagic)); err != nil {
+		return err
+	}
+
+	current := sectionHeader
+	for {
+		section, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("unexpected end of state: %s", err)
+		}
+
+		if section != current {
+			imp.endSection(current)
+			current = section
+		}
+
+		if section == sectionEnd {
+			return nil
+		}
+
+		if section == sectionHeader {
+			if _, err := streamCdc.UnmarshalBinaryLengthPrefixedReader(r, &imp.header, maxStreamItemSize); err != nil {
+				return err
+			}
+			imp.setHeader()
+			continue
+		}
+
+		item, err := newSectionItem(section)
+		if err != nil {
+			return err
+		}
+
+		if _, err := streamCdc.UnmarshalBinaryLengthPrefixedReader(r, item, maxStreamItemSize); err != nil {
+			return fmt.Errorf("can't decode item of %s: %s", sectionNames[section], err)
+		}
+
+		if err := imp.importItem(item); err != nil {
+			return err
+		}
+	}
+}
+
+func (imp *importer) readJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	sections := map[string]byte{}
+	for section, name := range sectionNames {
+		sections[name] = section
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		key, _ := token.(string)
+		section, ok := sections[key]
+		if !ok {
+			if err := imp.readHeaderField(decoder, key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := imp.readJSONSection(decoder, section); err != nil {
+			return fmt.Errorf("can't read %s: %s", key, err)
+		}
+	}
+
+	if err := expectDelim(decoder, '}'); err != nil {
+		return err
+	}
+
+	imp.setHeader()
+	return nil
+}
+
+func (imp *importer) readHeaderField(decoder *json.Decoder, key string) error {
+	var value json.RawMessage
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+
+	fields := map[string]interface{}{
+		"note":          &imp.header.Note,
+		"start_height":  &imp.header.StartHeight,
+		"max_gas":       &imp.header.MaxGas,
+		"total_slashed": &imp.header.TotalSlashed,
+		"state_file":    &imp.header.StateFile,
+	}
+
+	field, ok := fields[key]
+	if !ok {
+		return nil
+	}
+
+	if err := streamCdc.UnmarshalJSON(value, field); err != nil {
+		return fmt.Errorf("can't decode %s: %s", key, err)
+	}
+
+	return nil
+}
+
+func (imp *importer) readJSONSection(decoder *json.Decoder, section byte) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		return nil
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", token)
+	}
+
+	for decoder.More() {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		item, err := newSectionItem(section)
+		if err != nil {
+			return err
+		}
+
+		if err := streamCdc.UnmarshalJSON(value, item); err != nil {
+			return err
+		}
+
+		if err := imp.importItem(item); err != nil {
+			return err
+		}
+	}
+
+	imp.endSection(section)
+	return expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %s, got %v", expected, token)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	db "github.com/tendermint/tm-db"
+)
+
+func TestStateExportStream(t *testing.T) {
+	st, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// volume is the sum of balances, stakes, the waitlist and frozen funds of the coin below, the stream is verified
+	volume := big.NewInt(0).Add(helpers.NoahToQNoah(big.NewInt(2)), big.NewInt(650))
+	coinID := st.App.GetNextCoinID()
+	st.Coins.Create(coinID, types.StrToCoinSymbol("TEST"), "TEST", volume, 50,
+		helpers.NoahToQNoah(big.NewInt(500)), helpers.NoahToQNoah(big.NewInt(10000)), nil)
+	st.App.SetCoinsCount(coinID.Uint32())
+
+	privateKey, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	for i := byte(1); i <= 10; i++ {
+		st.Accounts.AddBalance(types.Address{i}, types.GetBaseCoinID(), big.NewInt(int64(i)))
+		st.Accounts.AddBalance(types.Address{i}, coinID, big.NewInt(int64(i)*10))
+		st.Accounts.SetNonce(types.Address{i}, uint64(i))
+	}
+	st.Accounts.AddBalance(address, types.GetBaseCoinID(), helpers.NoahToQNoah(big.NewInt(1)))
+	st.Accounts.CreateMultisig([]uint32{1, 2}, []types.Address{{1}, {2}}, 2, types.Address{100})
+
+	pubKey := types.Pubkey{1}
+	st.Candidates.Create(address, address, address, pubKey, 10)
+	st.Candidates.SetOnline(pubKey)
+	st.Candidates.Delegate(address, pubKey, types.GetBaseCoinID(), helpers.NoahToQNoah(big.NewInt(10)), big.NewInt(0))
+	st.Candidates.Delegate(types.Address{1}, pubKey, coinID, helpers.NoahToQNoah(big.NewInt(1)), big.NewInt(0))
+	st.Candidates.Create(address, address, address, types.Pubkey{2}, 30)
+	st.Candidates.RecalculateStakes(0)
+	st.Validators.Create(pubKey, helpers.NoahToQNoah(big.NewInt(10)))
+	st.Candidates.AddToBlockPubKey(types.Pubkey{3})
+
+	st.Waitlist.AddWaitList(types.Address{2}, pubKey, coinID, big.NewInt(1e18))
+	st.FrozenFunds.AddFund(10, address, pubKey, st.Candidates.ID(pubKey), coinID, big.NewInt(100))
+	st.Halts.AddHaltBlock(5, pubKey)
+
+	newCheck := &check.Check{
+		Nonce:    []byte("test nonce"),
+		ChainID:  types.CurrentChainID,
+		DueBlock: 1,
+		Coin:     coinID,
+		Value:    big.NewInt(100),
+		GasCoin:  types.GetBaseCoinID(),
+	}
+	if err := newCheck.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+	st.Checks.UseCheck(newCheck)
+
+	if _, err := st.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := st.Export(0)
+
+	// halt blocks are not imported
+	withoutHalts := exported
+	withoutHalts.HaltBlocks = nil
+	expected, err := streamCdc.MarshalJSON(withoutHalts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := imported.Import(exported); err != nil {
+		t.Fatal(err)
+	}
+	expectedHash, err := imported.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []StreamFormat{StreamJSON, StreamBinary} {
+		buf := bytes.NewBuffer(nil)
+		if err := NewCheckState(st).ExportStream(0, buf, format); err != nil {
+			t.Fatalf("Can't export %s stream: %s", format, err)
+		}
+
+		verified, err := VerifyStream(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("Can't verify %s stream: %s", format, err)
+		}
+
+		if verified.StartHeight != exported.StartHeight {
+			t.Fatalf("Wrong start height of verified %s stream: %d", format, verified.StartHeight)
+		}
+
+		newState, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		header, err := newState.ImportStream(buf, exported.StartHeight+1)
+		if err != nil {
+			t.Fatalf("Can't import %s stream: %s", format, err)
+		}
+
+		if header.MaxGas != exported.MaxGas || header.TotalSlashed != exported.TotalSlashed || header.StartHeight != exported.StartHeight+1 {
+			t.Fatalf("Wrong header of %s stream: %+v", format, header)
+		}
+
+		hash, err := newState.Commit()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(hash, expectedHash) {
+			t.Fatalf("Wrong hash of state imported from %s stream. Expected %X, got %X", format, expectedHash, hash)
+		}
+
+		got, err := streamCdc.MarshalJSON(newState.Export(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(got, expected) {
+			t.Fatalf("Wrong state imported from %s stream.\nExpected: %s\nGot: %s", format, expected, got)
+		}
+	}
+}
+
+func TestStateImportStreamVerifies(t *testing.T) {
+	st, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// state without validators can't start a chain
+	buf := bytes.NewBuffer(nil)
+	if err := NewCheckState(st).ExportStream(0, buf, StreamBinary); err != nil {
+		t.Fatal(err)
+	}
+
+	newState, err := NewState(0, db.NewMemDB(), emptyEvents{}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newState.ImportStream(buf, 0); err == nil {
+		t.Fatal("State without validators is imported")
+	}
+}
@@ -96,11 +96,12 @@ func (v *Validators) SetValidatorPresent(height uint64, address types.TmAddress)
 }
 
 // SetValidatorAbsent marks validator as absent at current height
-// if validator misses signs of more than validatorMaxAbsentTimes, it will receive penalty and will be swithed off
-func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress) {
+// if validator misses signs of more than validatorMaxAbsentTimes, it will receive penalty and will be swithed off.
+// Returns true if the validator is switched off.
+func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress) bool {
 	validator := v.GetByTmAddress(address)
 	if validator == nil {
-		return
+		return false
 	}
 	validator.SetAbsent(height)
 
@@ -110,7 +111,10 @@ func (v *Validators) SetValidatorAbsent(height uint64, address types.TmAddress)
 		}
 
 		v.turnValidatorOff(address)
+		return true
 	}
+
+	return false
 }
 
 // GetValidators returns list of validators
@@ -366,16 +370,29 @@ func (v *Validators) SetValidators(vals []*Validator) {
 
 // Export exports all data to the given state
 func (v *Validators) Export(state *types.AppState) {
+	_ = v.ExportTo(func(validator types.Validator) error {
+		state.Validators = append(state.Validators, validator)
+		return nil
+	})
+}
+
+// ExportTo calls fn for every validator of the state
+func (v *Validators) ExportTo(fn func(validator types.Validator) error) error {
 	v.LoadValidators()
 
 	for _, val := range v.GetValidators() {
-		state.Validators = append(state.Validators, types.Validator{
+		err := fn(types.Validator{
 			TotalNoahStake: val.GetTotalNoahStake().String(),
-			PubKey:        val.PubKey,
-			AccumReward:   val.GetAccumReward().String(),
-			AbsentTimes:   val.AbsentTimes,
+			PubKey:         val.PubKey,
+			AccumReward:    val.GetAccumReward().String(),
+			AbsentTimes:    val.AbsentTimes,
 		})
+		if err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 // SetToDrop marks given validator as inactive for dropping it in the next block
@@ -320,8 +320,13 @@ func TestValidators_SetValidatorAbsent(t *testing.T) {
 	if validator == nil {
 		t.Fatal("validator not found")
 	}
-	for i := uint64(0); i < validatorMaxAbsentTimes+1; i++ {
-		validators.SetValidatorAbsent(i, validator.tmAddress)
+	for i := uint64(0); i < validatorMaxAbsentTimes; i++ {
+		if validators.SetValidatorAbsent(i, validator.tmAddress) {
+			t.Fatal("validator switched off too early")
+		}
+	}
+	if !validators.SetValidatorAbsent(validatorMaxAbsentTimes, validator.tmAddress) {
+		t.Fatal("validator not switched off")
 	}
 	if !validator.IsToDrop() {
 		t.Fatal("validator not drop")
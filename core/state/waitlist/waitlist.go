@@ -45,24 +45,9 @@ func NewWaitList(stateBus *bus.Bus, iavl tree.MTree) (*WaitList, error) {
 }
 
 func (wl *WaitList) Export(state *types.AppState) {
-	wl.iavl.Iterate(func(key []byte, value []byte) bool {
-		if key[0] == mainPrefix {
-			address := types.BytesToAddress(key[1:])
-
-			model := wl.GetByAddress(address)
-			if model != nil && len(model.List) != 0 {
-				for _, w := range model.List {
-					state.Waitlist = append(state.Waitlist, types.Waitlist{
-						CandidateID: uint64(w.CandidateId),
-						Owner:       address,
-						Coin:        uint64(w.Coin),
-						Value:       w.Value.String(),
-					})
-				}
-			}
-		}
-
-		return false
+	_ = wl.ExportTo(func(item types.Waitlist) error {
+		state.Waitlist = append(state.Waitlist, item)
+		return nil
 	})
 
 	sort.SliceStable(state.Waitlist, func(i, j int) bool {
@@ -187,6 +172,39 @@ func (wl *WaitList) getOrNew(address types.Address) *Model {
 	return w
 }
 
+// ExportTo calls fn for every item of waitlists of the state in order of owners. Waitlists not loaded before are read
+// from the tree and are not cached.
+func (wl *WaitList) ExportTo(fn func(item types.Waitlist) error) error {
+	var err error
+	wl.iavl.IterateRange([]byte{mainPrefix}, []byte{mainPrefix + 1}, true, func(key []byte, value []byte) bool {
+		address := types.BytesToAddress(key[1:])
+		model := wl.getFromMap(address)
+		if model == nil {
+			model = new(Model)
+			if err = rlp.DecodeBytes(value, model); err != nil {
+				err = fmt.Errorf("failed to decode waitlists for address %s: %s", address.String(), err)
+				return true
+			}
+		}
+
+		for _, w := range model.List {
+			err = fn(types.Waitlist{
+				CandidateID: uint64(w.CandidateId),
+				Owner:       address,
+				Coin:        uint64(w.Coin),
+				Value:       w.Value.String(),
+			})
+			if err != nil {
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return err
+}
+
 func (wl *WaitList) get(address types.Address) *Model {
 	if ff := wl.getFromMap(address); ff != nil {
 		return ff
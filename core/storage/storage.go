@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/filter"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	db "github.com/tendermint/tm-db"
+)
+
+// Backends lists database backends supported by the node.
+// boltdb is available only in binaries built with "boltdb" tag.
+var Backends = []db.BackendType{db.GoLevelDBBackend, db.MemDBBackend, db.BoltDBBackend}
+
+// NewDB creates database with given name in given dir using given backend.
+// goleveldb databases are tuned to use memLimit megabytes of memory, 0 - default options.
+func NewDB(name string, backend db.BackendType, dir string, memLimit int) (database db.DB, err error) {
+	if backend == db.GoLevelDBBackend && memLimit != 0 {
+		return db.NewGoLevelDBWithOpts(name, dir, getDbOpts(memLimit))
+	}
+
+	if !IsSupported(backend) {
+		return nil, fmt.Errorf("unsupported db backend %q", backend)
+	}
+
+	// tm-db panics on backends which are not compiled in
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot open %s db: %v", backend, r)
+		}
+	}()
+
+	return db.NewDB(name, backend, dir), nil
+}
+
+// IsSupported returns true if given backend is in the list of supported backends
+func IsSupported(backend db.BackendType) bool {
+	for _, supported := range Backends {
+		if backend == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Copy writes all records of src database to dst, flushing every batchSize records
+func Copy(dst, src db.DB, batchSize int) (int, error) {
+	iterator, err := src.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer iterator.Close()
+
+	count := 0
+	batch := dst.NewBatch()
+	for ; iterator.Valid(); iterator.Next() {
+		batch.Set(iterator.Key(), iterator.Value())
+		count++
+
+		if count%batchSize == 0 {
+			if err := batch.WriteSync(); err != nil {
+				return count, err
+			}
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return count, err
+	}
+	batch.Close()
+
+	return count, nil
+}
+
+func getDbOpts(memLimit int) *opt.Options {
+	if memLimit < 1024 {
+		panic(fmt.Sprintf("Not enough memory given to StateDB. Expected >1024M, given %d", memLimit))
+	}
+	return &opt.Options{
+		OpenFilesCacheCapacity: memLimit,
+		BlockCacheCapacity:     memLimit / 2 * opt.MiB,
+		WriteBuffer:            memLimit / 4 * opt.MiB, // Two of these are used internally
+		Filter:                 filter.NewBloomFilter(10),
+	}
+}
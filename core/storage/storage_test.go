@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	db "github.com/tendermint/tm-db"
+)
+
+func TestNewDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, backend := range []db.BackendType{db.GoLevelDBBackend, db.MemDBBackend} {
+		database, err := NewDB("test", backend, dir, 0)
+		if err != nil {
+			t.Fatalf("Cannot create %s db: %s", backend, err)
+		}
+
+		if err := database.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := NewDB("test", "unknown", dir, 0); err == nil {
+		t.Fatal("Unknown backend is accepted")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	src, dst := db.NewMemDB(), db.NewMemDB()
+	for i := 0; i < 25; i++ {
+		if err := src.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count, err := Copy(dst, src, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 25 {
+		t.Fatalf("Wrong number of copied records. Expected %d, got %d", 25, count)
+	}
+
+	value, err := dst.Get([]byte("key24"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(value) != "value24" {
+		t.Fatalf("Wrong copied value: %s", value)
+	}
+}
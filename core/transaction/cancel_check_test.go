@@ -0,0 +1,198 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestCancelCheckTx(t *testing.T) {
+	defer activateUpgrades(upgrades.CheckCancel)()
+
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	cState.Accounts.AddBalance(crypto.PubkeyToAddress(receiverKey.PublicKey), coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	rawCheck, lockKey := makeTestCheck(t, issuerKey, helpers.NoahToQNoah(big.NewInt(10)), false)
+
+	response := RunTx(cState, makeCancelCheckTx(t, receiverKey, 1, rawCheck), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != code.IsNotCheckIssuer {
+		t.Fatalf("Response code is not %d. Error %s", code.IsNotCheckIssuer, response.Log)
+	}
+
+	response = RunTx(cState, makeCancelCheckTx(t, issuerKey, 1, rawCheck), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	response = RunTx(cState, makeRedeemCheckTx(t, receiverKey, 1, rawCheck, lockKey, nil), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+}
+
+func TestRedeemPartialCheckTx(t *testing.T) {
+	defer activateUpgrades(upgrades.PartialChecks)()
+
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	receiverKey, _ := crypto.GenerateKey()
+	receiver := crypto.PubkeyToAddress(receiverKey.PublicKey)
+
+	rawCheck, lockKey := makeTestCheck(t, issuerKey, helpers.NoahToQNoah(big.NewInt(10)), true)
+
+	response := RunTx(cState, makeRedeemCheckTx(t, receiverKey, 1, rawCheck, lockKey, helpers.NoahToQNoah(big.NewInt(11))), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != code.WrongCheckValue {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCheckValue, response.Log)
+	}
+
+	response = RunTx(cState, makeRedeemCheckTx(t, receiverKey, 1, rawCheck, lockKey, helpers.NoahToQNoah(big.NewInt(4))), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(helpers.NoahToQNoah(big.NewInt(4))) != 0 {
+		t.Fatalf("Wrong balance of receiver. Expected %s, got %s", helpers.NoahToQNoah(big.NewInt(4)), balance)
+	}
+
+	decodedCheck, _ := c.DecodeFromBytes(rawCheck)
+	if remaining := cState.Checks.RemainingValue(decodedCheck); remaining.Cmp(helpers.NoahToQNoah(big.NewInt(6))) != 0 {
+		t.Fatalf("Wrong remaining value. Expected %s, got %s", helpers.NoahToQNoah(big.NewInt(6)), remaining)
+	}
+
+	// the whole remaining value is redeemed
+	response = RunTx(cState, makeRedeemCheckTx(t, receiverKey, 2, rawCheck, lockKey, nil), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	if balance := cState.Accounts.GetBalance(receiver, coin); balance.Cmp(helpers.NoahToQNoah(big.NewInt(10))) != 0 {
+		t.Fatalf("Wrong balance of receiver. Expected %s, got %s", helpers.NoahToQNoah(big.NewInt(10)), balance)
+	}
+
+	response = RunTx(cState, makeRedeemCheckTx(t, receiverKey, 3, rawCheck, lockKey, nil), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != code.CheckUsed {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckUsed, response.Log)
+	}
+
+	// value can't be set for check which is not partial
+	rawCheck, lockKey = makeTestCheck(t, issuerKey, helpers.NoahToQNoah(big.NewInt(10)), false)
+	response = RunTx(cState, makeRedeemCheckTx(t, receiverKey, 3, rawCheck, lockKey, helpers.NoahToQNoah(big.NewInt(1))), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != code.CheckNotPartial {
+		t.Fatalf("Response code is not %d. Error %s", code.CheckNotPartial, response.Log)
+	}
+}
+
+func makeTestCheck(t *testing.T, issuerKey *ecdsa.PrivateKey, value *big.Int, partial bool) ([]byte, *ecdsa.PrivateKey) {
+	passphraseHash := sha256.Sum256([]byte("password"))
+	lockKey, err := crypto.ToECDSA(passphraseHash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := c.Check{
+		Nonce:    []byte{byte(len(value.Bytes())), value.Bytes()[0]},
+		ChainID:  types.CurrentChainID,
+		DueBlock: 100,
+		Coin:     types.GetBaseCoinID(),
+		Value:    value,
+		GasCoin:  types.GetBaseCoinID(),
+	}
+
+	if partial {
+		check.Mode = []uint64{c.ModePartial}
+	}
+
+	lock, err := crypto.Sign(check.HashWithoutLock().Bytes(), lockKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check.Lock = big.NewInt(0).SetBytes(lock)
+
+	if err := check.Sign(issuerKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawCheck, err := rlp.EncodeToBytes(check)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawCheck, lockKey
+}
+
+func makeRedeemCheckTx(t *testing.T, receiverKey *ecdsa.PrivateKey, nonce uint64, rawCheck []byte, lockKey *ecdsa.PrivateKey, value *big.Int) []byte {
+	var receiverHash types.Hash
+	hw := sha3.NewLegacyKeccak256()
+	_ = rlp.Encode(hw, []interface{}{
+		crypto.PubkeyToAddress(receiverKey.PublicKey),
+	})
+	hw.Sum(receiverHash[:0])
+
+	sig, err := crypto.Sign(receiverHash.Bytes(), lockKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := RedeemCheckData{RawCheck: rawCheck}
+	copy(data.Proof[:], sig)
+	if value != nil {
+		data.Value = []*big.Int{value}
+	}
+
+	return makeTestTx(t, receiverKey, nonce, TypeRedeemCheck, data)
+}
+
+func makeCancelCheckTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, rawCheck []byte) []byte {
+	return makeTestTx(t, privateKey, nonce, TypeCancelCheck, CancelCheckData{RawCheck: rawCheck})
+}
+
+func makeTestTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, txType TxType, data interface{}) []byte {
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          txType,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/commissions"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/formula"
+)
+
+// CheckStatus describes whether the check can be redeemed at given height
+type CheckStatus struct {
+	Issuer types.Address
+	Used   bool
+	// Expired is true if DueBlock of the check is lower than the height of the block which redeems the check
+	Expired bool
+	// RemainingValue is the value which is not redeemed yet, it differs from the value of the check only for partial checks
+	RemainingValue *big.Int
+	// Commission is paid by the issuer in the gas coin of the check for redeem transaction with minimal gas price
+	Commission *big.Int
+	// Covered is true if balances of the issuer are enough to pay the remaining value and the commission
+	Covered bool
+}
+
+// GetCheckStatus verifies signature and lock of the check and returns its status in given state.
+// currentBlock is the height of the block which redeems the check, i.e. the next one after the state.
+// Chain id is not verified, checks of other networks are reported as not used.
+func GetCheckStatus(context *state.CheckState, decodedCheck *check.Check, currentBlock uint64) (*CheckStatus, error) {
+	issuer, err := decodedCheck.Sender()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decodedCheck.LockPubKey(); err != nil {
+		return nil, err
+	}
+
+	if !context.Coins().Exists(decodedCheck.Coin) {
+		return nil, errors.New("coin not exists")
+	}
+
+	if !context.Coins().Exists(decodedCheck.GasCoin) {
+		return nil, errors.New("gas coin not exists")
+	}
+
+	status := &CheckStatus{
+		Issuer:         issuer,
+		Used:           context.Checks().IsCheckUsed(decodedCheck),
+		Expired:        decodedCheck.DueBlock < currentBlock,
+		RemainingValue: big.NewInt(0),
+	}
+
+	if !status.Used {
+		status.RemainingValue = context.Checks().RemainingValue(decodedCheck)
+	}
+
+	commissionInBaseCoin := big.NewInt(0).Mul(big.NewInt(commissions.RedeemCheckTx), CommissionMultiplier)
+	status.Commission = big.NewInt(0).Set(commissionInBaseCoin)
+	if !decodedCheck.GasCoin.IsBaseCoin() {
+		gasCoin := context.Coins().GetCoin(decodedCheck.GasCoin)
+		if CheckReserveUnderflow(gasCoin, commissionInBaseCoin) != nil {
+			return status, nil
+		}
+		status.Commission = formula.CalculateSaleAmount(gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if decodedCheck.Coin == decodedCheck.GasCoin {
+		total := big.NewInt(0).Add(status.RemainingValue, status.Commission)
+		status.Covered = context.Accounts().GetBalance(issuer, decodedCheck.Coin).Cmp(total) >= 0
+	} else {
+		status.Covered = context.Accounts().GetBalance(issuer, decodedCheck.Coin).Cmp(status.RemainingValue) >= 0 &&
+			context.Accounts().GetBalance(issuer, decodedCheck.GasCoin).Cmp(status.Commission) >= 0
+	}
+
+	return status, nil
+}
@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	c "github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+)
+
+func TestGetCheckStatus(t *testing.T) {
+	cState := getState()
+	coin := types.GetBaseCoinID()
+
+	issuerKey, _ := crypto.GenerateKey()
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	cState.Accounts.AddBalance(issuer, coin, helpers.NoahToQNoah(big.NewInt(5)))
+
+	receiverKey, _ := crypto.GenerateKey()
+
+	rawCheck, lockKey := makeTestCheck(t, issuerKey, helpers.NoahToQNoah(big.NewInt(10)), false)
+	decodedCheck, err := c.DecodeFromBytes(rawCheck)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := GetCheckStatus(state.NewCheckState(cState), decodedCheck, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Issuer != issuer || status.Used || status.Expired || status.Covered {
+		t.Fatalf("Wrong status of check: %+v", status)
+	}
+
+	if status.RemainingValue.Cmp(decodedCheck.Value) != 0 {
+		t.Fatalf("Wrong remaining value. Expected %s, got %s", decodedCheck.Value, status.RemainingValue)
+	}
+
+	cState.Accounts.AddBalance(issuer, coin, helpers.NoahToQNoah(big.NewInt(10)))
+
+	status, err = GetCheckStatus(state.NewCheckState(cState), decodedCheck, 101)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.Covered || !status.Expired {
+		t.Fatalf("Wrong status of check: %+v", status)
+	}
+
+	response := RunTx(cState, makeRedeemCheckTx(t, receiverKey, 1, rawCheck, lockKey, nil), big.NewInt(0), 1, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	status, err = GetCheckStatus(state.NewCheckState(cState), decodedCheck, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.Used || status.RemainingValue.Sign() != 0 {
+		t.Fatalf("Wrong status of check: %+v", status)
+	}
+
+	decodedCheck.Lock = big.NewInt(1)
+	if _, err := GetCheckStatus(state.NewCheckState(cState), decodedCheck, 1); err == nil {
+		t.Fatal("Check with invalid lock is accepted")
+	}
+}
@@ -32,6 +32,8 @@ func init() {
 	TxDecoder.RegisterType(TypeEditMultisig, EditMultisigData{})
 	TxDecoder.RegisterType(TypePriceVote, PriceVoteData{})
 	TxDecoder.RegisterType(TypeEditCandidatePublicKey, EditCandidatePublicKeyData{})
+	TxDecoder.RegisterType(TypeEditCandidateCommission, EditCandidateCommissionData{})
+	TxDecoder.RegisterType(TypeCancelCheck, CancelCheckData{})
 }
 
 type Decoder struct {
@@ -0,0 +1,134 @@
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/commissions"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/formula"
+	"github.com/tendermint/tendermint/libs/kv"
+)
+
+type EditCandidateCommissionData struct {
+	PubKey     types.Pubkey
+	Commission uint32
+}
+
+func (data EditCandidateCommissionData) GetPubKey() types.Pubkey {
+	return data.PubKey
+}
+
+func (data EditCandidateCommissionData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
+	if response := checkCandidateOwnership(data, tx, context); response != nil {
+		return response
+	}
+
+	if update := context.Candidates().GetCommissionUpdate(data.PubKey); update != nil {
+		return &Response{
+			Code: code.CommissionUpdateExists,
+			Log:  fmt.Sprintf("Commission update of candidate is already scheduled at height %d", update.Height),
+			Info: EncodeError(code.NewCommissionUpdateExists(data.PubKey.String(), strconv.Itoa(int(update.Commission)), strconv.FormatUint(update.Height, 10))),
+		}
+	}
+
+	min, max := commissionUpdateBounds(context.Candidates().GetCandidate(data.PubKey).Commission)
+	if data.Commission < min || data.Commission > max {
+		return &Response{
+			Code: code.WrongCommission,
+			Log:  fmt.Sprintf("Commission should be between %d and %d", min, max),
+			Info: EncodeError(code.NewWrongCommission(strconv.Itoa(int(data.Commission)), strconv.Itoa(int(min)), strconv.Itoa(int(max)))),
+		}
+	}
+
+	return nil
+}
+
+func (data EditCandidateCommissionData) String() string {
+	return fmt.Sprintf("EDIT CANDIDATE COMMISSION pubkey:%s commission:%d",
+		data.PubKey.String(), data.Commission)
+}
+
+func (data EditCandidateCommissionData) Gas() int64 {
+	return commissions.EditCandidateCommission
+}
+
+func (data EditCandidateCommissionData) Run(tx *Transaction, context state.Interface, rewardPool *big.Int, currentBlock uint64) Response {
+	sender, _ := tx.Sender()
+
+	var checkState *state.CheckState
+	var isCheck bool
+	if checkState, isCheck = context.(*state.CheckState); !isCheck {
+		checkState = state.NewCheckState(context.(*state.State))
+	}
+
+	response := data.BasicCheck(tx, checkState)
+	if response != nil {
+		return *response
+	}
+
+	commissionInBaseCoin := tx.CommissionInBaseCoin()
+	commission := big.NewInt(0).Set(commissionInBaseCoin)
+
+	gasCoin := checkState.Coins().GetCoin(tx.GasCoin)
+
+	if !tx.GasCoin.IsBaseCoin() {
+		errResp := CheckReserveUnderflow(gasCoin, commissionInBaseCoin)
+		if errResp != nil {
+			return *errResp
+		}
+
+		commission = formula.CalculateSaleAmount(gasCoin.Volume(), gasCoin.Reserve(), gasCoin.Crr(), commissionInBaseCoin)
+	}
+
+	if checkState.Accounts().GetBalance(sender, tx.GasCoin).Cmp(commission) < 0 {
+		return Response{
+			Code: code.InsufficientFunds,
+			Log:  fmt.Sprintf("Insufficient funds for sender account: %s. Wanted %s %s", sender.String(), commission.String(), gasCoin.GetFullSymbol()),
+			Info: EncodeError(code.NewInsufficientFunds(sender.String(), commission.String(), gasCoin.GetFullSymbol(), gasCoin.ID().String())),
+		}
+	}
+
+	if deliverState, ok := context.(*state.State); ok {
+		rewardPool.Add(rewardPool, commissionInBaseCoin)
+
+		deliverState.Coins.SubReserve(tx.GasCoin, commissionInBaseCoin)
+		deliverState.Coins.SubVolume(tx.GasCoin, commission)
+
+		deliverState.Accounts.SubBalance(sender, tx.GasCoin, commission)
+		deliverState.Candidates.ScheduleCommissionUpdate(data.PubKey, data.Commission, currentBlock)
+		deliverState.Accounts.SetNonce(sender, tx.Nonce)
+	}
+
+	tags := kv.Pairs{
+		kv.Pair{Key: []byte("tx.type"), Value: []byte(hex.EncodeToString([]byte{byte(TypeEditCandidateCommission)}))},
+		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(sender[:]))},
+	}
+
+	return Response{
+		Code:      code.OK,
+		GasUsed:   tx.Gas(),
+		GasWanted: tx.Gas(),
+		Tags:      tags,
+	}
+}
+
+// commissionUpdateBounds returns range of commissions allowed to be scheduled for candidate with given current commission
+func commissionUpdateBounds(current uint32) (min uint32, max uint32) {
+	min, max = minCommission, maxCommission
+
+	if current > min+candidates.MaxCommissionUpdateDelta {
+		min = current - candidates.MaxCommissionUpdateDelta
+	}
+
+	if current+candidates.MaxCommissionUpdateDelta < max {
+		max = current + candidates.MaxCommissionUpdateDelta
+	}
+
+	return min, max
+}
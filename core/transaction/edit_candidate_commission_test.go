@@ -0,0 +1,166 @@
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
+)
+
+func TestEditCandidateCommissionTx(t *testing.T) {
+	defer activateUpgrades(upgrades.CommissionUpdate)()
+
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	encodedTx := makeEditCandidateCommissionTx(t, privateKey, 1, pubkey, 20)
+	response := RunTx(cState, encodedTx, big.NewInt(0), 100, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+
+	update := cState.Candidates.GetCommissionUpdate(pubkey)
+	if update == nil {
+		t.Fatal("Commission update is not scheduled")
+	}
+
+	if update.Commission != 20 {
+		t.Fatalf("Wrong scheduled commission. Expected %d, got %d", 20, update.Commission)
+	}
+
+	if update.Height != 100+candidates.CommissionUpdatePeriod {
+		t.Fatalf("Wrong scheduled height. Expected %d, got %d", 100+candidates.CommissionUpdatePeriod, update.Height)
+	}
+
+	if commission := cState.Candidates.GetCandidate(pubkey).Commission; commission != 10 {
+		t.Fatalf("Commission changed before scheduled height. Expected %d, got %d", 10, commission)
+	}
+
+	encodedTx = makeEditCandidateCommissionTx(t, privateKey, 2, pubkey, 15)
+	response = RunTx(cState, encodedTx, big.NewInt(0), 101, &sync.Map{}, 0)
+	if response.Code != code.CommissionUpdateExists {
+		t.Fatalf("Response code is not %d. Error %s", code.CommissionUpdateExists, response.Log)
+	}
+}
+
+func TestEditCandidateCommissionTxBeforeUpgrade(t *testing.T) {
+	defer upgrades.SetSchedule(types.CurrentChainID, upgrades.Schedule())
+	upgrades.SetSchedule(types.CurrentChainID, []upgrades.Upgrade{
+		{Name: upgrades.CommissionUpdate, Height: 200, Supported: true},
+	})
+
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	encodedTx := makeEditCandidateCommissionTx(t, privateKey, 1, pubkey, 20)
+	response := RunTx(cState, encodedTx, big.NewInt(0), 199, &sync.Map{}, 0)
+	if response.Code != code.DecodeError {
+		t.Fatalf("Response code is not %d. Error %s", code.DecodeError, response.Log)
+	}
+
+	response = RunTx(cState, encodedTx, big.NewInt(0), 200, &sync.Map{}, 0)
+	if response.Code != 0 {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+}
+
+func TestEditCandidateCommissionTxWithTooBigDelta(t *testing.T) {
+	defer activateUpgrades(upgrades.CommissionUpdate)()
+
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+	cState.Accounts.AddBalance(addr, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	pubkey := [32]byte{}
+	rand.Read(pubkey[:])
+
+	cState.Candidates.Create(addr, addr, addr, pubkey, 10)
+
+	encodedTx := makeEditCandidateCommissionTx(t, privateKey, 1, pubkey, 10+candidates.MaxCommissionUpdateDelta+1)
+	response := RunTx(cState, encodedTx, big.NewInt(0), 100, &sync.Map{}, 0)
+	if response.Code != code.WrongCommission {
+		t.Fatalf("Response code is not %d. Error %s", code.WrongCommission, response.Log)
+	}
+
+	if cState.Candidates.GetCommissionUpdate(pubkey) != nil {
+		t.Fatal("Commission update is scheduled")
+	}
+}
+
+func makeEditCandidateCommissionTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, pubkey types.Pubkey, commission uint32) []byte {
+	data := EditCandidateCommissionData{
+		PubKey:     pubkey,
+		Commission: commission,
+	}
+
+	encodedData, err := rlp.EncodeToBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         nonce,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          TypeEditCandidateCommission,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return encodedTx
+}
+
+// activateUpgrades schedules given upgrades at the first block and returns function restoring the schedule
+func activateUpgrades(names ...string) func() {
+	schedule := upgrades.Schedule()
+
+	var activated []upgrades.Upgrade
+	for _, name := range names {
+		activated = append(activated, upgrades.Upgrade{Name: name, Supported: true})
+	}
+	upgrades.SetSchedule(types.CurrentChainID, activated)
+
+	return func() {
+		upgrades.SetSchedule(types.CurrentChainID, schedule)
+	}
+}
@@ -16,44 +16,47 @@ type TxEncoderJSON struct {
 }
 
 type TransactionResponse struct {
-	Hash     string            `json:"hash"`
-	RawTx    string            `json:"raw_tx"`
-	Height   int64             `json:"height"`
-	Index    uint32            `json:"index"`
-	From     string            `json:"from"`
-	Nonce    uint64            `json:"nonce"`
-	Gas      int64             `json:"gas"`
-	GasPrice uint32            `json:"gas_price"`
-	GasCoin  CoinResource      `json:"gas_coin"`
-	Type     uint8             `json:"type"`
-	Data     json.RawMessage   `json:"data"`
-	Payload  []byte            `json:"payload"`
-	Tags     map[string]string `json:"tags"`
-	Code     uint32            `json:"code,omitempty"`
-	Log      string            `json:"log,omitempty"`
+	Hash       string            `json:"hash"`
+	RawTx      string            `json:"raw_tx"`
+	Height     int64             `json:"height"`
+	Index      uint32            `json:"index"`
+	From       string            `json:"from"`
+	Nonce      uint64            `json:"nonce"`
+	Gas        int64             `json:"gas"`
+	GasPrice   uint32            `json:"gas_price"`
+	GasCoin    CoinResource      `json:"gas_coin"`
+	Type       uint8             `json:"type"`
+	Data       json.RawMessage   `json:"data"`
+	Payload    []byte            `json:"payload"`
+	ValidUntil uint64            `json:"valid_until,omitempty"`
+	Tags       map[string]string `json:"tags"`
+	Code       uint32            `json:"code,omitempty"`
+	Log        string            `json:"log,omitempty"`
 }
 
 var resourcesConfig = map[transaction.TxType]TxDataResource{
-	transaction.TypeSend:                   new(SendDataResource),
-	transaction.TypeSellCoin:               new(SellCoinDataResource),
-	transaction.TypeSellAllCoin:            new(SellAllCoinDataResource),
-	transaction.TypeBuyCoin:                new(BuyCoinDataResource),
-	transaction.TypeCreateCoin:             new(CreateCoinDataResource),
-	transaction.TypeDeclareCandidacy:       new(DeclareCandidacyDataResource),
-	transaction.TypeDelegate:               new(DelegateDataResource),
-	transaction.TypeUnbond:                 new(UnbondDataResource),
-	transaction.TypeRedeemCheck:            new(RedeemCheckDataResource),
-	transaction.TypeSetCandidateOnline:     new(SetCandidateOnDataResource),
-	transaction.TypeSetCandidateOffline:    new(SetCandidateOffDataResource),
-	transaction.TypeCreateMultisig:         new(CreateMultisigDataResource),
-	transaction.TypeMultisend:              new(MultiSendDataResource),
-	transaction.TypeEditCandidate:          new(EditCandidateDataResource),
-	transaction.TypeSetHaltBlock:           new(SetHaltBlockDataResource),
-	transaction.TypeRecreateCoin:           new(RecreateCoinDataResource),
-	transaction.TypeEditCoinOwner:          new(EditCoinOwnerDataResource),
-	transaction.TypeEditMultisig:           new(EditMultisigResource),
-	transaction.TypePriceVote:              new(PriceVoteResource),
-	transaction.TypeEditCandidatePublicKey: new(EditCandidatePublicKeyResource),
+	transaction.TypeSend:                    new(SendDataResource),
+	transaction.TypeSellCoin:                new(SellCoinDataResource),
+	transaction.TypeSellAllCoin:             new(SellAllCoinDataResource),
+	transaction.TypeBuyCoin:                 new(BuyCoinDataResource),
+	transaction.TypeCreateCoin:              new(CreateCoinDataResource),
+	transaction.TypeDeclareCandidacy:        new(DeclareCandidacyDataResource),
+	transaction.TypeDelegate:                new(DelegateDataResource),
+	transaction.TypeUnbond:                  new(UnbondDataResource),
+	transaction.TypeRedeemCheck:             new(RedeemCheckDataResource),
+	transaction.TypeSetCandidateOnline:      new(SetCandidateOnDataResource),
+	transaction.TypeSetCandidateOffline:     new(SetCandidateOffDataResource),
+	transaction.TypeCreateMultisig:          new(CreateMultisigDataResource),
+	transaction.TypeMultisend:               new(MultiSendDataResource),
+	transaction.TypeEditCandidate:           new(EditCandidateDataResource),
+	transaction.TypeSetHaltBlock:            new(SetHaltBlockDataResource),
+	transaction.TypeRecreateCoin:            new(RecreateCoinDataResource),
+	transaction.TypeEditCoinOwner:           new(EditCoinOwnerDataResource),
+	transaction.TypeEditMultisig:            new(EditMultisigResource),
+	transaction.TypePriceVote:               new(PriceVoteResource),
+	transaction.TypeEditCandidatePublicKey:  new(EditCandidatePublicKeyResource),
+	transaction.TypeEditCandidateCommission: new(EditCandidateCommissionResource),
+	transaction.TypeCancelCheck:             new(CancelCheckResource),
 }
 
 func NewTxEncoderJSON(context *state.CheckState) *TxEncoderJSON {
@@ -79,21 +82,22 @@ func (encoder *TxEncoderJSON) Encode(transaction *transaction.Transaction, tmTx
 	txGasCoin := CoinResource{gasCoin.ID().Uint32(), gasCoin.GetFullSymbol()}
 
 	tx := TransactionResponse{
-		Hash:     bytes.HexBytes(tmTx.Tx.Hash()).String(),
-		RawTx:    fmt.Sprintf("%x", []byte(tmTx.Tx)),
-		Height:   tmTx.Height,
-		Index:    tmTx.Index,
-		From:     sender.String(),
-		Nonce:    transaction.Nonce,
-		Gas:      transaction.Gas(),
-		GasPrice: transaction.GasPrice,
-		GasCoin:  txGasCoin,
-		Type:     uint8(transaction.Type),
-		Data:     data,
-		Payload:  transaction.Payload,
-		Tags:     tags,
-		Code:     tmTx.TxResult.Code,
-		Log:      tmTx.TxResult.Log,
+		Hash:       bytes.HexBytes(tmTx.Tx.Hash()).String(),
+		RawTx:      fmt.Sprintf("%x", []byte(tmTx.Tx)),
+		Height:     tmTx.Height,
+		Index:      tmTx.Index,
+		From:       sender.String(),
+		Nonce:      transaction.Nonce,
+		Gas:        transaction.Gas(),
+		GasPrice:   transaction.GasPrice,
+		GasCoin:    txGasCoin,
+		Type:       uint8(transaction.Type),
+		Data:       data,
+		Payload:    transaction.Payload,
+		ValidUntil: transaction.ValidUntil(),
+		Tags:       tags,
+		Code:       tmTx.TxResult.Code,
+		Log:        tmTx.TxResult.Log,
 	}
 
 	return json.Marshal(tx)
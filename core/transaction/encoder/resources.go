@@ -192,15 +192,22 @@ func (UnbondDataResource) Transform(txData interface{}, context *state.CheckStat
 type RedeemCheckDataResource struct {
 	RawCheck string `json:"raw_check"`
 	Proof    string `json:"proof"`
+	Value    string `json:"value,omitempty"`
 }
 
 // Transform returns TxDataResource from given txData. Used for JSON encoder.
 func (RedeemCheckDataResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
 	data := txData.(*transaction.RedeemCheckData)
 
+	var value string
+	if len(data.Value) != 0 {
+		value = data.Value[0].String()
+	}
+
 	return RedeemCheckDataResource{
 		RawCheck: base64.StdEncoding.EncodeToString(data.RawCheck),
 		Proof:    base64.StdEncoding.EncodeToString(data.Proof[:]),
+		Value:    value,
 	}
 }
 
@@ -399,3 +406,33 @@ func (EditCandidatePublicKeyResource) Transform(txData interface{}, context *sta
 		NewPubKey: data.NewPubKey.String(),
 	}
 }
+
+// EditCandidateCommissionResource is JSON representation of TxType 0x15
+type EditCandidateCommissionResource struct {
+	PubKey     string `json:"pub_key"`
+	Commission string `json:"commission"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (EditCandidateCommissionResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.EditCandidateCommissionData)
+
+	return EditCandidateCommissionResource{
+		PubKey:     data.PubKey.String(),
+		Commission: strconv.Itoa(int(data.Commission)),
+	}
+}
+
+// CancelCheckResource is JSON representation of TxType 0x16
+type CancelCheckResource struct {
+	RawCheck string `json:"raw_check"`
+}
+
+// Transform returns TxDataResource from given txData. Used for JSON encoder.
+func (CancelCheckResource) Transform(txData interface{}, context *state.CheckState) TxDataResource {
+	data := txData.(*transaction.CancelCheckData)
+
+	return CancelCheckResource{
+		RawCheck: base64.StdEncoding.EncodeToString(data.RawCheck),
+	}
+}
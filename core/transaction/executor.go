@@ -10,6 +10,7 @@ import (
 	"github.com/noah-blockchain/noah-go-node/core/code"
 	"github.com/noah-blockchain/noah-go-node/core/state"
 	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 	"github.com/tendermint/tendermint/libs/kv"
 )
 
@@ -20,6 +21,12 @@ const (
 	stdGas               = 5000
 )
 
+// typeUpgrades are upgrades which activate transaction types
+var typeUpgrades = map[TxType]string{
+	TypeEditCandidateCommission: upgrades.CommissionUpdate,
+	TypeCancelCheck:             upgrades.CheckCancel,
+}
+
 // Response represents standard response from tx delivery/check
 type Response struct {
 	Code      uint32    `json:"code,omitempty"`
@@ -105,6 +112,24 @@ func RunTx(context state.Interface,
 		}
 	}
 
+	// transactions are checked against the next block, as the current one is already committed
+	height := currentBlock
+	if isCheck {
+		height++
+	}
+
+	if upgrade, ok := typeUpgrades[tx.Type]; ok && !upgrades.IsActive(upgrade, height) {
+		return Response{
+			Code: code.DecodeError,
+			Log:  fmt.Sprintf("Transaction type %d is not active until upgrade %s", tx.Type, upgrade),
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
+	if response := CheckExpiry(tx, height); response != nil {
+		return *response
+	}
+
 	sender, err := tx.Sender()
 	if err != nil {
 		return Response{
@@ -210,6 +235,20 @@ func RunTx(context state.Interface,
 	return response
 }
 
+// CheckExpiry returns error response if tx can not be included in block of given height
+func CheckExpiry(tx *Transaction, height uint64) *Response {
+	validUntil := tx.ValidUntil()
+	if validUntil == 0 || height <= validUntil || !upgrades.IsActive(upgrades.TxExpiry, height) {
+		return nil
+	}
+
+	return &Response{
+		Code: code.TxExpired,
+		Log:  fmt.Sprintf("Tx is valid until block %d", validUntil),
+		Info: EncodeError(code.NewTxExpired(strconv.FormatUint(validUntil, 10), strconv.FormatUint(height, 10))),
+	}
+}
+
 // EncodeError encodes error to json
 func EncodeError(data interface{}) string {
 	marshaled, err := json.Marshal(data)
@@ -15,6 +15,7 @@ import (
 	"github.com/noah-blockchain/noah-go-node/crypto"
 	"github.com/noah-blockchain/noah-go-node/formula"
 	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
 	"github.com/tendermint/tendermint/libs/kv"
 	"golang.org/x/crypto/sha3"
 )
@@ -22,6 +23,18 @@ import (
 type RedeemCheckData struct {
 	RawCheck []byte
 	Proof    [65]byte
+
+	// Value is optional value to redeem from partial check, the whole remaining value is redeemed if it is empty
+	Value []*big.Int `rlp:"tail"`
+}
+
+// redeemValue returns value which is requested to redeem, nil if the whole remaining value is requested
+func (data RedeemCheckData) redeemValue() *big.Int {
+	if len(data.Value) == 0 {
+		return nil
+	}
+
+	return data.Value[0]
 }
 
 func (data RedeemCheckData) BasicCheck(tx *Transaction, context *state.CheckState) *Response {
@@ -42,6 +55,14 @@ func (data RedeemCheckData) BasicCheck(tx *Transaction, context *state.CheckStat
 		}
 	}
 
+	if len(data.Value) > 1 {
+		return &Response{
+			Code: code.DecodeError,
+			Log:  "Incorrect tx data",
+			Info: EncodeError(code.NewDecodeError()),
+		}
+	}
+
 	return nil
 }
 
@@ -142,6 +163,33 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		}
 	}
 
+	// value to redeem and remaining value of partial check
+	value := decodedCheck.Value
+	var remainingValue *big.Int
+	if decodedCheck.IsPartial() || data.redeemValue() != nil {
+		if !decodedCheck.IsPartial() || !upgrades.IsActive(upgrades.PartialChecks, currentBlock) {
+			return Response{
+				Code: code.CheckNotPartial,
+				Log:  "Check can not be redeemed partially",
+				Info: EncodeError(code.NewCheckNotPartial()),
+			}
+		}
+
+		remainingValue = checkState.Checks().RemainingValue(decodedCheck)
+		value = remainingValue
+		if requested := data.redeemValue(); requested != nil {
+			value = requested
+		}
+
+		if value.Sign() <= 0 || value.Cmp(remainingValue) > 0 {
+			return Response{
+				Code: code.WrongCheckValue,
+				Log:  fmt.Sprintf("Value to redeem should be positive and not greater than remaining value %s", remainingValue),
+				Info: EncodeError(code.NewWrongCheckValue(value.String(), remainingValue.String())),
+			}
+		}
+	}
+
 	lockPublicKey, err := decodedCheck.LockPubKey()
 
 	if err != nil {
@@ -192,7 +240,7 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 	}
 
 	if decodedCheck.Coin == decodedCheck.GasCoin {
-		totalTxCost := big.NewInt(0).Add(decodedCheck.Value, commission)
+		totalTxCost := big.NewInt(0).Add(value, commission)
 		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(totalTxCost) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
@@ -201,11 +249,11 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 			}
 		}
 	} else {
-		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(decodedCheck.Value) < 0 {
+		if checkState.Accounts().GetBalance(checkSender, decodedCheck.Coin).Cmp(value) < 0 {
 			return Response{
 				Code: code.InsufficientFunds,
-				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", checkSender.String(), decodedCheck.Coin, decodedCheck.Value.String(), coin.GetFullSymbol()),
-				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), decodedCheck.Value.String(), coin.GetFullSymbol(), coin.ID().String())),
+				Log:  fmt.Sprintf("Insufficient funds for check issuer account: %s %s. Wanted %s %s", checkSender.String(), decodedCheck.Coin, value.String(), coin.GetFullSymbol()),
+				Info: EncodeError(code.NewInsufficientFunds(checkSender.String(), value.String(), coin.GetFullSymbol(), coin.ID().String())),
 			}
 		}
 
@@ -219,15 +267,19 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 	}
 
 	if deliverState, ok := context.(*state.State); ok {
-		deliverState.Checks.UseCheck(decodedCheck)
+		if remainingValue != nil && remainingValue.Cmp(value) > 0 {
+			deliverState.Checks.SetRemainingValue(decodedCheck.Hash(), big.NewInt(0).Sub(remainingValue, value))
+		} else {
+			deliverState.Checks.UseCheck(decodedCheck)
+		}
 		rewardPool.Add(rewardPool, commissionInBaseCoin)
 
 		deliverState.Coins.SubVolume(decodedCheck.GasCoin, commission)
 		deliverState.Coins.SubReserve(decodedCheck.GasCoin, commissionInBaseCoin)
 
 		deliverState.Accounts.SubBalance(checkSender, decodedCheck.GasCoin, commission)
-		deliverState.Accounts.SubBalance(checkSender, decodedCheck.Coin, decodedCheck.Value)
-		deliverState.Accounts.AddBalance(sender, decodedCheck.Coin, decodedCheck.Value)
+		deliverState.Accounts.SubBalance(checkSender, decodedCheck.Coin, value)
+		deliverState.Accounts.AddBalance(sender, decodedCheck.Coin, value)
 		deliverState.Accounts.SetNonce(sender, tx.Nonce)
 	}
 
@@ -236,6 +288,8 @@ func (data RedeemCheckData) Run(tx *Transaction, context state.Interface, reward
 		kv.Pair{Key: []byte("tx.from"), Value: []byte(hex.EncodeToString(checkSender[:]))},
 		kv.Pair{Key: []byte("tx.to"), Value: []byte(hex.EncodeToString(sender[:]))},
 		kv.Pair{Key: []byte("tx.coin_id"), Value: []byte(decodedCheck.Coin.String())},
+		kv.Pair{Key: []byte("tx.value"), Value: []byte(value.String())},
+		kv.Pair{Key: []byte("tx.check_hash"), Value: []byte(hex.EncodeToString(decodedCheck.Hash().Bytes()))},
 	}
 
 	return Response{
@@ -1,7 +1,9 @@
 package transaction
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/noah-blockchain/noah-go-node/core/code"
@@ -21,26 +23,28 @@ type TxType byte
 type SigType byte
 
 const (
-	TypeSend                   TxType = 0x01
-	TypeSellCoin               TxType = 0x02
-	TypeSellAllCoin            TxType = 0x03
-	TypeBuyCoin                TxType = 0x04
-	TypeCreateCoin             TxType = 0x05
-	TypeDeclareCandidacy       TxType = 0x06
-	TypeDelegate               TxType = 0x07
-	TypeUnbond                 TxType = 0x08
-	TypeRedeemCheck            TxType = 0x09
-	TypeSetCandidateOnline     TxType = 0x0A
-	TypeSetCandidateOffline    TxType = 0x0B
-	TypeCreateMultisig         TxType = 0x0C
-	TypeMultisend              TxType = 0x0D
-	TypeEditCandidate          TxType = 0x0E
-	TypeSetHaltBlock           TxType = 0x0F
-	TypeRecreateCoin           TxType = 0x10
-	TypeEditCoinOwner          TxType = 0x11
-	TypeEditMultisig           TxType = 0x12
-	TypePriceVote              TxType = 0x13
-	TypeEditCandidatePublicKey TxType = 0x14
+	TypeSend                    TxType = 0x01
+	TypeSellCoin                TxType = 0x02
+	TypeSellAllCoin             TxType = 0x03
+	TypeBuyCoin                 TxType = 0x04
+	TypeCreateCoin              TxType = 0x05
+	TypeDeclareCandidacy        TxType = 0x06
+	TypeDelegate                TxType = 0x07
+	TypeUnbond                  TxType = 0x08
+	TypeRedeemCheck             TxType = 0x09
+	TypeSetCandidateOnline      TxType = 0x0A
+	TypeSetCandidateOffline     TxType = 0x0B
+	TypeCreateMultisig          TxType = 0x0C
+	TypeMultisend               TxType = 0x0D
+	TypeEditCandidate           TxType = 0x0E
+	TypeSetHaltBlock            TxType = 0x0F
+	TypeRecreateCoin            TxType = 0x10
+	TypeEditCoinOwner           TxType = 0x11
+	TypeEditMultisig            TxType = 0x12
+	TypePriceVote               TxType = 0x13
+	TypeEditCandidatePublicKey  TxType = 0x14
+	TypeEditCandidateCommission TxType = 0x15
+	TypeCancelCheck             TxType = 0x16
 
 	SigTypeSingle SigType = 0x01
 	SigTypeMulti  SigType = 0x02
@@ -50,10 +54,42 @@ var (
 	ErrInvalidSig = errors.New("invalid transaction v, r, s values")
 )
 
+// TxTypeByName maps names of transaction types used in node configuration to their values
+var TxTypeByName = map[string]TxType{
+	"send":                      TypeSend,
+	"sell_coin":                 TypeSellCoin,
+	"sell_all_coin":             TypeSellAllCoin,
+	"buy_coin":                  TypeBuyCoin,
+	"create_coin":               TypeCreateCoin,
+	"declare_candidacy":         TypeDeclareCandidacy,
+	"delegate":                  TypeDelegate,
+	"unbond":                    TypeUnbond,
+	"redeem_check":              TypeRedeemCheck,
+	"set_candidate_online":      TypeSetCandidateOnline,
+	"set_candidate_offline":     TypeSetCandidateOffline,
+	"create_multisig":           TypeCreateMultisig,
+	"multisend":                 TypeMultisend,
+	"edit_candidate":            TypeEditCandidate,
+	"set_halt_block":            TypeSetHaltBlock,
+	"recreate_coin":             TypeRecreateCoin,
+	"edit_coin_owner":           TypeEditCoinOwner,
+	"edit_multisig":             TypeEditMultisig,
+	"price_vote":                TypePriceVote,
+	"edit_candidate_public_key": TypeEditCandidatePublicKey,
+	"edit_candidate_commission": TypeEditCandidateCommission,
+	"cancel_check":              TypeCancelCheck,
+}
+
 var (
 	CommissionMultiplier = big.NewInt(10e14)
 )
 
+// DefaultValidityPeriod is the number of blocks suggested to clients as tx validity period
+const DefaultValidityPeriod = 120
+
+// validUntilPrefix marks service data which carries the last height tx can be included in
+var validUntilPrefix = []byte("valid_until:")
+
 type Transaction struct {
 	Nonce         uint64
 	ChainID       types.ChainID
@@ -140,6 +176,22 @@ func (tx *Transaction) CommissionInBaseCoin() *big.Int {
 	return commissionInBaseCoin
 }
 
+// ValidUntil returns the last height tx can be included in, 0 if tx has no expiry
+func (tx *Transaction) ValidUntil() uint64 {
+	if len(tx.ServiceData) != len(validUntilPrefix)+8 || !bytes.HasPrefix(tx.ServiceData, validUntilPrefix) {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(tx.ServiceData[len(validUntilPrefix):])
+}
+
+// SetValidUntil stores the last height tx can be included in to service data of tx
+func (tx *Transaction) SetValidUntil(height uint64) {
+	tx.ServiceData = make([]byte, len(validUntilPrefix)+8)
+	copy(tx.ServiceData, validUntilPrefix)
+	binary.BigEndian.PutUint64(tx.ServiceData[len(validUntilPrefix):], height)
+}
+
 func (tx *Transaction) String() string {
 	sender, _ := tx.Sender()
 
@@ -0,0 +1,82 @@
+package transaction
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+	"github.com/noah-blockchain/noah-go-node/upgrades"
+)
+
+func TestTransaction_ValidUntil(t *testing.T) {
+	tx := Transaction{}
+	if tx.ValidUntil() != 0 {
+		t.Fatal("Tx without service data has expiry")
+	}
+
+	tx.ServiceData = []byte("some service data")
+	if tx.ValidUntil() != 0 {
+		t.Fatal("Tx with arbitrary service data has expiry")
+	}
+
+	tx.SetValidUntil(100)
+	if tx.ValidUntil() != 100 {
+		t.Fatalf("Wrong expiry. Expected %d, got %d", 100, tx.ValidUntil())
+	}
+}
+
+func TestExpiredTx(t *testing.T) {
+	defer activateUpgrades(upgrades.TxExpiry)()
+
+	cState := getState()
+
+	privateKey, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	coin := types.GetBaseCoinID()
+
+	cState.Accounts.AddBalance(addr, coin, helpers.NoahToQNoah(big.NewInt(1000000)))
+
+	encodedData, err := rlp.EncodeToBytes(SendData{
+		Coin:  coin,
+		To:    types.Address([20]byte{1}),
+		Value: helpers.NoahToQNoah(big.NewInt(10)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       coin,
+		Type:          TypeSend,
+		Data:          encodedData,
+		SignatureType: SigTypeSingle,
+	}
+	tx.SetValidUntil(100)
+
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	encodedTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := RunTx(cState, encodedTx, big.NewInt(0), 101, &sync.Map{}, 0)
+	if response.Code != code.TxExpired {
+		t.Fatalf("Response code is not %d. Error %s", code.TxExpired, response.Log)
+	}
+
+	response = RunTx(cState, encodedTx, big.NewInt(0), 100, &sync.Map{}, 0)
+	if response.Code != code.OK {
+		t.Fatalf("Response code is not 0. Error %s", response.Log)
+	}
+}
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"github.com/noah-blockchain/noah-go-node/helpers"
 	"math/big"
+	"sort"
 )
 
 type AppState struct {
@@ -21,229 +23,290 @@ type AppState struct {
 	UsedChecks          []UsedCheck  `json:"used_checks,omitempty"`
 	MaxGas              uint64       `json:"max_gas"`
 	TotalSlashed        string       `json:"total_slashed"`
+
+	// PartialChecks are remaining values of partially redeemed checks
+	PartialChecks []PartialCheck `json:"partial_checks,omitempty"`
+
+	// StateFile refers to the state exported to a separate file, sections of app state are empty in this case
+	StateFile *StateFile `json:"state_file,omitempty"`
+}
+
+// StateFile is the state written by streaming export, see State.ExportStream
+type StateFile struct {
+	Name   string `json:"name"`
+	Sha256 string `json:"sha256"`
 }
 
 func (s *AppState) Verify() error {
-	if !helpers.IsValidBigInt(s.TotalSlashed) {
-		return fmt.Errorf("total slashed is not valid BigInt")
-	}
+	v := NewAppStateVerifier()
 
-	if len(s.Validators) < 1 {
-		return fmt.Errorf("there should be at least one validator")
+	for i := range s.Validators {
+		if err := v.VerifyItem(&s.Validators[i]); err != nil {
+			return err
+		}
 	}
 
-	validators := map[Pubkey]struct{}{}
-	for _, val := range s.Validators {
-		// check for validators duplication
-		if _, exists := validators[val.PubKey]; exists {
-			return fmt.Errorf("duplicated validator %s", val.PubKey.String())
+	for i := range s.Accounts {
+		if err := v.VerifyItem(&s.Accounts[i]); err != nil {
+			return err
 		}
+	}
 
-		validators[val.PubKey] = struct{}{}
+	for i := range s.Candidates {
+		if err := v.VerifyItem(&s.Candidates[i]); err != nil {
+			return err
+		}
+	}
 
-		// search for candidate
-		foundCandidate := false
-		for _, candidate := range s.Candidates {
-			if candidate.PubKey == val.PubKey {
-				foundCandidate = true
-				break
-			}
+	for i := range s.Coins {
+		if err := v.VerifyItem(&s.Coins[i]); err != nil {
+			return err
 		}
+	}
 
-		if !foundCandidate {
-			return fmt.Errorf("candidate for validator %s not found", val.PubKey.String())
+	for i := range s.Waitlist {
+		if err := v.VerifyItem(&s.Waitlist[i]); err != nil {
+			return err
 		}
+	}
 
-		// basic checks
-		if !helpers.IsValidBigInt(val.TotalNoahStake) {
-			return fmt.Errorf("total noah stake of validator %s is not valid", val.PubKey.String())
+	for i := range s.FrozenFunds {
+		if err := v.VerifyItem(&s.FrozenFunds[i]); err != nil {
+			return err
 		}
+	}
 
-		if !helpers.IsValidBigInt(val.AccumReward) {
-			return fmt.Errorf("accum reward of validator %s is not valid", val.PubKey.String())
+	for i := range s.UsedChecks {
+		if err := v.VerifyItem(&s.UsedChecks[i]); err != nil {
+			return err
 		}
+	}
 
-		if val.AbsentTimes == nil {
-			return fmt.Errorf("absent times of validator %s is not valid", val.PubKey.String())
+	for i := range s.PartialChecks {
+		if err := v.VerifyItem(&s.PartialChecks[i]); err != nil {
+			return err
 		}
 	}
 
-	accounts := map[Address]struct{}{}
-	for _, acc := range s.Accounts {
+	return v.Finish(s)
+}
+
+// AppStateVerifier verifies app state item by item, so the state can be verified while it is read from a stream.
+// Only keys of validators, candidates, accounts and volumes of coins are kept in memory.
+type AppStateVerifier struct {
+	validators map[Pubkey]struct{}
+	candidates map[Pubkey]struct{}
+	accounts   map[Address]struct{}
+
+	// coins are declared coins, volumes are sums of all amounts of coins held in the state
+	coins   map[uint64]Coin
+	volumes map[uint64]*big.Int
+}
+
+// NewAppStateVerifier creates verifier of app state, see AppState.Verify
+func NewAppStateVerifier() *AppStateVerifier {
+	return &AppStateVerifier{
+		validators: map[Pubkey]struct{}{},
+		candidates: map[Pubkey]struct{}{},
+		accounts:   map[Address]struct{}{},
+		coins:      map[uint64]Coin{},
+		volumes:    map[uint64]*big.Int{},
+	}
+}
+
+// VerifyItem verifies item of a section of app state. Items may come in any order,
+// the checks which depend on other sections are done in Finish.
+func (v *AppStateVerifier) VerifyItem(item interface{}) error {
+	switch item := item.(type) {
+	case *Validator:
+		// check for validators duplication
+		if _, exists := v.validators[item.PubKey]; exists {
+			return fmt.Errorf("duplicated validator %s", item.PubKey.String())
+		}
+
+		v.validators[item.PubKey] = struct{}{}
+
+		// basic checks
+		if !helpers.IsValidBigInt(item.TotalNoahStake) {
+			return fmt.Errorf("total noah stake of validator %s is not valid", item.PubKey.String())
+		}
+
+		if !helpers.IsValidBigInt(item.AccumReward) {
+			return fmt.Errorf("accum reward of validator %s is not valid", item.PubKey.String())
+		}
+
+		if item.AbsentTimes == nil {
+			return fmt.Errorf("absent times of validator %s is not valid", item.PubKey.String())
+		}
+	case *Account:
 		// check for account duplication
-		if _, exists := accounts[acc.Address]; exists {
-			return fmt.Errorf("duplicated account %s", acc.Address.String())
+		if _, exists := v.accounts[item.Address]; exists {
+			return fmt.Errorf("duplicated account %s", item.Address.String())
 		}
 
-		accounts[acc.Address] = struct{}{}
+		v.accounts[item.Address] = struct{}{}
 
-		for _, bal := range acc.Balance {
+		for _, bal := range item.Balance {
 			if !helpers.IsValidBigInt(bal.Value) {
-				return fmt.Errorf("not valid balance for account %s", acc.Address.String())
+				return fmt.Errorf("not valid balance for account %s", item.Address.String())
 			}
 
-			coinID := CoinID(bal.Coin)
-			if !coinID.IsBaseCoin() {
-				// check not existing coins
-				foundCoin := false
-				for _, coin := range s.Coins {
-					id := CoinID(coin.ID)
-					if id == coinID {
-						foundCoin = true
-						break
-					}
-				}
-
-				if !foundCoin {
-					return fmt.Errorf("coin %s not found", coinID)
-				}
-			}
+			v.addVolume(bal.Coin, bal.Value)
+		}
+	case *Candidate:
+		if item.CommissionUpdate != nil && item.CommissionUpdate.Commission > 100 {
+			return fmt.Errorf("wrong commission update of candidate %s", item.PubKey.String())
 		}
-	}
 
-	for _, candidate := range s.Candidates {
+		v.candidates[item.PubKey] = struct{}{}
+
 		stakes := map[string]struct{}{}
-		for _, stake := range candidate.Stakes {
+		for _, stake := range item.Stakes {
 			// check duplicated stakes
-			coinID := CoinID(stake.Coin)
-			key := fmt.Sprintf("%s:%s", stake.Owner.String(), coinID.String())
+			key := fmt.Sprintf("%s:%s", stake.Owner.String(), CoinID(stake.Coin).String())
 			if _, exists := stakes[key]; exists {
 				return fmt.Errorf("duplicated stake %s", key)
 			}
 			stakes[key] = struct{}{}
 
-			// check not existing coins
-			if !coinID.IsBaseCoin() {
-				foundCoin := false
-				for _, coin := range s.Coins {
-					id := CoinID(coin.ID)
-					if id == coinID {
-						foundCoin = true
-						break
-					}
-				}
-
-				if !foundCoin {
-					return fmt.Errorf("coin %s not found", coinID)
-				}
-			}
+			v.addVolume(stake.Coin, stake.Value)
 		}
-	}
 
-	coins := map[uint64]struct{}{}
-	for _, coin := range s.Coins {
-		if coin.Symbol.IsBaseCoin() {
+		for _, stake := range item.Updates {
+			v.addVolume(stake.Coin, stake.Value)
+		}
+	case *Coin:
+		if item.Symbol.IsBaseCoin() {
 			return fmt.Errorf("base coin should not be declared")
 		}
 
 		// check duplicated coins
-		if _, exists := coins[coin.ID]; exists {
-			return fmt.Errorf("duplicated coin %s", coin.Symbol)
+		if _, exists := v.coins[item.ID]; exists {
+			return fmt.Errorf("duplicated coin %s", item.Symbol)
 		}
 
-		coins[coin.ID] = struct{}{}
-
-		// check coins' volume
-		volume := big.NewInt(0)
-		for _, ff := range s.FrozenFunds {
-			if ff.Coin == coin.ID {
-				volume.Add(volume, helpers.StringToBigInt(ff.Value))
-			}
+		v.coins[item.ID] = Coin{ID: item.ID, Symbol: item.Symbol, Volume: item.Volume}
+	case *Waitlist:
+		if !helpers.IsValidBigInt(item.Value) {
+			return fmt.Errorf("wrong waitlist value: %s", item.Value)
 		}
 
-		for _, candidate := range s.Candidates {
-			for _, stake := range candidate.Stakes {
-				if stake.Coin == coin.ID {
-					volume.Add(volume, helpers.StringToBigInt(stake.Value))
-				}
-			}
+		v.addVolume(item.Coin, item.Value)
+	case *FrozenFund:
+		if !helpers.IsValidBigInt(item.Value) {
+			return fmt.Errorf("wrong frozen fund value: %s", item.Value)
+		}
 
-			for _, stake := range candidate.Updates {
-				if stake.Coin == coin.ID {
-					volume.Add(volume, helpers.StringToBigInt(stake.Value))
-				}
-			}
+		v.addVolume(item.Coin, item.Value)
+	case *UsedCheck:
+		// check used checks length
+		b, err := hex.DecodeString(string(*item))
+		if err != nil {
+			return err
 		}
 
-		for _, account := range s.Accounts {
-			for _, bal := range account.Balance {
-				if bal.Coin == coin.ID {
-					volume.Add(volume, helpers.StringToBigInt(bal.Value))
-				}
-			}
+		if len(b) != 32 {
+			return fmt.Errorf("wrong used check size %s", *item)
+		}
+	case *PartialCheck:
+		b, err := hex.DecodeString(item.Hash)
+		if err != nil {
+			return err
 		}
 
-		for _, wl := range s.Waitlist {
-			if wl.Coin == coin.ID {
-				volume.Add(volume, helpers.StringToBigInt(wl.Value))
-			}
+		if len(b) != 32 {
+			return fmt.Errorf("wrong partial check size %s", item.Hash)
 		}
 
-		if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
-			return fmt.Errorf("wrong coin %s volume (%s)", coin.Symbol.String(), big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
+		if !helpers.IsValidBigInt(item.Value) {
+			return fmt.Errorf("partial check %s has invalid value", item.Hash)
 		}
 	}
 
-	for _, wl := range s.Waitlist {
-		if !helpers.IsValidBigInt(wl.Value) {
-			return fmt.Errorf("wrong waitlist value: %s", wl.Value)
-		}
+	return nil
+}
 
-		// check not existing coins
-		coinID := CoinID(wl.Coin)
-		if !coinID.IsBaseCoin() {
-			foundCoin := false
-			for _, coin := range s.Coins {
-				if CoinID(coin.ID) == coinID {
-					foundCoin = true
-					break
-				}
-			}
+// Finish verifies header values of the state and the checks of verified items which depend on other sections
+func (v *AppStateVerifier) Finish(header *AppState) error {
+	if !helpers.IsValidBigInt(header.TotalSlashed) {
+		return fmt.Errorf("total slashed is not valid BigInt")
+	}
 
-			if !foundCoin {
-				return fmt.Errorf("coin %s not found", coinID)
-			}
-		}
+	if len(v.validators) < 1 {
+		return fmt.Errorf("there should be at least one validator")
 	}
 
-	for _, ff := range s.FrozenFunds {
-		if !helpers.IsValidBigInt(ff.Value) {
-			return fmt.Errorf("wrong frozen fund value: %s", ff.Value)
-		}
-
-		// check not existing coins
-		coinID := CoinID(ff.Coin)
-		if !coinID.IsBaseCoin() {
-			foundCoin := false
-			for _, coin := range s.Coins {
-				id := CoinID(coin.ID)
-				if id == coinID {
-					foundCoin = true
-					break
-				}
-			}
+	for _, pubKey := range sortedPubkeys(v.validators) {
+		if _, exists := v.candidates[pubKey]; !exists {
+			return fmt.Errorf("candidate for validator %s not found", pubKey.String())
+		}
+	}
 
-			if !foundCoin {
-				return fmt.Errorf("coin %s not found", coinID)
-			}
+	// check not existing coins
+	for _, id := range sortedCoins(v.volumes) {
+		if _, exists := v.coins[id]; !exists && !CoinID(id).IsBaseCoin() {
+			return fmt.Errorf("coin %s not found", CoinID(id))
 		}
 	}
 
-	// check used checks length
-	for _, check := range s.UsedChecks {
-		b, err := hex.DecodeString(string(check))
-		if err != nil {
-			return err
+	// check coins' volume
+	ids := make([]uint64, 0, len(v.coins))
+	for id := range v.coins {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	for _, id := range ids {
+		coin := v.coins[id]
+		volume := v.volumes[id]
+		if volume == nil {
+			volume = big.NewInt(0)
 		}
 
-		if len(b) != 32 {
-			return fmt.Errorf("wrong used check size %s", check)
+		if volume.Cmp(helpers.StringToBigInt(coin.Volume)) != 0 {
+			return fmt.Errorf("wrong coin %s volume (%s)", coin.Symbol.String(), big.NewInt(0).Sub(volume, helpers.StringToBigInt(coin.Volume)))
 		}
 	}
 
 	return nil
 }
 
+func (v *AppStateVerifier) addVolume(coin uint64, value string) {
+	if v.volumes[coin] == nil {
+		v.volumes[coin] = big.NewInt(0)
+	}
+
+	v.volumes[coin].Add(v.volumes[coin], helpers.StringToBigInt(value))
+}
+
+func sortedPubkeys(set map[Pubkey]struct{}) []Pubkey {
+	keys := make([]Pubkey, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	return keys
+}
+
+func sortedCoins(coins map[uint64]*big.Int) []uint64 {
+	ids := make([]uint64, 0, len(coins))
+	for id := range coins {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
 type Validator struct {
 	TotalNoahStake string    `json:"total_noah_stake"`
 	PubKey        Pubkey    `json:"public_key"`
@@ -262,6 +325,13 @@ type Candidate struct {
 	Stakes         []Stake `json:"stakes"`
 	Updates        []Stake `json:"updates"`
 	Status         uint64  `json:"status"`
+
+	CommissionUpdate *CommissionUpdate `json:"commission_update,omitempty"`
+}
+
+type CommissionUpdate struct {
+	Commission uint64 `json:"commission"`
+	Height     uint64 `json:"height"`
 }
 
 type Stake struct {
@@ -301,6 +371,12 @@ type FrozenFund struct {
 
 type UsedCheck string
 
+// PartialCheck is remaining value of partially redeemed check
+type PartialCheck struct {
+	Hash  string `json:"hash"`
+	Value string `json:"value"`
+}
+
 type Account struct {
 	Address      Address   `json:"address"`
 	Balance      []Balance `json:"balance"`
@@ -323,3 +399,47 @@ type HaltBlock struct {
 	Height       uint64 `json:"height"`
 	CandidateKey Pubkey `json:"candidate_key"`
 }
+
+// RecalculateCoinsVolume sets volume of every coin to the sum of all its amounts held in the state
+func (s *AppState) RecalculateCoinsVolume() {
+	volumes := map[uint64]*big.Int{}
+	add := func(coin uint64, value string) {
+		if volumes[coin] == nil {
+			volumes[coin] = big.NewInt(0)
+		}
+		volumes[coin].Add(volumes[coin], helpers.StringToBigInt(value))
+	}
+
+	for _, ff := range s.FrozenFunds {
+		add(ff.Coin, ff.Value)
+	}
+
+	for _, candidate := range s.Candidates {
+		for _, stake := range candidate.Stakes {
+			add(stake.Coin, stake.Value)
+		}
+
+		for _, stake := range candidate.Updates {
+			add(stake.Coin, stake.Value)
+		}
+	}
+
+	for _, item := range s.Waitlist {
+		add(item.Coin, item.Value)
+	}
+
+	for _, account := range s.Accounts {
+		for _, balance := range account.Balance {
+			add(balance.Coin, balance.Value)
+		}
+	}
+
+	for i, coin := range s.Coins {
+		volume := volumes[coin.ID]
+		if volume == nil {
+			volume = big.NewInt(0)
+		}
+
+		s.Coins[i].Volume = volume.String()
+	}
+}
@@ -0,0 +1,561 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/appdb"
+	"github.com/noah-blockchain/noah-go-node/core/check"
+	"github.com/noah-blockchain/noah-go-node/core/code"
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	tmLog "github.com/tendermint/tendermint/libs/log"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// Types of payloads
+const (
+	TypeFundsReceived    = "funds_received"
+	TypeCandidateOffline = "candidate_offline"
+	TypeStakeKick        = "stake_kick"
+)
+
+// Sources of received funds
+const (
+	SourceTx     = "tx"
+	SourceReward = "reward"
+	SourceUnbond = "unbond"
+)
+
+// Headers of requests to webhooks
+const (
+	SignatureHeader = "X-Noah-Signature"
+	DeliveryHeader  = "X-Noah-Delivery"
+)
+
+const (
+	deliveryInterval = time.Second
+	maxRetryDelay    = time.Hour
+
+	// deliveryBatch is the max number of payloads read from the outbox of a webhook at once
+	deliveryBatch = 100
+)
+
+var (
+	ErrInvalidURL   = errors.New("webhook URL should be absolute http or https URL")
+	ErrEmptyWatches = errors.New("webhook should watch at least one address or candidate")
+)
+
+// Payload is JSON body posted to webhooks. The same payload may be delivered more than once,
+// e.g. if the node is restarted, receivers should skip payloads with known IDs.
+type Payload struct {
+	ID     string      `json:"id"`
+	Type   string      `json:"type"`
+	Height uint64      `json:"height"`
+	Data   interface{} `json:"data"`
+}
+
+// FundsReceived is data of payload about coins received by a watched address: sent by send, multisend or
+// redeem check transaction, paid as reward or returned from unbonded stake. Rewards and unbonds have validator
+// public key instead of sender address and transaction hash.
+type FundsReceived struct {
+	Address string `json:"address"`
+	Source  string `json:"source"`
+	From    string `json:"from,omitempty"`
+	PubKey  string `json:"pub_key,omitempty"`
+	Coin    uint64 `json:"coin"`
+	Value   string `json:"value"`
+	TxHash  string `json:"tx_hash,omitempty"`
+}
+
+// CandidateOffline is data of payload about a watched candidate switched off for missed blocks
+type CandidateOffline struct {
+	PubKey string `json:"pub_key"`
+}
+
+// StakeKick is data of payload about a stake moved to the waitlist, it is sent if the owner or the candidate is watched
+type StakeKick struct {
+	Address string `json:"address"`
+	PubKey  string `json:"pub_key"`
+	Coin    uint64 `json:"coin"`
+	Value   string `json:"value"`
+}
+
+type notification struct {
+	typ     string
+	address *types.Address
+	pubKey  *types.Pubkey
+	data    interface{}
+}
+
+// Notifier collects activity of watched addresses and candidates in blocks, writes payloads to the outbox
+// of app database on commit and delivers them to webhooks in background
+type Notifier struct {
+	appDB       *appdb.AppDB
+	client      *http.Client
+	maxAttempts uint32
+	logger      tmLog.Logger
+
+	lock     sync.RWMutex
+	webhooks []appdb.Webhook
+
+	// notifications of the current block, accessed only from consensus
+	pending []notification
+
+	// webhooks which are being delivered and times of the next attempts of webhooks in backoff, guarded by lock
+	busy    map[uint64]bool
+	backoff map[uint64]time.Time
+	workers sync.WaitGroup
+
+	// ctx is canceled on stop to abort requests which are in progress
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	wakeup chan struct{}
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+// NewNotifier creates Notifier of webhooks stored in the app database
+func NewNotifier(appDB *appdb.AppDB, maxAttempts int, timeout time.Duration) *Notifier {
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Notifier{
+		appDB:       appDB,
+		client:      &http.Client{Timeout: timeout},
+		maxAttempts: uint32(maxAttempts),
+		logger:      tmLog.NewNopLogger(),
+		webhooks:    appDB.GetWebhooks(),
+		busy:        map[uint64]bool{},
+		backoff:     map[uint64]time.Time{},
+		ctx:         ctx,
+		cancel:      cancel,
+		wakeup:      make(chan struct{}, 1),
+		quit:        make(chan struct{}),
+	}
+}
+
+// SetLogger sets logger of deliveries
+func (n *Notifier) SetLogger(logger tmLog.Logger) {
+	n.logger = logger
+}
+
+// Add registers the webhook watching the addresses and candidates
+func (n *Notifier) Add(rawURL, secret string, addresses []types.Address, pubKeys []types.Pubkey) (appdb.Webhook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return appdb.Webhook{}, ErrInvalidURL
+	}
+
+	if len(addresses) == 0 && len(pubKeys) == 0 {
+		return appdb.Webhook{}, ErrEmptyWatches
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	webhook := n.appDB.AddWebhook(appdb.Webhook{
+		URL:       rawURL,
+		Secret:    secret,
+		Addresses: addresses,
+		PubKeys:   pubKeys,
+	})
+	n.webhooks = append(n.webhooks, webhook)
+
+	return webhook, nil
+}
+
+// Remove unregisters the webhook and drops its pending deliveries, returns false if there is no such webhook
+func (n *Notifier) Remove(id uint64) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if !n.appDB.RemoveWebhook(id) {
+		return false
+	}
+
+	n.webhooks = n.appDB.GetWebhooks()
+	delete(n.backoff, id)
+
+	return true
+}
+
+// Webhooks returns registered webhooks
+func (n *Notifier) Webhooks() []appdb.Webhook {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return append([]appdb.Webhook(nil), n.webhooks...)
+}
+
+// PendingDeliveries returns number of payloads in the outbox by webhook IDs, webhooks without payloads are omitted
+func (n *Notifier) PendingDeliveries() map[uint64]int {
+	pending := map[uint64]int{}
+	for _, webhook := range n.Webhooks() {
+		if count := n.appDB.CountWebhookDeliveries(webhook.ID); count != 0 {
+			pending[webhook.ID] = count
+		}
+	}
+
+	return pending
+}
+
+func (n *Notifier) hasWebhooks() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	return len(n.webhooks) != 0
+}
+
+// BeginBlock drops notifications collected for the previous block
+func (n *Notifier) BeginBlock() {
+	n.pending = nil
+}
+
+// AddTx collects coins received by addresses in the delivered transaction
+func (n *Notifier) AddTx(rawTx []byte, response transaction.Response) {
+	if response.Code != code.OK || !n.hasWebhooks() {
+		return
+	}
+
+	tx, err := transaction.TxDecoder.DecodeFromBytes(rawTx)
+	if err != nil {
+		return
+	}
+
+	sender, err := tx.Sender()
+	if err != nil {
+		return
+	}
+
+	hash := "Mt" + strings.ToLower(hex.EncodeToString(tmTypes.Tx(rawTx).Hash()))
+	switch data := tx.GetDecodedData().(type) {
+	case *transaction.SendData:
+		n.fundsReceived(data.To, sender, data.Coin, data.Value.String(), hash)
+	case *transaction.MultisendData:
+		for _, item := range data.List {
+			n.fundsReceived(item.To, sender, item.Coin, item.Value.String(), hash)
+		}
+	case *transaction.RedeemCheckData:
+		decodedCheck, err := check.DecodeFromBytes(data.RawCheck)
+		if err != nil {
+			return
+		}
+
+		issuer, err := decodedCheck.Sender()
+		if err != nil {
+			return
+		}
+
+		// partially redeemed checks have the value in tags only
+		value := decodedCheck.Value.String()
+		for _, tag := range response.Tags {
+			if string(tag.Key) == "tx.value" {
+				value = string(tag.Value)
+			}
+		}
+
+		n.fundsReceived(sender, issuer, decodedCheck.Coin, value, hash)
+	}
+}
+
+func (n *Notifier) fundsReceived(to, from types.Address, coin types.CoinID, value string, hash string) {
+	n.pending = append(n.pending, notification{
+		typ:     TypeFundsReceived,
+		address: &to,
+		data: FundsReceived{
+			Address: to.String(),
+			Source:  SourceTx,
+			From:    from.String(),
+			Coin:    uint64(coin),
+			Value:   value,
+			TxHash:  hash,
+		},
+	})
+}
+
+// CandidateOffline collects the candidate switched off for missed blocks
+func (n *Notifier) CandidateOffline(pubKey types.Pubkey) {
+	if !n.hasWebhooks() {
+		return
+	}
+
+	n.pending = append(n.pending, notification{
+		typ:    TypeCandidateOffline,
+		pubKey: &pubKey,
+		data:   CandidateOffline{PubKey: pubKey.String()},
+	})
+}
+
+// Commit collects rewards, unbonds and stakes kicked to the waitlist from events of the block and writes payloads of all notifications
+// of the block to the outbox. IDs of payloads depend on the height only, so the block committed again after restart
+// replaces its payloads which are not delivered yet.
+func (n *Notifier) Commit(height uint64, events eventsdb.Events) {
+	pending := n.pending
+	n.pending = nil
+
+	n.lock.RLock()
+	webhooks := n.webhooks
+	n.lock.RUnlock()
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		switch event := event.(type) {
+		case *eventsdb.RewardEvent:
+			address := event.Address
+			pending = append(pending, notification{
+				typ:     TypeFundsReceived,
+				address: &address,
+				data: FundsReceived{
+					Address: address.String(),
+					Source:  SourceReward,
+					PubKey:  event.ValidatorPubKey.String(),
+					Coin:    uint64(types.GetBaseCoinID()),
+					Value:   event.Amount,
+				},
+			})
+		case *eventsdb.UnbondEvent:
+			address := event.Address
+			pending = append(pending, notification{
+				typ:     TypeFundsReceived,
+				address: &address,
+				data: FundsReceived{
+					Address: address.String(),
+					Source:  SourceUnbond,
+					PubKey:  event.ValidatorPubKey.String(),
+					Coin:    event.Coin,
+					Value:   event.Amount,
+				},
+			})
+		case *eventsdb.StakeKickEvent:
+			address, pubKey := event.Address, event.ValidatorPubKey
+			pending = append(pending, notification{
+				typ:     TypeStakeKick,
+				address: &address,
+				pubKey:  &pubKey,
+				data: StakeKick{
+					Address: address.String(),
+					PubKey:  pubKey.String(),
+					Coin:    event.Coin,
+					Value:   event.Amount,
+				},
+			})
+		}
+	}
+
+	added := false
+	for i, item := range pending {
+		for _, webhook := range webhooks {
+			if !watches(webhook, item) {
+				continue
+			}
+
+			id := fmt.Sprintf("%020d-%d-%d", height, webhook.ID, i)
+			payload, err := json.Marshal(Payload{ID: id, Type: item.typ, Height: height, Data: item.data})
+			if err != nil {
+				panic(err)
+			}
+
+			n.appDB.SetWebhookDelivery(appdb.WebhookDelivery{
+				ID:        id,
+				WebhookID: webhook.ID,
+				Payload:   payload,
+			})
+			added = true
+		}
+	}
+
+	if added {
+		select {
+		case n.wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func watches(webhook appdb.Webhook, item notification) bool {
+	if item.address != nil {
+		for _, address := range webhook.Addresses {
+			if address == *item.address {
+				return true
+			}
+		}
+	}
+
+	if item.pubKey != nil {
+		for _, pubKey := range webhook.PubKeys {
+			if pubKey == *item.pubKey {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Start runs delivery of payloads from the outbox in background
+func (n *Notifier) Start() {
+	n.done = make(chan struct{})
+	go func() {
+		defer close(n.done)
+
+		ticker := time.NewTicker(deliveryInterval)
+		defer ticker.Stop()
+
+		for {
+			n.deliver(time.Now())
+
+			select {
+			case <-n.quit:
+				return
+			case <-n.wakeup:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop aborts requests in progress and stops the background delivery, it does nothing if delivery is not started
+func (n *Notifier) Stop() {
+	if n.done == nil {
+		return
+	}
+
+	close(n.quit)
+	n.cancel()
+	<-n.done
+	n.workers.Wait()
+	n.done = nil
+}
+
+// deliver starts delivery to every webhook which is neither in backoff nor being delivered already.
+// Webhooks are delivered concurrently, so a slow webhook does not delay others.
+func (n *Notifier) deliver(now time.Time) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for _, webhook := range n.webhooks {
+		if n.busy[webhook.ID] || n.backoff[webhook.ID].After(now) {
+			continue
+		}
+
+		n.busy[webhook.ID] = true
+		n.workers.Add(1)
+		go func(webhook appdb.Webhook) {
+			defer n.workers.Done()
+
+			nextAttempt := n.deliverTo(webhook, now)
+
+			n.lock.Lock()
+			defer n.lock.Unlock()
+
+			delete(n.busy, webhook.ID)
+			if nextAttempt.IsZero() {
+				delete(n.backoff, webhook.ID)
+			} else {
+				n.backoff[webhook.ID] = nextAttempt
+			}
+		}(webhook)
+	}
+}
+
+// deliverTo sends payloads of the webhook one by one in order of their IDs until a payload fails or is not due yet.
+// Failed payloads are retried with exponential delay, the webhook is in backoff until the returned time of the next attempt,
+// zero time is returned if there is no payload to wait for.
+func (n *Notifier) deliverTo(webhook appdb.Webhook, now time.Time) time.Time {
+	for _, delivery := range n.appDB.GetWebhookDeliveries(webhook.ID, deliveryBatch) {
+		if n.ctx.Err() != nil {
+			return time.Time{}
+		}
+
+		if delivery.NextAttempt.After(now) {
+			return delivery.NextAttempt
+		}
+
+		err := n.post(webhook, delivery)
+		if n.ctx.Err() != nil {
+			// the node is stopping, the payload is not counted as failed
+			return time.Time{}
+		}
+
+		if err == nil {
+			n.appDB.DeleteWebhookDelivery(webhook.ID, delivery.ID)
+			continue
+		}
+
+		delivery.Attempts++
+		if delivery.Attempts >= n.maxAttempts {
+			n.logger.Error("Webhook payload dropped", "webhook", webhook.ID, "id", delivery.ID, "attempts", delivery.Attempts, "err", err)
+			n.appDB.DeleteWebhookDelivery(webhook.ID, delivery.ID)
+			continue
+		}
+
+		n.logger.Info("Webhook delivery failed", "webhook", webhook.ID, "id", delivery.ID, "attempts", delivery.Attempts, "err", err)
+		delivery.NextAttempt = now.Add(retryDelay(delivery.Attempts))
+		n.appDB.SetWebhookDelivery(delivery)
+
+		return delivery.NextAttempt
+	}
+
+	return time.Time{}
+}
+
+func (n *Notifier) post(webhook appdb.Webhook, delivery appdb.WebhookDelivery) error {
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	request = request.WithContext(n.ctx)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(DeliveryHeader, delivery.ID)
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", response.Status)
+	}
+
+	return nil
+}
+
+// Sign returns hex encoded HMAC-SHA256 of the payload with the secret of webhook
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func retryDelay(attempts uint32) time.Duration {
+	delay := maxRetryDelay
+	if attempts < 32 && deliveryInterval<<attempts < maxRetryDelay {
+		delay = deliveryInterval << attempts
+	}
+
+	return delay
+}
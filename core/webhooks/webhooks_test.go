@@ -0,0 +1,276 @@
+package webhooks
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/appdb"
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/rlp"
+)
+
+type receiver struct {
+	lock     sync.Mutex
+	statuses []int
+	payloads []Payload
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	body, _ := ioutil.ReadAll(req.Body)
+	status := http.StatusOK
+	if len(r.statuses) != 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+
+	if status == http.StatusOK {
+		var payload Payload
+		_ = json.Unmarshal(body, &payload)
+		r.payloads = append(r.payloads, payload)
+		r.bodies = append(r.bodies, body)
+		r.headers = append(r.headers, req.Header)
+	}
+
+	w.WriteHeader(status)
+}
+
+func TestNotifier_Add(t *testing.T) {
+	notifier := NewNotifier(appdb.NewMemAppDB(), 3, time.Second)
+
+	if _, err := notifier.Add("ftp://example.com", "", []types.Address{{1}}, nil); err != ErrInvalidURL {
+		t.Fatalf("expected error of URL, got %v", err)
+	}
+	if _, err := notifier.Add("http://example.com", "", nil, nil); err != ErrEmptyWatches {
+		t.Fatalf("expected error of watches, got %v", err)
+	}
+
+	first, err := notifier.Add("http://example.com/1", "", []types.Address{{1}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := notifier.Add("http://example.com/2", "", nil, []types.Pubkey{{2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID != 1 || second.ID != 2 {
+		t.Fatalf("unexpected IDs of webhooks: %d %d", first.ID, second.ID)
+	}
+
+	if !notifier.Remove(first.ID) || notifier.Remove(first.ID) {
+		t.Fatal("webhook is not removed once")
+	}
+
+	// webhooks are loaded from app database
+	webhooks := NewNotifier(notifier.appDB, 3, time.Second).Webhooks()
+	if len(webhooks) != 1 || webhooks[0].URL != "http://example.com/2" {
+		t.Fatalf("unexpected webhooks: %v", webhooks)
+	}
+}
+
+func TestNotifier_Deliver(t *testing.T) {
+	server := &receiver{statuses: []int{http.StatusInternalServerError}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	privateKey, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(privateKey.PublicKey)
+	address, pubKey := types.Address{1}, types.Pubkey{2}
+
+	notifier := NewNotifier(appdb.NewMemAppDB(), 3, time.Second)
+	webhook, err := notifier.Add(httpServer.URL, "secret", []types.Address{address}, []types.Pubkey{pubKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.BeginBlock()
+	notifier.AddTx(sendTx(t, privateKey, address), transaction.Response{})
+	notifier.AddTx(sendTx(t, privateKey, types.Address{3}), transaction.Response{})
+	notifier.CandidateOffline(pubKey)
+	notifier.CandidateOffline(types.Pubkey{4})
+	notifier.Commit(10, eventsdb.Events{
+		&eventsdb.StakeKickEvent{Address: types.Address{5}, Amount: "7", Coin: 1, ValidatorPubKey: pubKey},
+		&eventsdb.RewardEvent{Role: eventsdb.RoleDelegator.String(), Address: address, Amount: "10", ValidatorPubKey: pubKey},
+		&eventsdb.RewardEvent{Role: eventsdb.RoleDelegator.String(), Address: types.Address{6}, Amount: "10", ValidatorPubKey: pubKey},
+		&eventsdb.UnbondEvent{Address: address, Amount: "20", Coin: 1, ValidatorPubKey: types.Pubkey{7}},
+	})
+
+	if pending := notifier.PendingDeliveries(); pending[webhook.ID] != 5 {
+		t.Fatalf("unexpected pending deliveries: %v", pending)
+	}
+
+	// payloads are delivered in order, so the webhook is in backoff after the first failure
+	now := time.Now()
+	deliver(notifier, now)
+	if len(server.payloads) != 0 {
+		t.Fatalf("expected no payloads after failed delivery, got %d", len(server.payloads))
+	}
+
+	// failed delivery is retried after delay
+	deliver(notifier, now)
+	if len(server.payloads) != 0 {
+		t.Fatalf("payload is retried before delay")
+	}
+	deliver(notifier, now.Add(retryDelay(1)))
+	if len(server.payloads) != 5 {
+		t.Fatalf("payloads are not delivered after retry, got %d", len(server.payloads))
+	}
+	if pending := notifier.PendingDeliveries(); len(pending) != 0 {
+		t.Fatalf("unexpected pending deliveries: %v", pending)
+	}
+
+	received := map[string]Payload{}
+	for i, payload := range server.payloads {
+		if server.headers[i].Get(SignatureHeader) != Sign("secret", server.bodies[i]) || server.headers[i].Get(DeliveryHeader) != payload.ID {
+			t.Fatalf("unexpected headers: %v", server.headers[i])
+		}
+		if payload.Height != 10 {
+			t.Fatalf("unexpected payload: %v", payload)
+		}
+		if payload.Type == TypeFundsReceived {
+			received[payload.Data.(map[string]interface{})["source"].(string)] = payload
+			continue
+		}
+		received[payload.Type] = payload
+	}
+
+	funds := received[SourceTx].Data.(map[string]interface{})
+	if funds["address"] != address.String() || funds["from"] != sender.String() || funds["value"] != "10" {
+		t.Fatalf("unexpected payload of funds: %v", funds)
+	}
+	reward := received[SourceReward].Data.(map[string]interface{})
+	if reward["address"] != address.String() || reward["pub_key"] != pubKey.String() || reward["value"] != "10" {
+		t.Fatalf("unexpected payload of reward: %v", reward)
+	}
+	unbond := received[SourceUnbond].Data.(map[string]interface{})
+	if unbond["address"] != address.String() || unbond["coin"] != float64(1) || unbond["value"] != "20" {
+		t.Fatalf("unexpected payload of unbond: %v", unbond)
+	}
+	if received[TypeCandidateOffline].Data.(map[string]interface{})["pub_key"] != pubKey.String() {
+		t.Fatalf("unexpected payload of candidate: %v", received[TypeCandidateOffline])
+	}
+	kick := received[TypeStakeKick].Data.(map[string]interface{})
+	if kick["address"] != (types.Address{5}).String() || kick["value"] != "7" {
+		t.Fatalf("unexpected payload of stake kick: %v", kick)
+	}
+}
+
+func TestNotifier_MaxAttempts(t *testing.T) {
+	server := &receiver{statuses: []int{http.StatusBadGateway, http.StatusBadGateway}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	notifier := NewNotifier(appdb.NewMemAppDB(), 2, time.Second)
+	if _, err := notifier.Add(httpServer.URL, "secret", nil, []types.Pubkey{{1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	notifier.BeginBlock()
+	notifier.CandidateOffline(types.Pubkey{1})
+	notifier.Commit(1, nil)
+
+	now := time.Now()
+	deliver(notifier, now)
+	deliver(notifier, now.Add(maxRetryDelay))
+	if len(server.statuses) != 0 || len(server.payloads) != 0 {
+		t.Fatalf("unexpected deliveries: %d %d", len(server.statuses), len(server.payloads))
+	}
+	if pending := notifier.PendingDeliveries(); len(pending) != 0 {
+		t.Fatalf("payload is not dropped: %v", pending)
+	}
+}
+
+func TestNotifier_DeliverConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer slowServer.Close()
+
+	server := &receiver{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	notifier := NewNotifier(appdb.NewMemAppDB(), 2, time.Minute)
+	for _, url := range []string{slowServer.URL, httpServer.URL} {
+		if _, err := notifier.Add(url, "secret", nil, []types.Pubkey{{1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	notifier.BeginBlock()
+	notifier.CandidateOffline(types.Pubkey{1})
+	notifier.Commit(1, nil)
+
+	// the slow webhook does not delay delivery to others
+	notifier.deliver(time.Now())
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		server.lock.Lock()
+		delivered := len(server.payloads)
+		server.lock.Unlock()
+
+		if delivered == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			close(release)
+			t.Fatal("payload is not delivered while another webhook is slow")
+		}
+	}
+
+	// the webhook which is being delivered is not delivered twice
+	notifier.deliver(time.Now())
+
+	close(release)
+	notifier.workers.Wait()
+
+	if pending := notifier.PendingDeliveries(); len(pending) != 0 {
+		t.Fatalf("unexpected pending deliveries: %v", pending)
+	}
+}
+
+// deliver runs delivery and waits for it
+func deliver(notifier *Notifier, now time.Time) {
+	notifier.deliver(now)
+	notifier.workers.Wait()
+}
+
+func sendTx(t *testing.T, privateKey *ecdsa.PrivateKey, to types.Address) []byte {
+	data, err := rlp.EncodeToBytes(transaction.SendData{Coin: types.GetBaseCoinID(), To: to, Value: big.NewInt(10)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := transaction.Transaction{
+		Nonce:         1,
+		GasPrice:      1,
+		ChainID:       types.CurrentChainID,
+		GasCoin:       types.GetBaseCoinID(),
+		Type:          transaction.TypeSend,
+		Data:          data,
+		SignatureType: transaction.SigTypeSingle,
+	}
+	if err := tx.Sign(privateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	rawTx, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rawTx
+}
@@ -10,9 +10,11 @@ require (
 	github.com/golang/protobuf v1.3.4
 	github.com/google/uuid v1.1.1
 	github.com/gorilla/websocket v1.4.1
+	github.com/graph-gophers/graphql-go v1.3.0
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0
 	github.com/grpc-ecosystem/grpc-gateway v1.12.0
 	github.com/marcusolsson/tui-go v0.4.0
+	github.com/mattn/go-sqlite3 v1.14.6
 	github.com/noah-blockchain/explorer-events-db v0.1.1-0.20201016102755-b04112103c19
 	github.com/noah-blockchain/node-grpc-gateway v0.0.0-20201016090438-d615ecc520ff
 	github.com/pkg/errors v0.9.1
@@ -8,7 +8,6 @@ import (
 	"github.com/noah-blockchain/noah-go-node/helpers"
 	"github.com/noah-blockchain/noah-go-node/rlp"
 	"github.com/noah-blockchain/noah-go-node/tree"
-	"math/big"
 	"sort"
 	"sync"
 )
@@ -106,40 +105,7 @@ func (c *Coins) Export(state *types.AppState) map[types.CoinSymbol]types.Coin {
 	var coins []types.Coin
 
 	if len(state.Coins) != 0 {
-		for k, coin := range state.Coins {
-			// check coins' volume
-			volume := big.NewInt(0)
-			for _, ff := range state.FrozenFunds {
-				if ff.Coin == coin.ID {
-					volume.Add(volume, helpers.StringToBigInt(ff.Value))
-				}
-			}
-
-			for _, candidate := range state.Candidates {
-				for _, stake := range candidate.Stakes {
-					if stake.Coin == coin.ID {
-						volume.Add(volume, helpers.StringToBigInt(stake.Value))
-					}
-				}
-
-				for _, stake := range candidate.Updates {
-					if stake.Coin == coin.ID {
-						volume.Add(volume, helpers.StringToBigInt(stake.Value))
-					}
-				}
-			}
-
-			for _, account := range state.Accounts {
-				for _, bal := range account.Balance {
-					if bal.Coin == coin.ID {
-						volume.Add(volume, helpers.StringToBigInt(bal.Value))
-					}
-				}
-			}
-
-			state.Coins[k].Volume = volume.String()
-		}
-
+		state.RecalculateCoinsVolume()
 		return nil
 	}
 
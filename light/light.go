@@ -0,0 +1,302 @@
+// Package light implements light client of NOAH network. The client verifies headers signed by validators
+// of the network starting from a trusted header and verifies state proofs against app hashes of verified headers.
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// TrustOptions are the subjective trust root of the client: a header obtained from a trusted source
+type TrustOptions struct {
+	// Period is the time during which validators of a verified header can be trusted.
+	// Should be significantly less than the unbonding period.
+	Period time.Duration
+
+	// Height and Hash of the trusted header
+	Height int64
+	Hash   []byte
+}
+
+// Client is a light client which keeps the latest verified header
+type Client struct {
+	chainID    string
+	period     time.Duration
+	trustLevel tmmath.Fraction
+	provider   Provider
+
+	lock        sync.Mutex
+	trusted     *tmTypes.SignedHeader
+	trustedVals *tmTypes.ValidatorSet
+}
+
+// NewClient creates light client with trusted header from given options
+func NewClient(chainID string, options TrustOptions, provider Provider) (*Client, error) {
+	if options.Period <= 0 {
+		return nil, errors.New("trusting period should be positive")
+	}
+
+	if options.Height <= 0 {
+		return nil, errors.New("trusted height should be positive")
+	}
+
+	header, vals, err := fetch(provider, options.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header.Hash(), options.Hash) {
+		return nil, fmt.Errorf("expected header hash %X, got %X", options.Hash, header.Hash())
+	}
+
+	if err := header.ValidateBasic(chainID); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(header.ValidatorsHash, vals.Hash()) {
+		return nil, fmt.Errorf("expected validators hash %X, got %X", header.ValidatorsHash, vals.Hash())
+	}
+
+	if err := vals.VerifyCommit(chainID, header.Commit.BlockID, header.Height, header.Commit); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		chainID:     chainID,
+		period:      options.Period,
+		trustLevel:  DefaultTrustLevel,
+		provider:    provider,
+		trusted:     header,
+		trustedVals: vals,
+	}, nil
+}
+
+// SetTrustLevel sets part of trusted validators voting power which should sign a non-adjacent header
+func (c *Client) SetTrustLevel(level tmmath.Fraction) error {
+	if err := ValidateTrustLevel(level); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.trustLevel = level
+	return nil
+}
+
+// TrustedHeader returns the latest verified header
+func (c *Client) TrustedHeader() *tmTypes.SignedHeader {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.trusted
+}
+
+// Update verifies the latest header of the provider and makes it trusted
+func (c *Client) Update(now time.Time) (*tmTypes.SignedHeader, error) {
+	return c.VerifyHeaderAtHeight(0, now)
+}
+
+// VerifyHeaderAtHeight verifies header at given height, 0 means the latest header of the provider.
+// Headers newer than trusted one are verified by validators (skipping with bisection) and become trusted,
+// older ones are verified by the hash chain.
+func (c *Client) VerifyHeaderAtHeight(height int64, now time.Time) (*tmTypes.SignedHeader, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if height != 0 && height <= c.trusted.Height {
+		return c.verifyBackwards(height)
+	}
+
+	header, vals, err := fetch(c.provider, height)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Height < c.trusted.Height {
+		return nil, fmt.Errorf("latest height %d of provider is lower than trusted height %d", header.Height, c.trusted.Height)
+	}
+
+	if header.Height == c.trusted.Height {
+		if !bytes.Equal(header.Hash(), c.trusted.Hash()) {
+			return nil, fmt.Errorf("header %X of provider conflicts with trusted header %X", header.Hash(), c.trusted.Hash())
+		}
+
+		return c.trusted, nil
+	}
+
+	if err := c.verifySkipping(header, vals, now); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// Account returns verified state of the address at given height, 0 means the latest height
+func (c *Client) Account(address types.Address, height int64, now time.Time) (*proofs.Account, error) {
+	header, height, err := c.stateHeader(height, now)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := c.provider.AddressProof(address, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs.VerifyAddress(header.Header, address, proof)
+}
+
+// Coin returns verified state of the coin at given height, nil if the coin does not exist
+func (c *Client) Coin(id types.CoinID, height int64, now time.Time) (*proofs.Coin, error) {
+	header, height, err := c.stateHeader(height, now)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := c.provider.CoinProof(id, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return proofs.VerifyCoin(header.Header, id, proof)
+}
+
+// Candidate returns verified state of the candidate with its stakes at given height
+func (c *Client) Candidate(pubKey types.Pubkey, height int64, now time.Time) (*proofs.Candidate, error) {
+	header, height, err := c.stateHeader(height, now)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := c.provider.CandidateProof(pubKey, height)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := proofs.VerifyCandidate(header.Header, pubKey, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if candidate == nil {
+		return nil, errors.New("candidate not found")
+	}
+
+	return candidate, nil
+}
+
+// stateHeader returns verified header which app hash commits to the state at given height.
+// State at height H is committed by the header at height H+1.
+func (c *Client) stateHeader(height int64, now time.Time) (*tmTypes.SignedHeader, int64, error) {
+	if height == 0 {
+		header, err := c.Update(now)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return header, header.Height - 1, nil
+	}
+
+	header, err := c.VerifyHeaderAtHeight(height+1, now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return header, height, nil
+}
+
+// verifySkipping verifies new header against trusted one. If trusted validators can not verify the header
+// because validators set has been changed too much, the header in the middle is verified first.
+func (c *Client) verifySkipping(header *tmTypes.SignedHeader, vals *tmTypes.ValidatorSet, now time.Time) error {
+	err := verify(c.chainID, c.trusted, c.trustedVals, header, vals, c.period, now, c.trustLevel)
+	switch err.(type) {
+	case nil:
+		c.trusted, c.trustedVals = header, vals
+		return nil
+	case ErrNewValSetCantBeTrusted:
+		pivotHeight := (c.trusted.Height + header.Height) / 2
+		pivot, pivotVals, err := fetch(c.provider, pivotHeight)
+		if err != nil {
+			return err
+		}
+
+		if err := c.verifySkipping(pivot, pivotVals, now); err != nil {
+			return err
+		}
+
+		return c.verifySkipping(header, vals, now)
+	default:
+		return err
+	}
+}
+
+// verifyBackwards verifies header older than trusted one by hashes of previous blocks
+func (c *Client) verifyBackwards(height int64) (*tmTypes.SignedHeader, error) {
+	verified := c.trusted
+	for verified.Height > height {
+		header, _, err := fetch(c.provider, verified.Height-1)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := verifyBackwards(c.chainID, header, verified); err != nil {
+			return nil, err
+		}
+
+		verified = header
+	}
+
+	return verified, nil
+}
+
+// fetch requests light block from provider and checks that it is consistent
+func fetch(provider Provider, height int64) (*tmTypes.SignedHeader, *tmTypes.ValidatorSet, error) {
+	block, err := provider.LightBlock(height)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if block.SignedHeader == nil || block.SignedHeader.Header == nil || block.SignedHeader.Commit == nil {
+		return nil, nil, errors.New("light block has no signed header")
+	}
+
+	if height != 0 && block.SignedHeader.Height != height {
+		return nil, nil, fmt.Errorf("expected header of height %d, got %d", height, block.SignedHeader.Height)
+	}
+
+	vals, err := ValidatorSet(block.Validators)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return block.SignedHeader, vals, nil
+}
+
+// ValidatorSet converts validators list of the application to Tendermint validator set
+func ValidatorSet(validators abciTypes.ValidatorUpdates) (*tmTypes.ValidatorSet, error) {
+	if len(validators) == 0 {
+		return nil, errors.New("validators list is empty")
+	}
+
+	tmVals, err := tmTypes.PB2TM.ValidatorUpdates(validators)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := &tmTypes.ValidatorSet{}
+	if err := vals.UpdateWithChangeSet(tmVals); err != nil {
+		return nil, err
+	}
+
+	return vals, nil
+}
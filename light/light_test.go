@@ -0,0 +1,233 @@
+package light
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	eventsdb "github.com/noah-blockchain/noah-go-node/core/events"
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
+	"github.com/noah-blockchain/noah-go-node/core/state"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/tree"
+	tmTypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+)
+
+const chainID = "noah-test"
+
+var genesisTime = time.Now().Add(-time.Hour)
+
+type testProvider struct {
+	blocks    map[int64]*LightBlock
+	latest    int64
+	stateTree *tree.ImmutableTree
+}
+
+func (p *testProvider) LightBlock(height int64) (*LightBlock, error) {
+	if height == 0 {
+		height = p.latest
+	}
+
+	block, ok := p.blocks[height]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return block, nil
+}
+
+func (p *testProvider) AddressProof(address types.Address, height int64) (*proofs.AddressProof, error) {
+	return proofs.NewAddressProof(p.stateTree, address)
+}
+
+func (p *testProvider) CoinProof(id types.CoinID, height int64) (*proofs.CoinProof, error) {
+	return proofs.NewCoinProof(p.stateTree, id)
+}
+
+func (p *testProvider) CandidateProof(pubKey types.Pubkey, height int64) (*proofs.CandidateProof, error) {
+	return proofs.NewCandidateProof(p.stateTree, pubKey)
+}
+
+// newTestProvider creates chain of blocks signed by vals[i] for height i+1. State at height 1 is committed to block 2.
+func newTestProvider(t *testing.T, vals []*tmTypes.ValidatorSet, privVals [][]tmTypes.PrivValidator) *testProvider {
+	s, err := state.NewState(0, db.NewMemDB(), eventsdb.NewEventsStore(db.NewMemDB()), 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Accounts.AddBalance(types.Address{1}, types.GetBaseCoinID(), big.NewInt(100))
+	s.Coins.Create(types.CoinID(1), types.StrToCoinSymbol("TEST"), "TEST", big.NewInt(1000), 10, big.NewInt(500), big.NewInt(10000), nil)
+	s.Candidates.Create(types.Address{1}, types.Address{1}, types.Address{1}, types.Pubkey{1}, 10)
+	s.Candidates.Delegate(types.Address{1}, types.Pubkey{1}, types.GetBaseCoinID(), big.NewInt(1000), big.NewInt(1000))
+	s.Candidates.RecalculateStakes(1)
+
+	appHash, err := s.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateTree, err := s.Tree().GetImmutableAtHeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &testProvider{blocks: map[int64]*LightBlock{}, latest: int64(len(vals)), stateTree: stateTree}
+
+	var lastBlockID tmTypes.BlockID
+	for i := range vals {
+		height := int64(i + 1)
+		nextVals := vals[i]
+		if i+1 < len(vals) {
+			nextVals = vals[i+1]
+		}
+
+		header := &tmTypes.Header{
+			ChainID:            chainID,
+			Height:             height,
+			Time:               genesisTime.Add(time.Duration(height) * time.Minute),
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals[i].Hash(),
+			NextValidatorsHash: nextVals.Hash(),
+			AppHash:            appHash,
+		}
+
+		lastBlockID = tmTypes.BlockID{Hash: header.Hash(), PartsHeader: tmTypes.PartSetHeader{Total: 1, Hash: header.Hash()}}
+		voteSet := tmTypes.NewVoteSet(chainID, height, 0, tmTypes.PrecommitType, vals[i])
+		commit, err := tmTypes.MakeCommit(lastBlockID, height, 0, voteSet, privVals[i], header.Time)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		provider.blocks[height] = &LightBlock{
+			SignedHeader: &tmTypes.SignedHeader{Header: header, Commit: commit},
+			Validators:   tmTypes.TM2PB.ValidatorUpdates(vals[i]),
+		}
+	}
+
+	return provider
+}
+
+func sameValidators(count int) ([]*tmTypes.ValidatorSet, [][]tmTypes.PrivValidator) {
+	valSet, privVals := tmTypes.RandValidatorSet(4, 10)
+
+	vals := make([]*tmTypes.ValidatorSet, count)
+	signers := make([][]tmTypes.PrivValidator, count)
+	for i := range vals {
+		vals[i], signers[i] = valSet, privVals
+	}
+
+	return vals, signers
+}
+
+func TestClient_State(t *testing.T) {
+	vals, privVals := sameValidators(2)
+	provider := newTestProvider(t, vals, privVals)
+
+	client, err := NewClient(chainID, TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: provider.blocks[1].SignedHeader.Hash()}, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := client.Account(types.Address{1}, 1, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.Balances[types.GetBaseCoinID()].Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("Wrong verified balance: %s", account.Balances[types.GetBaseCoinID()])
+	}
+
+	coin, err := client.Coin(types.CoinID(1), 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coin == nil || coin.Reserve.Cmp(big.NewInt(500)) != 0 {
+		t.Fatalf("Wrong verified coin: %v", coin)
+	}
+
+	candidate, err := client.Candidate(types.Pubkey{1}, 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if candidate.TotalStake.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("Wrong verified total stake: %s", candidate.TotalStake)
+	}
+
+	if client.TrustedHeader().Height != 2 {
+		t.Fatalf("Wrong trusted height: %d", client.TrustedHeader().Height)
+	}
+}
+
+func TestClient_ForgedState(t *testing.T) {
+	vals, privVals := sameValidators(2)
+	provider := newTestProvider(t, vals, privVals)
+	provider.blocks[2].SignedHeader.AppHash = []byte{1, 2, 3}
+
+	client, err := NewClient(chainID, TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: provider.blocks[1].SignedHeader.Hash()}, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Account(types.Address{1}, 1, time.Now()); err == nil {
+		t.Fatal("Header with changed app hash is accepted")
+	}
+}
+
+func TestClient_ValidatorsChange(t *testing.T) {
+	vals, privVals := sameValidators(10)
+	newValSet, newPrivVals := tmTypes.RandValidatorSet(4, 10)
+	for i := 5; i < len(vals); i++ {
+		vals[i], privVals[i] = newValSet, newPrivVals
+	}
+
+	provider := newTestProvider(t, vals, privVals)
+
+	client, err := NewClient(chainID, TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: provider.blocks[1].SignedHeader.Hash()}, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, err := client.Update(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Height != 10 {
+		t.Fatalf("Wrong verified height: %d", header.Height)
+	}
+
+	header, err = client.VerifyHeaderAtHeight(3, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header.Hash().String() != provider.blocks[3].SignedHeader.Hash().String() {
+		t.Fatal("Wrong header verified backwards")
+	}
+}
+
+func TestClient_UntrustedValidators(t *testing.T) {
+	vals, privVals := sameValidators(3)
+	provider := newTestProvider(t, vals, privVals)
+
+	forgedVals, forgedPrivVals := sameValidators(3)
+	forged := newTestProvider(t, forgedVals, forgedPrivVals)
+	provider.blocks[3] = forged.blocks[3]
+
+	client, err := NewClient(chainID, TrustOptions{Period: 24 * time.Hour, Height: 1, Hash: provider.blocks[1].SignedHeader.Hash()}, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.VerifyHeaderAtHeight(3, time.Now()); err == nil {
+		t.Fatal("Header signed by unknown validators is accepted")
+	}
+
+	if client.TrustedHeader().Height != 2 {
+		t.Fatalf("Wrong trusted height: %d", client.TrustedHeader().Height)
+	}
+}
@@ -0,0 +1,128 @@
+package light
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/proofs"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	rpctypes "github.com/noah-blockchain/noah-go-node/rpc/lib/types"
+	"github.com/tendermint/go-amino"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+var cdc = amino.NewCodec()
+
+// LightBlock is a signed header with validators which signed it.
+// Validators are Tendermint validators of the block with their public keys and voting powers.
+type LightBlock struct {
+	SignedHeader *tmTypes.SignedHeader      `json:"signed_header"`
+	Validators   abciTypes.ValidatorUpdates `json:"validators"`
+}
+
+// Provider provides light blocks and state proofs. It is not trusted by the client.
+// Height 0 means the latest available height.
+type Provider interface {
+	LightBlock(height int64) (*LightBlock, error)
+	AddressProof(address types.Address, height int64) (*proofs.AddressProof, error)
+	CoinProof(id types.CoinID, height int64) (*proofs.CoinProof, error)
+	CandidateProof(pubKey types.Pubkey, height int64) (*proofs.CandidateProof, error)
+}
+
+// HTTPProvider requests light blocks and state proofs from API v1 of a NOAH node
+type HTTPProvider struct {
+	remote string
+	client *http.Client
+}
+
+// NewHTTPProvider creates provider for API v1 at given address, e.g. http://localhost:8841
+func NewHTTPProvider(remote string) *HTTPProvider {
+	return &HTTPProvider{
+		remote: strings.TrimRight(remote, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *HTTPProvider) LightBlock(height int64) (*LightBlock, error) {
+	block := &LightBlock{}
+	if err := p.call("light_block", url.Values{"height": {strconv.FormatInt(height, 10)}}, block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (p *HTTPProvider) AddressProof(address types.Address, height int64) (*proofs.AddressProof, error) {
+	var response struct {
+		Proof *proofs.AddressProof `json:"proof"`
+	}
+
+	if err := p.call("address", proveParams(height, "address", strconv.Quote(address.String())), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Proof, nil
+}
+
+func (p *HTTPProvider) CoinProof(id types.CoinID, height int64) (*proofs.CoinProof, error) {
+	var response struct {
+		Proof *proofs.CoinProof `json:"proof"`
+	}
+
+	if err := p.call("coin_info", proveParams(height, "id", id.String()), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Proof, nil
+}
+
+func (p *HTTPProvider) CandidateProof(pubKey types.Pubkey, height int64) (*proofs.CandidateProof, error) {
+	var response struct {
+		Proof *proofs.CandidateProof `json:"proof"`
+	}
+
+	if err := p.call("candidate", proveParams(height, "pub_key", strconv.Quote(pubKey.String())), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Proof, nil
+}
+
+func (p *HTTPProvider) call(method string, params url.Values, result interface{}) error {
+	resp, err := p.client.Get(fmt.Sprintf("%s/%s?%s", p.remote, method, params.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	response := rpctypes.RPCResponse{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("can't decode response of %s: %s", method, err)
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	return cdc.UnmarshalJSON(response.Result, result)
+}
+
+func proveParams(height int64, name, value string) url.Values {
+	return url.Values{
+		name:     {value},
+		"height": {strconv.FormatInt(height, 10)},
+		"prove":  {"true"},
+	}
+}
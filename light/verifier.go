@@ -0,0 +1,109 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmTypes "github.com/tendermint/tendermint/types"
+)
+
+// Verification rules follow Tendermint light client specification. They are implemented on top of types package
+// to keep RPC and storage dependencies of Tendermint light client out of applications using this package.
+
+const maxClockDrift = 10 * time.Second
+
+// DefaultTrustLevel requires at least one correct validator of trusted set to sign a new header
+var DefaultTrustLevel = tmmath.Fraction{Numerator: 1, Denominator: 3}
+
+// ErrNewValSetCantBeTrusted means that trusted validators have not signed enough voting power of a new header
+type ErrNewValSetCantBeTrusted struct {
+	Reason error
+}
+
+func (e ErrNewValSetCantBeTrusted) Error() string {
+	return fmt.Sprintf("can't trust new validators set: %s", e.Reason)
+}
+
+// ErrHeaderExpired means that trusting period of the trusted header is over and the client should be reset
+type ErrHeaderExpired struct {
+	At time.Time
+}
+
+func (e ErrHeaderExpired) Error() string {
+	return fmt.Sprintf("trusted header has expired at %s", e.At)
+}
+
+// ValidateTrustLevel checks that trust level is within [1/3, 1]
+func ValidateTrustLevel(level tmmath.Fraction) error {
+	if level.Denominator == 0 || level.Numerator*3 < level.Denominator || level.Numerator > level.Denominator {
+		return fmt.Errorf("trust level should be within [1/3, 1], got %d/%d", level.Numerator, level.Denominator)
+	}
+
+	return nil
+}
+
+// verify checks that untrusted header with its validators is signed by validators of trusted header
+func verify(chainID string, trusted *tmTypes.SignedHeader, trustedVals *tmTypes.ValidatorSet,
+	untrusted *tmTypes.SignedHeader, untrustedVals *tmTypes.ValidatorSet,
+	period time.Duration, now time.Time, trustLevel tmmath.Fraction) error {
+
+	if expiresAt := trusted.Time.Add(period); !expiresAt.After(now) {
+		return ErrHeaderExpired{At: expiresAt}
+	}
+
+	if err := untrusted.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	if untrusted.Height <= trusted.Height {
+		return fmt.Errorf("new header height %d should be greater than trusted %d", untrusted.Height, trusted.Height)
+	}
+
+	if !untrusted.Time.After(trusted.Time) {
+		return fmt.Errorf("new header time %s should be after trusted %s", untrusted.Time, trusted.Time)
+	}
+
+	if !untrusted.Time.Before(now.Add(maxClockDrift)) {
+		return fmt.Errorf("new header has time from the future %s", untrusted.Time)
+	}
+
+	if !bytes.Equal(untrusted.ValidatorsHash, untrustedVals.Hash()) {
+		return fmt.Errorf("expected validators hash %X, got %X", untrusted.ValidatorsHash, untrustedVals.Hash())
+	}
+
+	if untrusted.Height == trusted.Height+1 {
+		if !bytes.Equal(untrusted.ValidatorsHash, trusted.NextValidatorsHash) {
+			return fmt.Errorf("expected next validators hash %X, got %X", trusted.NextValidatorsHash, untrusted.ValidatorsHash)
+		}
+	} else {
+		err := trustedVals.VerifyCommitTrusting(chainID, untrusted.Commit.BlockID, untrusted.Height, untrusted.Commit, trustLevel)
+		if e, ok := err.(tmTypes.ErrNotEnoughVotingPowerSigned); ok {
+			return ErrNewValSetCantBeTrusted{Reason: e}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return untrustedVals.VerifyCommit(chainID, untrusted.Commit.BlockID, untrusted.Height, untrusted.Commit)
+}
+
+// verifyBackwards checks that untrusted header is the previous block of trusted one
+func verifyBackwards(chainID string, untrusted, trusted *tmTypes.SignedHeader) error {
+	if err := untrusted.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	if !untrusted.Time.Before(trusted.Time) {
+		return errors.New("previous header time should be before trusted one")
+	}
+
+	if !bytes.Equal(untrusted.Hash(), trusted.LastBlockID.Hash) {
+		return fmt.Errorf("header hash %X does not match last block of trusted header %X", untrusted.Hash(), trusted.LastBlockID.Hash)
+	}
+
+	return nil
+}
@@ -25,7 +25,8 @@ import (
 
 // RegisterRPCFuncs adds a route for each function in the funcMap, as well as general jsonrpc and websocket handlers for all functions.
 // "result" is the interface on which the result objects are registered, and is popualted with every RPCResponse
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger, middleware func(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request)) {
+// JSON-RPC batches of more than maxBatchSize requests are rejected, 0 disables the limit.
+func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger, maxBatchSize int, middleware func(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request)) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
 		handler := makeHTTPHandler(rpcFunc, cdc, logger)
@@ -36,7 +37,7 @@ func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, cdc *amin
 	}
 
 	// JSONRPC endpoints
-	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cdc, logger)))
+	mux.HandleFunc("/", handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, cdc, logger, maxBatchSize)))
 }
 
 //-------------------------------------
@@ -103,7 +104,7 @@ func funcReturnTypes(f interface{}) []reflect.Type {
 // rpc.json
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger, maxBatchSize int) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		b, err := ioutil.ReadAll(r.Body)
 		if err != nil {
@@ -117,44 +118,78 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 			return
 		}
 
-		var request types.RPCRequest
-		err = json.Unmarshal(b, &request)
+		// a batch is an array of requests, responses to them are written as an array too
+		var requests []types.RPCRequest
+		trimmed := bytes.TrimSpace(b)
+		batch := len(trimmed) > 0 && trimmed[0] == '['
+		if batch {
+			err = json.Unmarshal(b, &requests)
+		} else {
+			var request types.RPCRequest
+			err = json.Unmarshal(b, &request)
+			requests = []types.RPCRequest{request}
+		}
 		if err != nil {
 			WriteRPCResponseHTTP(w, types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "Error unmarshalling request")))
 			return
 		}
-		// A Notification is a Request object without an "id" member.
-		// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-		if request.ID == types.JSONRPCStringID("") {
-			logger.Debug("HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
+		if batch && len(requests) == 0 {
+			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.New("Empty batch")))
 			return
 		}
-		if len(r.URL.Path) > 1 {
-			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(request.ID, errors.Errorf("Path %s is invalid", r.URL.Path)))
+		if maxBatchSize > 0 && len(requests) > maxBatchSize {
+			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(types.JSONRPCStringID(""),
+				errors.Errorf("Batch of %d requests exceeds the limit of %d", len(requests), maxBatchSize)))
 			return
 		}
-		rpcFunc := funcMap[request.Method]
-		if rpcFunc == nil || rpcFunc.ws {
-			WriteRPCResponseHTTP(w, types.RPCMethodNotFoundError(request.ID))
+
+		responses := make([]types.RPCResponse, 0, len(requests))
+		for _, request := range requests {
+			// A Notification is a Request object without an "id" member.
+			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+			if request.ID == types.JSONRPCStringID("") {
+				logger.Debug("HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
+				continue
+			}
+			if len(r.URL.Path) > 1 {
+				responses = append(responses, types.RPCInvalidRequestError(request.ID, errors.Errorf("Path %s is invalid", r.URL.Path)))
+				continue
+			}
+			responses = append(responses, callJSONRPCFunc(funcMap, cdc, logger, request))
+		}
+
+		if len(responses) == 0 {
 			return
 		}
-		var args []reflect.Value
-		if len(request.Params) > 0 {
-			args, err = jsonParamsToArgsRPC(rpcFunc, cdc, request.Params)
-			if err != nil {
-				WriteRPCResponseHTTP(w, types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "Error converting json params to arguments")))
-				return
-			}
+		if batch {
+			WriteRPCResponseArrayHTTP(w, responses)
+			return
 		}
-		returns := rpcFunc.f.Call(args)
-		logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
-		result, err := unreflectResult(returns)
+		WriteRPCResponseHTTP(w, responses[0])
+	}
+}
+
+// callJSONRPCFunc calls the function of the request and returns its result or error as a response
+func callJSONRPCFunc(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger, request types.RPCRequest) types.RPCResponse {
+	rpcFunc := funcMap[request.Method]
+	if rpcFunc == nil || rpcFunc.ws {
+		return types.RPCMethodNotFoundError(request.ID)
+	}
+	var args []reflect.Value
+	if len(request.Params) > 0 {
+		var err error
+		args, err = jsonParamsToArgsRPC(rpcFunc, cdc, request.Params)
 		if err != nil {
-			WriteRPCResponseHTTP(w, types.RPCInternalError(request.ID, err))
-			return
+			return types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "Error converting json params to arguments"))
 		}
-		WriteRPCResponseHTTP(w, types.NewRPCSuccessResponse(cdc, request.ID, result))
 	}
+	returns := rpcFunc.f.Call(args)
+	logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
+	result, err := unreflectResult(returns)
+	if err != nil {
+		return types.RPCInternalError(request.ID, err)
+	}
+	return types.NewRPCSuccessResponse(cdc, request.ID, result)
 }
 
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
@@ -459,7 +494,7 @@ func NewWSConnection(
 	cdc *amino.Codec,
 	options ...func(*wsConnection),
 ) *wsConnection {
-	baseConn.SetReadLimit(maxBodyBytes)
+	baseConn.SetReadLimit(MaxBodyBytes)
 	wsc := &wsConnection{
 		remoteAddr:        baseConn.RemoteAddr().String(),
 		baseConn:          baseConn,
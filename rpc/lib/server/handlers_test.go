@@ -25,6 +25,9 @@ import (
 //////////////////////////////////////////////////////////////////////////////
 // JSON-RPC over HTTP
 
+// testMaxBatchSize is the limit of batch size of the test server
+const testMaxBatchSize = 3
+
 func testMux() *http.ServeMux {
 	funcMap := map[string]*rs.RPCFunc{
 		"c": rs.NewRPCFunc(func(s string, i int) (string, error) { return "foo", nil }, "s,i"),
@@ -33,7 +36,7 @@ func testMux() *http.ServeMux {
 	mux := http.NewServeMux()
 	buf := new(bytes.Buffer)
 	logger := log.NewTMLogger(buf)
-	rs.RegisterRPCFuncs(mux, funcMap, cdc, logger, nil)
+	rs.RegisterRPCFuncs(mux, funcMap, cdc, logger, testMaxBatchSize, nil)
 
 	return mux
 }
@@ -153,6 +156,72 @@ func TestRPCNotification(t *testing.T) {
 	require.Equal(t, len(blob), 0, "a notification SHOULD NOT be responded to by the server")
 }
 
+func TestRPCBatch(t *testing.T) {
+	mux := testMux()
+	tests := []struct {
+		payload     string
+		expectedIds []interface{}
+		errors      []bool
+	}{
+		{`[{"jsonrpc": "2.0", "method": "c", "id": "0", "params": ["a", "10"]}, {"jsonrpc": "2.0", "method": "y", "id": 1}]`,
+			[]interface{}{types.JSONRPCStringID("0"), types.JSONRPCIntID(1)}, []bool{false, true}},
+		// notifications are not responded to within a batch too
+		{`[{"jsonrpc": "2.0", "method": "c", "id": "", "params": ["a", "10"]}, {"jsonrpc": "2.0", "method": "c", "id": "1", "params": ["a", "10"]}]`,
+			[]interface{}{types.JSONRPCStringID("1")}, []bool{false}},
+		// a batch of one request is still responded to with an array
+		{`[{"jsonrpc": "2.0", "method": "c", "id": "0", "params": ["a", "10"]}]`,
+			[]interface{}{types.JSONRPCStringID("0")}, []bool{false}},
+	}
+
+	for i, tt := range tests {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(tt.payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		require.True(t, statusOK(res.StatusCode), "#%d: batch should always return 2XX", i)
+		blob, err := ioutil.ReadAll(res.Body)
+		require.Nil(t, err, "#%d: err reading body", i)
+
+		var recv []types.RPCResponse
+		require.Nil(t, json.Unmarshal(blob, &recv), "#%d: expecting successful parsing of RPCResponse array:\nblob: %s", i, blob)
+		require.Equal(t, len(tt.expectedIds), len(recv), "#%d: unexpected number of responses", i)
+		for j, resp := range recv {
+			assert.Equal(t, tt.expectedIds[j], resp.ID, "#%d.%d: expected ID not matched in RPCResponse", i, j)
+			assert.Equal(t, tt.errors[j], resp.Error != nil, "#%d.%d: unexpected error %v", i, j, resp.Error)
+		}
+	}
+}
+
+func TestRPCEmptyBatch(t *testing.T) {
+	mux := testMux()
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(`[]`))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	blob, err := ioutil.ReadAll(rec.Result().Body)
+	require.Nil(t, err)
+
+	recv := new(types.RPCResponse)
+	require.Nil(t, json.Unmarshal(blob, recv), "expecting a single RPCResponse:\nblob: %s", blob)
+	require.NotNil(t, recv.Error)
+	assert.Equal(t, -32600, recv.Error.Code)
+}
+
+func TestRPCBatchLimit(t *testing.T) {
+	mux := testMux()
+	request := `{"jsonrpc": "2.0", "method": "c", "id": "0", "params": ["a", "10"]}`
+	payload := "[" + strings.Repeat(request+",", testMaxBatchSize) + request + "]"
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	blob, err := ioutil.ReadAll(rec.Result().Body)
+	require.Nil(t, err)
+
+	recv := new(types.RPCResponse)
+	require.Nil(t, json.Unmarshal(blob, recv), "expecting a single RPCResponse:\nblob: %s", blob)
+	require.NotNil(t, recv.Error)
+	assert.Equal(t, -32600, recv.Error.Code)
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
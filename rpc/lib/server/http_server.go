@@ -24,9 +24,9 @@ type Config struct {
 }
 
 const (
-	// maxBodyBytes controls the maximum number of bytes the
+	// MaxBodyBytes controls the maximum number of bytes the
 	// server will read parsing the request body.
-	maxBodyBytes = int64(1000000) // 1MB
+	MaxBodyBytes = int64(1000000) // 1MB
 
 	// same as the net/http default
 	maxHeaderBytes = 1 << 20
@@ -46,7 +46,7 @@ const (
 func StartHTTPServer(listener net.Listener, handler http.Handler, logger log.Logger) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
 	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: maxBodyBytes}, logger),
+		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: MaxBodyBytes}, logger),
 		ReadTimeout:    ReadTimeout,
 		WriteTimeout:   WriteTimeout,
 		MaxHeaderBytes: maxHeaderBytes,
@@ -68,7 +68,7 @@ func StartHTTPAndTLSServer(
 	logger.Info(fmt.Sprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
 	s := &http.Server{
-		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: maxBodyBytes}, logger),
+		Handler:        RecoverAndLogHandler(maxBytesHandler{h: handler, n: MaxBodyBytes}, logger),
 		ReadTimeout:    ReadTimeout,
 		WriteTimeout:   WriteTimeout,
 		MaxHeaderBytes: maxHeaderBytes,
@@ -112,6 +112,17 @@ func WriteRPCResponseHTTP(w http.ResponseWriter, res types.RPCResponse) {
 	w.Write(jsonBytes) // nolint: errcheck, gas
 }
 
+// WriteRPCResponseArrayHTTP writes responses to a batch of requests, errors of single requests do not change HTTP status
+func WriteRPCResponseArrayHTTP(w http.ResponseWriter, res []types.RPCResponse) {
+	jsonBytes, err := json.Marshal(res)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(jsonBytes) // nolint: errcheck, gas
+}
+
 //-----------------------------------------------------------------------------
 
 // Wraps an HTTP handler, adding error logging.
@@ -0,0 +1,385 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/cmd/utils"
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/noah"
+	"github.com/noah-blockchain/noah-go-node/core/state/candidates"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+	"github.com/noah-blockchain/noah-go-node/helpers"
+	"github.com/tendermint/go-amino"
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmLog "github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	tmNode "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	tmTypes "github.com/tendermint/tendermint/types"
+	db "github.com/tendermint/tm-db"
+)
+
+// networkChainID is the chain id of genesis of test networks
+const networkChainID = "noah-test-network"
+
+// ValidatorStake is the stake of each validator in genesis of test network
+var ValidatorStake = helpers.NoahToQNoah(big.NewInt(1000000))
+
+// Node is a validator of the test network
+type Node struct {
+	Index  int
+	Home   string
+	App    *noah.Blockchain
+	TmNode *tmNode.Node
+
+	// PrivKey is the validator key, PubKey is the public key of the candidate in genesis
+	PrivKey ed25519.PrivKeyEd25519
+	PubKey  types.Pubkey
+
+	nodeKey *p2p.NodeKey
+	p2pAddr string
+	stopped bool
+
+	// dbs are in-memory databases of Tendermint by their ids
+	dbs map[string]db.DB
+}
+
+// DB returns Tendermint database of the node with given id, e.g. blockstore or state
+func (node *Node) DB(id string) db.DB {
+	return node.dbs[id]
+}
+
+// Network is a network of validators running in one process and connected by real Tendermint consensus over loopback.
+// Each node has its own home directory, applications and Tendermint use in-memory databases.
+type Network struct {
+	Nodes []*Node
+
+	// Keys are private keys of accounts funded in genesis
+	Keys []*ecdsa.PrivateKey
+
+	genesis *tmTypes.GenesisDoc
+	lock    sync.Mutex
+}
+
+// NewNetwork starts network of n validators with equal stakes.
+// Validators and candidates of given state are replaced, accounts count accounts with funds in base coin are added.
+func NewNetwork(n int, accounts int, state types.AppState) (*Network, error) {
+	network := &Network{}
+
+	for i := 0; i < accounts; i++ {
+		address, pk := CreateAddress()
+		network.Keys = append(network.Keys, pk)
+		state.Accounts = append(state.Accounts, types.Account{
+			Address: address,
+			Balance: []types.Balance{{Coin: uint64(types.GetBaseCoinID()), Value: helpers.NoahToQNoah(big.NewInt(1000000)).String()}},
+		})
+	}
+
+	state.Validators, state.Candidates = nil, nil
+	for i := 0; i < n; i++ {
+		home, err := ioutil.TempDir("", fmt.Sprintf("noah-network-%d-", i))
+		if err != nil {
+			network.Stop()
+			return nil, err
+		}
+
+		node := &Node{
+			Index:   i,
+			Home:    home,
+			PrivKey: ed25519.GenPrivKey(),
+			nodeKey: &p2p.NodeKey{PrivKey: ed25519.GenPrivKey()},
+			dbs:     map[string]db.DB{},
+		}
+		pubKey := node.PrivKey.PubKey().(ed25519.PubKeyEd25519)
+		copy(node.PubKey[:], pubKey[:])
+		network.Nodes = append(network.Nodes, node)
+
+		owner, _ := CreateAddress()
+		state.Validators = append(state.Validators, types.Validator{
+			TotalNoahStake: ValidatorStake.String(),
+			PubKey:         node.PubKey,
+			AccumReward:    "0",
+			AbsentTimes:    types.NewBitArray(24),
+		})
+		state.Candidates = append(state.Candidates, types.Candidate{
+			ID:             uint64(i + 1),
+			RewardAddress:  owner,
+			OwnerAddress:   owner,
+			ControlAddress: owner,
+			TotalNoahStake: ValidatorStake.String(),
+			PubKey:         node.PubKey,
+			Commission:     10,
+			Stakes: []types.Stake{
+				{
+					Owner:     owner,
+					Coin:      uint64(types.GetBaseCoinID()),
+					Value:     ValidatorStake.String(),
+					NoahValue: ValidatorStake.String(),
+				},
+			},
+			Status: candidates.CandidateStatusOnline,
+		})
+	}
+
+	appState, err := amino.MarshalJSON(state)
+	if err != nil {
+		network.Stop()
+		return nil, err
+	}
+
+	network.genesis = &tmTypes.GenesisDoc{
+		GenesisTime:     time.Now(),
+		ChainID:         networkChainID,
+		ConsensusParams: tmTypes.DefaultConsensusParams(),
+		AppHash:         make([]byte, 32), // app reports zero hash before the first block
+		AppState:        appState,
+	}
+	for _, node := range network.Nodes {
+		network.genesis.Validators = append(network.genesis.Validators, tmTypes.GenesisValidator{
+			PubKey: node.PrivKey.PubKey(),
+			Power:  1,
+		})
+	}
+
+	if err := network.genesis.ValidateAndComplete(); err != nil {
+		network.Stop()
+		return nil, err
+	}
+
+	for _, node := range network.Nodes {
+		if err := network.startNode(node); err != nil {
+			network.Stop()
+			return nil, err
+		}
+	}
+
+	network.Heal()
+
+	return network, nil
+}
+
+func (n *Network) startNode(node *Node) error {
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+
+	// noah databases are created in home directory which is global
+	n.lock.Lock()
+	home := utils.NoahHome
+	utils.NoahHome = node.Home
+	cfg := config.GetConfig()
+	cfg.FastSync = false
+	cfg.DBBackend = "memdb"
+	cfg.Instrumentation.Prometheus = false
+	cfg.RPC.ListenAddress = ""
+	cfg.RPC.GRPCListenAddress = ""
+	cfg.P2P.ListenAddress = fmt.Sprintf("tcp://127.0.0.1:%d", port)
+	cfg.P2P.Seeds = ""
+	cfg.P2P.PersistentPeers = ""
+	cfg.P2P.PexReactor = false
+	cfg.P2P.AddrBookStrict = false
+	cfg.P2P.AllowDuplicateIP = true
+	cfg.Consensus.TimeoutPropose = 1 * time.Second
+	cfg.Consensus.TimeoutPrevote = 200 * time.Millisecond
+	cfg.Consensus.TimeoutPrecommit = 200 * time.Millisecond
+	cfg.Consensus.TimeoutCommit = 300 * time.Millisecond
+	node.App = noah.NewNoahBlockchain(cfg)
+	utils.NoahHome = home
+	n.lock.Unlock()
+
+	tmConfig := config.GetTmConfig(cfg)
+	pv := privval.GenFilePV(tmConfig.PrivValidatorKeyFile(), tmConfig.PrivValidatorStateFile())
+	pv.Key.PrivKey = node.PrivKey
+	pv.Key.PubKey = node.PrivKey.PubKey()
+	pv.Key.Address = node.PrivKey.PubKey().Address()
+
+	node.TmNode, err = tmNode.NewNode(
+		tmConfig,
+		pv,
+		node.nodeKey,
+		proxy.NewLocalClientCreator(node.App),
+		func() (*tmTypes.GenesisDoc, error) { return n.genesis, nil },
+		func(ctx *tmNode.DBContext) (db.DB, error) {
+			database, err := tmNode.DefaultDBProvider(ctx)
+			if err == nil {
+				node.dbs[ctx.ID] = database
+			}
+			return database, err
+		},
+		tmNode.DefaultMetricsProvider(tmConfig.Instrumentation),
+		tmLog.NewNopLogger(),
+	)
+	if err != nil {
+		return err
+	}
+
+	node.App.SetTmNode(node.TmNode)
+	node.p2pAddr = p2p.IDAddressString(node.nodeKey.ID(), fmt.Sprintf("127.0.0.1:%d", port))
+
+	return node.TmNode.Start()
+}
+
+// Stop stops all nodes and removes their home directories
+func (n *Network) Stop() {
+	for i, node := range n.Nodes {
+		if node.TmNode != nil {
+			_ = n.Kill(i)
+		}
+		_ = os.RemoveAll(node.Home)
+	}
+}
+
+// Kill stops the node, it can't be started again
+func (n *Network) Kill(i int) error {
+	node := n.Nodes[i]
+	if node.stopped {
+		return nil
+	}
+
+	node.stopped = true
+	if err := node.TmNode.Stop(); err != nil {
+		return err
+	}
+	node.TmNode.Wait()
+	node.App.Stop()
+
+	return nil
+}
+
+// Partition disconnects nodes of different groups from each other.
+// Nodes which are not in any group are disconnected from all nodes.
+func (n *Network) Partition(groups ...[]int) {
+	group := make(map[int]int, len(n.Nodes))
+	for i := range n.Nodes {
+		group[i] = -1 - i
+	}
+	for g, nodes := range groups {
+		for _, i := range nodes {
+			group[i] = g
+		}
+	}
+
+	for i, node := range n.Nodes {
+		if node.stopped {
+			continue
+		}
+
+		for j, other := range n.Nodes {
+			if group[i] == group[j] {
+				continue
+			}
+
+			if peer := node.TmNode.Switch().Peers().Get(other.nodeKey.ID()); peer != nil {
+				node.TmNode.Switch().StopPeerGracefully(peer)
+			}
+		}
+	}
+}
+
+// Heal connects all running nodes to each other.
+// Peers are not persistent, so disconnected nodes are not redialed until Heal is called.
+func (n *Network) Heal() {
+	for i, node := range n.Nodes {
+		if node.stopped {
+			continue
+		}
+
+		var peers []string
+		for _, other := range n.Nodes[i+1:] {
+			if !other.stopped && !node.TmNode.Switch().Peers().Has(other.nodeKey.ID()) {
+				peers = append(peers, other.p2pAddr)
+			}
+		}
+
+		if len(peers) != 0 {
+			_ = node.TmNode.Switch().DialPeersAsync(peers)
+		}
+	}
+}
+
+// Height returns the lowest height of blocks committed by running nodes
+func (n *Network) Height() uint64 {
+	var height uint64
+	first := true
+	for _, node := range n.Nodes {
+		if node.stopped {
+			continue
+		}
+
+		if h := node.App.LastCommittedHeight(); first || h < height {
+			height, first = h, false
+		}
+	}
+
+	return height
+}
+
+// WaitForHeight waits until all running nodes commit block of given height
+func (n *Network) WaitForHeight(height uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for n.Height() < height {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for height %d, current height %d", height, n.Height())
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil
+}
+
+// WaitForBlocks waits until all running nodes commit count more blocks
+func (n *Network) WaitForBlocks(count uint64, timeout time.Duration) error {
+	return n.WaitForHeight(n.Height()+count, timeout)
+}
+
+// SendTx signs transaction with given key and sends it to mempool of the node, returns hash of the transaction.
+// Nonce is taken from the committed state of the node and mempool accepts one transaction of the sender per block,
+// so the next transaction of the same sender should be sent after the block is committed.
+func (n *Network) SendTx(i int, pk *ecdsa.PrivateKey, txType transaction.TxType, data interface{}) ([]byte, error) {
+	node := n.Nodes[i]
+	if node.stopped {
+		return nil, errors.New("node is stopped")
+	}
+
+	tx := CreateTx(node.App, crypto.PubkeyToAddress(pk.PublicKey), txType, data)
+	rawTx := SignTx(pk, tx)
+
+	result := make(chan *abciTypes.Response, 1)
+	if err := node.TmNode.Mempool().CheckTx(rawTx, func(response *abciTypes.Response) {
+		result <- response
+	}, mempool.TxInfo{}); err != nil {
+		return nil, err
+	}
+
+	response := (<-result).GetCheckTx()
+	if response.Code != 0 {
+		return nil, fmt.Errorf("transaction rejected with code %d: %s", response.Code, response.Log)
+	}
+
+	return tmTypes.Tx(rawTx).Hash(), nil
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+	"github.com/noah-blockchain/noah-go-node/crypto"
+)
+
+func TestNetwork(t *testing.T) {
+	network, err := NewNetwork(4, 1, DefaultAppState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.Stop()
+
+	if err := network.WaitForHeight(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	to, _ := CreateAddress()
+	value := big.NewInt(100)
+	if _, err := network.SendTx(0, network.Keys[0], transaction.TypeSend, transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    to,
+		Value: value,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.WaitForBlocks(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, node := range network.Nodes {
+		if balance := node.App.CurrentState().Accounts().GetBalance(to, types.GetBaseCoinID()); balance.Cmp(value) != 0 {
+			t.Fatalf("Wrong balance on node %d. Expected %s, got %s", node.Index, value, balance)
+		}
+	}
+
+	sender := crypto.PubkeyToAddress(network.Keys[0].PublicKey)
+	if nonce := network.Nodes[3].App.CurrentState().Accounts().GetNonce(sender); nonce != 1 {
+		t.Fatalf("Wrong nonce of sender. Expected 1, got %d", nonce)
+	}
+
+	// 3 of 4 validators have more than 2/3 of voting power
+	if err := network.Kill(3); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.WaitForBlocks(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// neither part of the network has 2/3 of voting power
+	network.Partition([]int{0, 1}, []int{2})
+	height := network.Height()
+	time.Sleep(3 * time.Second)
+	if network.Height() > height+1 {
+		t.Fatalf("Partitioned network is producing blocks, height %d, expected at most %d", network.Height(), height+1)
+	}
+
+	network.Heal()
+	if err := network.WaitForBlocks(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
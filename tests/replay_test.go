@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/noah-blockchain/noah-go-node/config"
+	"github.com/noah-blockchain/noah-go-node/core/noah"
+	"github.com/noah-blockchain/noah-go-node/core/transaction"
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+func TestReplay(t *testing.T) {
+	network, err := NewNetwork(2, 1, DefaultAppState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer network.Stop()
+
+	if err := network.WaitForHeight(2, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	to, _ := CreateAddress()
+	value := big.NewInt(100)
+	txHash, err := network.SendTx(0, network.Keys[0], transaction.TypeSend, transaction.SendData{
+		Coin:  types.GetBaseCoinID(),
+		To:    to,
+		Value: value,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := network.WaitForBlocks(3, 30*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	node := network.Nodes[0]
+	replayer, err := noah.NewReplayer(config.GetConfig(), node.App.GetStateDB(), 1, 0, node.TmNode.BlockStore(), node.DB("state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replayed := false
+	for height := network.Height() - 1; replayer.Height() < height; {
+		block, err := replayer.Replay()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if block.ExpectedAppHash == nil || !block.AppHashMatches() {
+			t.Fatalf("App hash of block %d does not match. Expected %X, got %X", block.Height, block.ExpectedAppHash, block.AppHash)
+		}
+
+		for _, tx := range block.Txs {
+			if !bytes.Equal(tx.Hash, txHash) {
+				continue
+			}
+
+			if tx.Code != 0 {
+				t.Fatalf("Replayed tx failed with code %d: %s", tx.Code, tx.Log)
+			}
+
+			for _, change := range tx.Changes {
+				if change.Old == nil && bytes.Equal(change.New, value.Bytes()) {
+					replayed = true
+				}
+			}
+		}
+	}
+
+	if !replayed {
+		t.Fatal("Balance of the recipient is not found in changes of replayed tx")
+	}
+}
@@ -0,0 +1,272 @@
+package tree
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// OverlayDB keeps writes in memory over the base DB which is only read,
+// so the tree stored in the base can be loaded and changed without copying it
+type OverlayDB struct {
+	base dbm.DB
+
+	lock sync.RWMutex
+	// writes are values set over the base, nil value is a deleted key
+	writes map[string][]byte
+}
+
+// NewOverlayDB creates overlay of the base DB, the base is not closed with the overlay
+func NewOverlayDB(base dbm.DB) *OverlayDB {
+	return &OverlayDB{
+		base:   base,
+		writes: map[string][]byte{},
+	}
+}
+
+// Get returns the value of the key written to the overlay or stored in the base
+func (db *OverlayDB) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	value, ok := db.writes[string(key)]
+	db.lock.RUnlock()
+	if ok {
+		return copyBytes(value), nil
+	}
+
+	return db.base.Get(key)
+}
+
+// Has reports whether the key exists in the overlay or in the base
+func (db *OverlayDB) Has(key []byte) (bool, error) {
+	value, err := db.Get(key)
+	return value != nil, err
+}
+
+// Set writes the value to the overlay
+func (db *OverlayDB) Set(key []byte, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.writes[string(key)] = append([]byte{}, value...)
+
+	return nil
+}
+
+// SetSync is Set, the overlay is not persisted
+func (db *OverlayDB) SetSync(key []byte, value []byte) error {
+	return db.Set(key, value)
+}
+
+// Delete hides the key of the base
+func (db *OverlayDB) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.writes[string(key)] = nil
+
+	return nil
+}
+
+// DeleteSync is Delete, the overlay is not persisted
+func (db *OverlayDB) DeleteSync(key []byte) error {
+	return db.Delete(key)
+}
+
+// Iterator iterates over keys of the overlay and the base in [start, end) in ascending order
+func (db *OverlayDB) Iterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, false)
+}
+
+// ReverseIterator iterates over keys of the overlay and the base in [start, end) in descending order
+func (db *OverlayDB) ReverseIterator(start, end []byte) (dbm.Iterator, error) {
+	return db.newIterator(start, end, true)
+}
+
+// Close drops writes of the overlay
+func (db *OverlayDB) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.writes = map[string][]byte{}
+
+	return nil
+}
+
+// NewBatch returns batch of writes applied to the overlay at once
+func (db *OverlayDB) NewBatch() dbm.Batch {
+	return &overlayBatch{db: db}
+}
+
+// Print is not supported by the overlay
+func (db *OverlayDB) Print() error {
+	return nil
+}
+
+// Stats returns stats of the base
+func (db *OverlayDB) Stats() map[string]string {
+	return db.base.Stats()
+}
+
+func (db *OverlayDB) newIterator(start, end []byte, reverse bool) (dbm.Iterator, error) {
+	var base dbm.Iterator
+	var err error
+	if reverse {
+		base, err = db.base.ReverseIterator(start, end)
+	} else {
+		base, err = db.base.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db.lock.RLock()
+	var keys []string
+	for key := range db.writes {
+		if dbm.IsKeyInDomain([]byte(key), start, end) {
+			keys = append(keys, key)
+		}
+	}
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		values[key] = db.writes[key]
+	}
+	db.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return (keys[i] < keys[j]) != reverse
+	})
+
+	it := &overlayIterator{
+		base:    base,
+		keys:    keys,
+		values:  values,
+		start:   start,
+		end:     end,
+		reverse: reverse,
+	}
+	it.Next()
+
+	return it, nil
+}
+
+// overlayIterator merges the iterator of the base with the keys written to the overlay when the iterator is created
+type overlayIterator struct {
+	base   dbm.Iterator
+	keys   []string
+	values map[string][]byte
+
+	start, end []byte
+	reverse    bool
+
+	key, value []byte
+	valid      bool
+}
+
+func (it *overlayIterator) Domain() ([]byte, []byte) {
+	return it.start, it.end
+}
+
+func (it *overlayIterator) Valid() bool {
+	return it.valid
+}
+
+// Next moves to the next key of the overlay or the base, keys written to the overlay replace keys of the base
+func (it *overlayIterator) Next() {
+	for {
+		hasBase, hasOverlay := it.base.Valid(), len(it.keys) > 0
+		if !hasBase && !hasOverlay {
+			it.valid = false
+			return
+		}
+
+		cmp := -1
+		if !hasBase {
+			cmp = 1
+		} else if hasOverlay {
+			cmp = bytes.Compare(it.base.Key(), []byte(it.keys[0]))
+			if it.reverse {
+				cmp = -cmp
+			}
+		}
+
+		if cmp < 0 {
+			it.key, it.value = copyBytes(it.base.Key()), copyBytes(it.base.Value())
+			it.base.Next()
+			it.valid = true
+			return
+		}
+
+		if cmp == 0 {
+			it.base.Next()
+		}
+
+		key := it.keys[0]
+		it.keys = it.keys[1:]
+		if value := it.values[key]; value != nil {
+			it.key, it.value = []byte(key), copyBytes(value)
+			it.valid = true
+			return
+		}
+	}
+}
+
+func (it *overlayIterator) Key() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+
+	return it.key
+}
+
+func (it *overlayIterator) Value() []byte {
+	if !it.valid {
+		panic("iterator is invalid")
+	}
+
+	return it.value
+}
+
+func (it *overlayIterator) Error() error {
+	return it.base.Error()
+}
+
+func (it *overlayIterator) Close() {
+	it.base.Close()
+}
+
+type overlayBatch struct {
+	db     *OverlayDB
+	keys   [][]byte
+	values [][]byte
+}
+
+func (b *overlayBatch) Set(key, value []byte) {
+	b.keys = append(b.keys, copyBytes(key))
+	b.values = append(b.values, append([]byte{}, value...))
+}
+
+func (b *overlayBatch) Delete(key []byte) {
+	b.keys = append(b.keys, copyBytes(key))
+	b.values = append(b.values, nil)
+}
+
+func (b *overlayBatch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	for i, key := range b.keys {
+		b.db.writes[string(key)] = b.values[i]
+	}
+
+	return nil
+}
+
+func (b *overlayBatch) WriteSync() error {
+	return b.Write()
+}
+
+func (b *overlayBatch) Close() {
+	b.keys, b.values = nil, nil
+}
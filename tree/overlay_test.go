@@ -0,0 +1,58 @@
+package tree
+
+import (
+	"testing"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestOverlayDB(t *testing.T) {
+	base := dbm.NewMemDB()
+	for _, key := range []string{"a", "b", "c", "d"} {
+		if err := base.Set([]byte(key), []byte("base "+key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	overlay := NewOverlayDB(base)
+	if err := overlay.Set([]byte("b"), []byte("overlay b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := overlay.Delete([]byte("c")); err != nil {
+		t.Fatal(err)
+	}
+	batch := overlay.NewBatch()
+	batch.Set([]byte("e"), []byte("overlay e"))
+	batch.Delete([]byte("a"))
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	batch.Close()
+
+	if value, _ := overlay.Get([]byte("c")); value != nil {
+		t.Fatalf("deleted key is found: %s", value)
+	}
+	if value, _ := base.Get([]byte("c")); string(value) != "base c" {
+		t.Fatalf("base is changed: %s", value)
+	}
+
+	iterate := func(it dbm.Iterator, err error) string {
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer it.Close()
+
+		var result string
+		for ; it.Valid(); it.Next() {
+			result += string(it.Key()) + "=" + string(it.Value()) + ";"
+		}
+		return result
+	}
+
+	if result := iterate(overlay.Iterator(nil, nil)); result != "b=overlay b;d=base d;e=overlay e;" {
+		t.Fatalf("unexpected iteration: %s", result)
+	}
+	if result := iterate(overlay.ReverseIterator([]byte("b"), []byte("e"))); result != "d=base d;b=overlay b;" {
+		t.Fatalf("unexpected reverse iteration: %s", result)
+	}
+}
@@ -0,0 +1,96 @@
+package tree
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+)
+
+// Change is a change of the value of the key, Old is nil if the key is created and New is nil if the key is removed
+type Change struct {
+	Key []byte
+	Old []byte
+	New []byte
+}
+
+// RecordingTree is MTree which records changes of values made by Set and Remove
+type RecordingTree struct {
+	MTree
+
+	lock    sync.Mutex
+	changes []Change
+	index   map[string]int
+}
+
+// NewRecordingTree wraps given tree to record changes made through the wrapper
+func NewRecordingTree(tree MTree) *RecordingTree {
+	return &RecordingTree{
+		MTree: tree,
+		index: map[string]int{},
+	}
+}
+
+// Set sets the value of the key and records the change if the value differs from the current one
+func (t *RecordingTree) Set(key, value []byte) bool {
+	_, old := t.MTree.Get(key)
+	t.record(key, old, value)
+
+	return t.MTree.Set(key, value)
+}
+
+// Remove removes the key and records the change if the key exists
+func (t *RecordingTree) Remove(key []byte) ([]byte, bool) {
+	old, removed := t.MTree.Remove(key)
+	if removed {
+		t.record(key, old, nil)
+	}
+
+	return old, removed
+}
+
+// Changes returns changes recorded since the previous call ordered by keys, changes of the same key are merged
+func (t *RecordingTree) Changes() []Change {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	changes := make([]Change, 0, len(t.changes))
+	for _, change := range t.changes {
+		if !bytes.Equal(change.Old, change.New) || (change.Old == nil) != (change.New == nil) {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+
+	t.changes = nil
+	t.index = map[string]int{}
+
+	return changes
+}
+
+func (t *RecordingTree) record(key, old, value []byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if i, ok := t.index[string(key)]; ok {
+		t.changes[i].New = copyBytes(value)
+		return
+	}
+
+	t.index[string(key)] = len(t.changes)
+	t.changes = append(t.changes, Change{
+		Key: copyBytes(key),
+		Old: copyBytes(old),
+		New: copyBytes(value),
+	})
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	return append([]byte{}, b...)
+}
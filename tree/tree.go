@@ -12,6 +12,7 @@ type ReadOnlyTree interface {
 	Version() int64
 	Hash() []byte
 	Iterate(fn func(key []byte, value []byte) bool) (stopped bool)
+	IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool)
 }
 
 // MTree mutable tree, used for txs delivery
@@ -90,6 +91,13 @@ func (t *mutableTree) Iterate(fn func(key []byte, value []byte) bool) (stopped b
 	return t.tree.Iterate(fn)
 }
 
+func (t *mutableTree) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.tree.IterateRange(start, end, ascending, fn)
+}
+
 func (t *mutableTree) Hash() []byte {
 	t.lock.RLock()
 	defer t.lock.RUnlock()
@@ -226,6 +234,28 @@ func (t *ImmutableTree) Iterate(fn func(key []byte, value []byte) bool) (stopped
 	return t.tree.Iterate(fn)
 }
 
+// IterateRange iterates over keys of the tree in [start, end), in given order. The keys and values must not be modified,
+// since they may point to data stored within IAVL.
+func (t *ImmutableTree) IterateRange(start, end []byte, ascending bool, fn func(key []byte, value []byte) bool) (stopped bool) {
+	return t.tree.IterateRange(start, end, ascending, fn)
+}
+
+// GetWithProof returns value of the key if it exists, or nil, and the proof of the single leaf
+// at the key or preceding it against the root hash.
+func (t *ImmutableTree) GetWithProof(key []byte) (value []byte, proof *iavl.RangeProof, err error) {
+	return t.tree.GetWithProof(key)
+}
+
+// GetByIndex returns the key and value at given index of the tree
+func (t *ImmutableTree) GetByIndex(index int64) (key []byte, value []byte) {
+	return t.tree.GetByIndex(index)
+}
+
+// Size returns the number of leaf nodes in the tree.
+func (t *ImmutableTree) Size() int64 {
+	return t.tree.Size()
+}
+
 // Hash returns the root hash.
 func (t *ImmutableTree) Hash() []byte {
 	return t.tree.Hash()
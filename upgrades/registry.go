@@ -0,0 +1,84 @@
+package upgrades
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+// Names of upgrades which switch behaviour of the network
+const (
+	CommissionUpdate = "commission_update"
+	TxExpiry         = "tx_expiry"
+	CheckCancel      = "check_cancel"
+	PartialChecks    = "partial_checks"
+)
+
+// Upgrade is a named change of behaviour of the network activated at the height
+type Upgrade struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Height  uint64 `json:"height"`
+
+	// Supported is false if the upgrade is only announced and this build of the node doesn't implement it yet
+	Supported bool `json:"supported"`
+}
+
+// schedules of upgrades by networks. Upgrades change consensus rules, so they are scheduled here only
+// once the height of activation is coordinated with validators, until then they are never active.
+var schedules = map[types.ChainID][]Upgrade{
+	types.ChainMainnet: {},
+	types.ChainTestnet: {},
+}
+
+// SetSchedule replaces upgrades scheduled for the network
+func SetSchedule(chainID types.ChainID, upgrades []Upgrade) {
+	sorted := make([]Upgrade, len(upgrades))
+	copy(sorted, upgrades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	schedules[chainID] = sorted
+}
+
+// Schedule returns upgrades scheduled for the current network in order of heights
+func Schedule() []Upgrade {
+	return schedules[types.CurrentChainID]
+}
+
+// IsActive returns true if the upgrade is activated at given height.
+// Upgrades which are not scheduled for the current network are never active.
+func IsActive(name string, height uint64) bool {
+	for _, upgrade := range Schedule() {
+		if upgrade.Name == name {
+			return height >= upgrade.Height
+		}
+	}
+
+	return false
+}
+
+// Next returns the first upgrade scheduled after given height, nil if there is none
+func Next(height uint64) *Upgrade {
+	for _, upgrade := range Schedule() {
+		if upgrade.Height > height {
+			return &upgrade
+		}
+	}
+
+	return nil
+}
+
+// CheckSupported returns error if an upgrade which is not implemented by this build is active at given height
+func CheckSupported(height uint64) error {
+	for _, upgrade := range Schedule() {
+		if height >= upgrade.Height && !upgrade.Supported {
+			return fmt.Errorf("upgrade %s is activated at height %d, node version %s or newer is required",
+				upgrade.Name, upgrade.Height, upgrade.Version)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package upgrades
+
+import (
+	"testing"
+
+	"github.com/noah-blockchain/noah-go-node/core/types"
+)
+
+func TestSchedule(t *testing.T) {
+	defer SetSchedule(types.CurrentChainID, Schedule())
+	SetSchedule(types.CurrentChainID, []Upgrade{
+		{Name: "second", Version: "2.0.0", Height: 200},
+		{Name: "first", Version: "1.0.0", Height: 100, Supported: true},
+	})
+
+	if IsActive("first", 99) || !IsActive("first", 100) {
+		t.Fatal("Wrong activation of the first upgrade")
+	}
+
+	if IsActive("unknown", 1000) {
+		t.Fatal("Upgrade which is not scheduled is active")
+	}
+
+	if next := Next(50); next == nil || next.Name != "first" {
+		t.Fatalf("Wrong next upgrade at height 50: %v", next)
+	}
+
+	if next := Next(100); next == nil || next.Name != "second" {
+		t.Fatalf("Wrong next upgrade at height 100: %v", next)
+	}
+
+	if next := Next(200); next != nil {
+		t.Fatalf("Wrong next upgrade at height 200: %v", next)
+	}
+
+	if err := CheckSupported(199); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckSupported(200); err == nil {
+		t.Fatal("Unsupported upgrade is not detected")
+	}
+}